@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -14,8 +16,12 @@ import (
 
 	"github.com/mark3labs/mcp-go/server" // Import directly without alias
 
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
 	"github.com/tuannvm/haproxy-mcp-server/internal/config"
 	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy/common"
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+	statsclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/stats"
 	"github.com/tuannvm/haproxy-mcp-server/internal/mcp"
 )
 
@@ -55,7 +61,8 @@ func main() {
 			Level: logLevel,
 		})
 	}
-	slog.SetDefault(slog.New(handler))
+	// Tag records with the authenticated client identity, if any
+	slog.SetDefault(slog.New(auth.NewLogHandler(handler)))
 
 	slog.Info("Starting HAProxy MCP Server...")
 	slog.Info("Loaded configuration", "config", cfg)
@@ -97,6 +104,34 @@ func main() {
 			}
 			runtimeAPIURL = u.String()
 
+		case "tls":
+			// TLS over TCP mode
+			if cfg.HAProxyHost == "" {
+				slog.Error("HAProxy host is empty. Please set HAPROXY_HOST env variable.")
+				os.Exit(1)
+			}
+
+			u := &url.URL{
+				Scheme: "tls",
+				Host:   fmt.Sprintf("%s:%d", cfg.HAProxyHost, cfg.HAProxyPort),
+			}
+			runtimeAPIURL = u.String()
+
+		case "ssh":
+			// Remote Unix socket reached through an SSH tunnel
+			if cfg.HAProxySSHHost == "" || cfg.HAProxyRuntimeSocket == "" {
+				slog.Error("SSH mode requires HAPROXY_SSH_HOST and HAPROXY_RUNTIME_SOCKET env variables.")
+				os.Exit(1)
+			}
+
+			u := &url.URL{
+				Scheme: "ssh",
+				User:   url.User(cfg.HAProxySSHUser),
+				Host:   fmt.Sprintf("%s:%d", cfg.HAProxySSHHost, cfg.HAProxySSHPort),
+				Path:   cfg.HAProxyRuntimeSocket,
+			}
+			runtimeAPIURL = u.String()
+
 		default:
 			if cfg.HAProxyStatsEnabled && cfg.HAProxyStatsURL != "" {
 				slog.Warn("Invalid HAProxy runtime mode, but stats API is enabled. Continuing with stats only.",
@@ -126,51 +161,229 @@ func main() {
 
 	slog.Info("Connecting to HAProxy", "runtimeAPIURL", runtimeAPIURL, "statsURL", statsURL)
 
+	statsHeaders, err := config.ParseHeaders(cfg.HAProxyStatsHeaders)
+	if err != nil {
+		slog.Error("Invalid HAProxy stats headers", "error", err)
+		os.Exit(1)
+	}
+
+	clientOptions := haproxy.Options{
+		Runtime: runtimeclient.ClientOptions{
+			Timeout: time.Duration(cfg.HAProxyRuntimeTimeout) * time.Second,
+			Retry: runtimeclient.RetryPolicy{
+				MaxAttempts:    cfg.HAProxyRuntimeMaxAttempts,
+				InitialBackoff: time.Duration(cfg.HAProxyRuntimeRetryBackoff) * time.Millisecond,
+			},
+			TLS: common.TLSOptions{
+				CAFile:             cfg.HAProxyRuntimeCAFile,
+				CertFile:           cfg.HAProxyRuntimeCertFile,
+				KeyFile:            cfg.HAProxyRuntimeKeyFile,
+				ServerName:         cfg.HAProxyRuntimeServerName,
+				InsecureSkipVerify: cfg.HAProxyRuntimeInsecureSkipVerify,
+			},
+			SSH: runtimeclient.SSHOptions{
+				User:                  cfg.HAProxySSHUser,
+				Password:              cfg.HAProxySSHPassword,
+				KeyFile:               cfg.HAProxySSHKeyFile,
+				KeyPassphrase:         cfg.HAProxySSHKeyPassphrase,
+				UseAgent:              cfg.HAProxySSHUseAgent,
+				KnownHostsFile:        cfg.HAProxySSHKnownHosts,
+				InsecureIgnoreHostKey: cfg.HAProxySSHInsecureIgnoreHostKey,
+			},
+		},
+		Stats: statsclient.ClientOptions{
+			Username:    cfg.HAProxyStatsUsername,
+			Password:    cfg.HAProxyStatsPassword,
+			BearerToken: cfg.HAProxyStatsBearerToken,
+			Headers:     statsHeaders,
+			TLS: common.TLSOptions{
+				CAFile:             cfg.HAProxyStatsCAFile,
+				CertFile:           cfg.HAProxyStatsCertFile,
+				KeyFile:            cfg.HAProxyStatsKeyFile,
+				InsecureSkipVerify: cfg.HAProxyStatsInsecureSkipVerify,
+			},
+			Timeout: time.Duration(cfg.HAProxyStatsTimeout) * time.Second,
+		},
+	}
+
 	// Create the HAProxy client with the appropriate URLs
-	haproxyClient, err := haproxy.NewHAProxyClient(runtimeAPIURL, statsURL)
+	haproxyClient, err := haproxy.NewHAProxyClientWithOptions(runtimeAPIURL, statsURL, clientOptions)
 	if err != nil {
 		// Log fatal here as the client is essential for the server's function
 		slog.Error("Failed to initialize HAProxy client", "error", err)
 		os.Exit(1)
 	}
 
+	// Restrict tools, resources, subscriptions, prompts and completions per identity when a policy is configured
+	var policy *auth.Policy
+	if cfg.MCPPolicyFile != "" {
+		policy, err = auth.LoadPolicyFile(cfg.MCPPolicyFile)
+		if err != nil {
+			slog.Error("Failed to load authorization policy", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Authorization policy loaded", "file", cfg.MCPPolicyFile, "rules", len(policy.Rules), "default", policy.Default)
+	}
+
 	// --- MCP Server ---
-	// Create MCP Server with name and version
-	mcpServer := server.NewMCPServer("haproxy-mcp-server", "0.1.0")
+	// Create MCP Server with name and version; resource subscriptions are tracked outside mcp-go
+	subscriptions := mcp.NewSubscriptions(policy)
+	serverOptions := []server.ServerOption{
+		server.WithResourceCapabilities(true, false),
+		server.WithLogging(),
+		server.WithPromptCapabilities(false),
+		server.WithHooks(subscriptions.Hooks()),
+	}
+	if policy != nil {
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(mcp.PolicyMiddleware(policy)))
+	}
+
+	// Hold destructive tool calls until approve_change confirms them
+	var approvals *mcp.ApprovalGate
+	if cfg.MCPApprovalMode {
+		tools := config.ParseList(cfg.MCPApprovalTools)
+		if len(tools) == 0 {
+			tools = mcp.DefaultApprovalTools
+		}
+		approvals = mcp.NewApprovalGate(tools, time.Duration(cfg.MCPApprovalTTL)*time.Second)
+		slog.Info("Approval mode enabled", "tools", tools, "ttl_seconds", cfg.MCPApprovalTTL)
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(approvals.Middleware()))
+	}
+	mcpServer := server.NewMCPServer("haproxy-mcp-server", "0.1.0", serverOptions...)
 
 	// --- Register Tools ---
 	mcp.RegisterTools(mcpServer, haproxyClient) // Use mcp.RegisterTools instead of tools.RegisterTools
 
+	// Raw commands are checked against the command allowlist and denylist
+	commandDeny := config.ParseList(cfg.MCPCommandDeny)
+	if len(commandDeny) == 0 {
+		commandDeny = mcp.DefaultCommandDeny
+	}
+	mcp.NewCommandGuard(config.ParseList(cfg.MCPCommandAllow), commandDeny, cfg.MCPCommandAllowWrites).Register(mcpServer, haproxyClient, policy)
+
+	// Only offer the tools the connected HAProxy version supports
+	if capabilities, err := haproxyClient.ProbeCapabilities(); err != nil {
+		slog.Warn("Failed to probe HAProxy capabilities, registering all tools", "error", err)
+	} else {
+		removed := mcp.RemoveUnsupportedTools(mcpServer, capabilities)
+		slog.Info("HAProxy capabilities probed", "version", capabilities.Version, "commands", len(capabilities.Commands), "unsupported_tools", removed)
+	}
+	if approvals != nil {
+		approvals.Register(mcpServer)
+	}
+
+	// Output schemas, annotation hints and structured content are completed on the way out
+	outputs := mcp.NewOutputRewriter(approvals)
+
+	// Argument completion for backend, server, frontend and map names
+	completions := mcp.NewCompletions(haproxyClient, time.Duration(cfg.MCPCompletionCacheTTL)*time.Second, policy)
+
+	// --- Register Resources ---
+	mcp.RegisterResources(mcpServer, haproxyClient, policy)
+
+	// --- Register Prompts ---
+	mcp.RegisterPrompts(mcpServer, haproxyClient, policy)
+
 	// --- Context and Shutdown Handling ---
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	// --- Resource Change Notifications ---
+	var watcher *mcp.StateWatcher
+	if cfg.MCPResourcePollInterval > 0 {
+		watcher = mcp.NewStateWatcher(mcpServer, haproxyClient, subscriptions, time.Duration(cfg.MCPResourcePollInterval)*time.Second)
+		go watcher.Run(ctx)
+	}
+
 	// --- Transport Handling ---
 	switch cfg.MCPTransport {
 	case "stdio":
 		slog.Info("Running MCP server in stdio mode")
-		// Use ServeStdio for stdio mode
-		if err := server.ServeStdio(mcpServer); err != nil {
+		// Listen on stdio, intercepting resource subscription and completion requests
+		stdioServer := server.NewStdioServer(mcpServer)
+		stdioServer.SetErrorLogger(log.New(os.Stderr, "", log.LstdFlags))
+		stdout := mcp.NewStdioWriter(os.Stdout)
+		if watcher != nil {
+			watcher.AddNotifier(stdout.Notify)
+		}
+		if err := stdioServer.Listen(ctx, completions.StdioReader(subscriptions.StdioReader(os.Stdin)), completions.Writer(outputs.Writer(stdout))); err != nil && !errors.Is(err, context.Canceled) {
 			slog.Error("MCP server exited with error (stdio)", "error", err)
 			os.Exit(1)
 		}
 		slog.Info("MCP server (stdio) finished gracefully")
 
-	case "http":
+	case "http", "streamable-http":
 		addr := fmt.Sprintf(":%d", cfg.MCPPort)
 
-		// Create an SSE server
-		sseServer := server.NewSSEServer(mcpServer)
+		// Authentication for the MCP endpoints
+		authenticators, err := auth.NewAuthenticators(auth.Options{
+			TokensFile:  cfg.MCPAuthTokensFile,
+			ClientCerts: cfg.MCPTLSClientCAFile != "",
+			JWT: auth.JWTOptions{
+				JWKSFile: cfg.MCPJWTJWKSFile,
+				Issuer:   cfg.MCPJWTIssuer,
+				Audience: cfg.MCPJWTAudience,
+			},
+		})
+		if err != nil {
+			slog.Error("Failed to initialize HTTP authentication", "error", err)
+			os.Exit(1)
+		}
+		if len(authenticators) == 0 {
+			slog.Warn("HTTP authentication is disabled; anyone who can reach MCP_PORT can manage HAProxy")
+		}
+
+		mcpMux := http.NewServeMux()
+
+		// SSE transport on /sse and /message
+		if cfg.MCPTransport == "http" || cfg.MCPHTTPServeBoth {
+			sseServer := mcp.NewSSEServer(mcpServer)
+			mcpMux.Handle(sseServer.CompleteSsePath(), sseServer)
+			mcpMux.Handle(sseServer.CompleteMessagePath(), sseServer)
+			if watcher != nil {
+				watcher.AddNotifier(mcp.SSENotifier(sseServer))
+			}
+			slog.Info("Serving MCP SSE transport", "sse", sseServer.CompleteSsePath(), "message", sseServer.CompleteMessagePath())
+		}
+
+		// Streamable HTTP transport
+		var streamableServer *mcp.StreamableHTTPServer
+		if cfg.MCPTransport == "streamable-http" || cfg.MCPHTTPServeBoth {
+			streamableServer = mcp.NewStreamableHTTPServer(mcpServer, time.Duration(cfg.MCPSessionIdleTimeout)*time.Second)
+			mcpMux.Handle(cfg.MCPStreamablePath, streamableServer)
+			slog.Info("Serving MCP streamable HTTP transport", "path", cfg.MCPStreamablePath)
+		}
+
+		// Health and readiness endpoints share the listener but skip authentication so probes work
+		mux := http.NewServeMux()
+		mux.Handle("/healthz", mcp.HealthHandler())
+		mux.Handle("/readyz", mcp.ReadinessHandler(haproxyClient.PingWithContext))
+		// Authenticate before intercepting resource subscription and completion requests
+		mux.Handle("/", auth.Middleware(authenticators, subscriptions.HTTPMiddleware(completions.HTTPMiddleware(outputs.HTTPMiddleware(mcpMux)))))
 
-		// Create HTTP server with SSE handler
 		httpServer := &http.Server{
 			Addr:    addr,
-			Handler: sseServer,
+			Handler: mux,
+		}
+
+		if cfg.MCPTLSCertFile != "" {
+			tlsConfig, err := auth.NewServerTLSConfig(cfg.MCPTLSCertFile, cfg.MCPTLSKeyFile, cfg.MCPTLSClientCAFile)
+			if err != nil {
+				slog.Error("Failed to configure HTTPS", "error", err)
+				os.Exit(1)
+			}
+			httpServer.TLSConfig = tlsConfig
 		}
 
 		go func() {
-			slog.Info("Starting HTTP server for MCP SSE transport", "address", addr)
-			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Info("Starting HTTP server for MCP", "address", addr, "transport", cfg.MCPTransport, "tls", httpServer.TLSConfig != nil)
+			var err error
+			if httpServer.TLSConfig != nil {
+				err = httpServer.ListenAndServeTLS("", "")
+			} else {
+				err = httpServer.ListenAndServe()
+			}
+			if err != nil && err != http.ErrServerClosed {
 				slog.Error("HTTP server failed", "error", err)
 				os.Exit(1)
 			}
@@ -180,6 +393,11 @@ func main() {
 		<-ctx.Done()
 		slog.Info("Shutdown signal received, stopping HTTP server...")
 
+		// End streamable sessions so their open streams do not hold up shutdown
+		if streamableServer != nil {
+			streamableServer.Close()
+		}
+
 		// Graceful shutdown
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -0,0 +1,170 @@
+// Package auth authenticates clients of the HTTP transports and carries the
+// resulting identity through request contexts for auditing and authorization.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Authentication methods recorded on an Identity
+const (
+	MethodToken = "token"
+	MethodMTLS  = "mtls"
+	MethodJWT   = "jwt"
+)
+
+// ErrNoCredentials is returned by an Authenticator when the request carries no
+// credentials of the kind it handles
+var ErrNoCredentials = errors.New("no credentials")
+
+// Identity is an authenticated client
+type Identity struct {
+	Subject string   `json:"subject"`          // Token name, certificate subject or JWT sub claim
+	Method  string   `json:"method"`           // How the client authenticated
+	Groups  []string `json:"groups,omitempty"` // Group memberships, when the credential carries them
+
+	// Claims holds the raw JWT claims for policy matching; nil for other methods
+	Claims map[string]interface{} `json:"-"`
+}
+
+// LogValue implements slog.LogValuer
+func (i *Identity) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("subject", i.Subject), slog.String("method", i.Method))
+}
+
+// Authenticator verifies the credentials of an HTTP request
+type Authenticator interface {
+	// Authenticate returns the identity behind the request, ErrNoCredentials when
+	// the request has no credentials for this authenticator, or another error
+	// when the credentials are invalid.
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// Options selects the authenticators to enable
+type Options struct {
+	TokensFile  string // File of static bearer tokens
+	ClientCerts bool   // Accept verified TLS client certificates
+	JWT         JWTOptions
+}
+
+// NewAuthenticators builds the authenticators enabled by opts. An empty result means authentication is disabled.
+func NewAuthenticators(opts Options) ([]Authenticator, error) {
+	authenticators := make([]Authenticator, 0, 3)
+
+	if opts.ClientCerts {
+		authenticators = append(authenticators, CertificateAuthenticator{})
+	}
+
+	if opts.TokensFile != "" {
+		tokens, err := LoadTokenFile(opts.TokensFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, tokens)
+	}
+
+	if opts.JWT.JWKSFile != "" {
+		jwt, err := NewJWTAuthenticator(opts.JWT)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, jwt)
+	}
+
+	return authenticators, nil
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a context carrying the identity
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity carried by ctx, if any
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// Middleware authenticates each request with the first authenticator that accepts it
+// and stores the identity in the request context. Requests no authenticator accepts
+// are rejected with 401. With no authenticators, requests pass through unchanged.
+func Middleware(authenticators []Authenticator, next http.Handler) http.Handler {
+	if len(authenticators) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var failures []string
+		for _, a := range authenticators {
+			identity, err := a.Authenticate(r)
+			if err == nil {
+				next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), identity)))
+				return
+			}
+			if !errors.Is(err, ErrNoCredentials) {
+				failures = append(failures, err.Error())
+			}
+		}
+
+		if len(failures) > 0 {
+			slog.Warn("Rejected request with invalid credentials", "remote", r.RemoteAddr, "path", r.URL.Path, "reasons", strings.Join(failures, "; "))
+		} else {
+			slog.Debug("Rejected request without credentials", "remote", r.RemoteAddr, "path", r.URL.Path)
+		}
+		w.Header().Set("WWW-Authenticate", `Bearer realm="haproxy-mcp-server"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	})
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer" header
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", ErrNoCredentials
+	}
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", ErrNoCredentials
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("empty bearer token")
+	}
+	return token, nil
+}
+
+// logHandler adds the identity from the context to every log record
+type logHandler struct {
+	slog.Handler
+}
+
+// NewLogHandler wraps h so that records logged with a context carrying an
+// Identity include it, which makes tool execution logs an audit trail
+func NewLogHandler(h slog.Handler) slog.Handler {
+	return logHandler{Handler: h}
+}
+
+// Handle implements slog.Handler
+func (h logHandler) Handle(ctx context.Context, r slog.Record) error {
+	if identity, ok := FromContext(ctx); ok {
+		r.AddAttrs(slog.Any("identity", identity))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler
+func (h logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return logHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler
+func (h logHandler) WithGroup(name string) slog.Handler {
+	return logHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFile writes content to a file in a test temp directory
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// serve runs a request through the middleware and returns the status and the identity seen by the handler
+func serve(authenticators []Authenticator, r *http.Request) (int, *Identity) {
+	var seen *Identity
+	handler := Middleware(authenticators, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = FromContext(r.Context())
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	return rec.Code, seen
+}
+
+// TestTokenAuthenticator tests static bearer tokens through the middleware
+func TestTokenAuthenticator(t *testing.T) {
+	path := writeFile(t, "tokens", "# on-call tokens\nalice s3cret-a\n\nbob s3cret-b\n")
+	tokens, err := LoadTokenFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load tokens: %v", err)
+	}
+	authenticators := []Authenticator{tokens}
+
+	testCases := []struct {
+		name    string
+		header  string
+		status  int
+		subject string
+	}{
+		{name: "Valid token", header: "Bearer s3cret-b", status: http.StatusOK, subject: "bob"},
+		{name: "Unknown token", header: "Bearer wrong", status: http.StatusUnauthorized},
+		{name: "Basic scheme", header: "Basic YWxpY2U6eA==", status: http.StatusUnauthorized},
+		{name: "No credentials", status: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tc.header != "" {
+				r.Header.Set("Authorization", tc.header)
+			}
+			status, identity := serve(authenticators, r)
+			if status != tc.status {
+				t.Fatalf("Expected status %d, got %d", tc.status, status)
+			}
+			if tc.subject != "" && (identity == nil || identity.Subject != tc.subject || identity.Method != MethodToken) {
+				t.Errorf("Expected identity %s, got %+v", tc.subject, identity)
+			}
+		})
+	}
+
+	if _, err := LoadTokenFile(writeFile(t, "bad", "alice\n")); err == nil {
+		t.Error("Expected error for a line without a token")
+	}
+}
+
+// TestCertificateAuthenticator tests identities taken from verified client certificates
+func TestCertificateAuthenticator(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	if _, err := (CertificateAuthenticator{}).Authenticate(r); err != ErrNoCredentials {
+		t.Errorf("Expected ErrNoCredentials without TLS, got %v", err)
+	}
+
+	leaf := &x509.Certificate{Subject: pkix.Name{CommonName: "ops-bot", OrganizationalUnit: []string{"sre"}}}
+	r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf}}}
+	status, identity := serve([]Authenticator{CertificateAuthenticator{}}, r)
+	if status != http.StatusOK || identity == nil || identity.Subject != "ops-bot" || identity.Groups[0] != "sre" {
+		t.Errorf("Expected ops-bot in sre, got %d %+v", status, identity)
+	}
+}
+
+// TestMiddlewareDisabled tests that requests pass through when no authenticator is configured
+func TestMiddlewareDisabled(t *testing.T) {
+	status, identity := serve(nil, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	if status != http.StatusOK || identity != nil {
+		t.Errorf("Expected anonymous pass-through, got %d %+v", status, identity)
+	}
+}
+
+// TestLogHandler tests that log records carry the identity from the context
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewTextHandler(&buf, nil)))
+
+	ctx := WithIdentity(context.Background(), &Identity{Subject: "alice", Method: MethodJWT})
+	logger.InfoContext(ctx, "Executing del_server")
+	logger.Info("Unauthenticated record")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected two log lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], "identity.subject=alice") || !strings.Contains(lines[0], "identity.method=jwt") {
+		t.Errorf("Expected identity in record, got %s", lines[0])
+	}
+	if strings.Contains(lines[1], "identity") {
+		t.Errorf("Expected no identity without context, got %s", lines[1])
+	}
+}
@@ -0,0 +1,354 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// jwtLeeway tolerates clock skew when checking exp and nbf
+const jwtLeeway = time.Minute
+
+// JWTOptions configures validation of OAuth2/OIDC access tokens
+type JWTOptions struct {
+	JWKSFile string // JSON Web Key Set holding the signing keys
+	Issuer   string // Required iss claim, if set
+	Audience string // Required aud claim entry, if set
+}
+
+// jwtAlgorithm describes a supported signing algorithm
+type jwtAlgorithm struct {
+	hash crypto.Hash
+	kty  string // Key type the algorithm needs
+	pss  bool   // RSASSA-PSS instead of PKCS #1 v1.5
+}
+
+// jwtAlgorithms lists the accepted signing algorithms; "none" and HMAC are deliberately absent
+var jwtAlgorithms = map[string]jwtAlgorithm{
+	"RS256": {hash: crypto.SHA256, kty: "RSA"},
+	"RS384": {hash: crypto.SHA384, kty: "RSA"},
+	"RS512": {hash: crypto.SHA512, kty: "RSA"},
+	"PS256": {hash: crypto.SHA256, kty: "RSA", pss: true},
+	"PS384": {hash: crypto.SHA384, kty: "RSA", pss: true},
+	"PS512": {hash: crypto.SHA512, kty: "RSA", pss: true},
+	"ES256": {hash: crypto.SHA256, kty: "EC"},
+	"ES384": {hash: crypto.SHA384, kty: "EC"},
+	"ES512": {hash: crypto.SHA512, kty: "EC"},
+}
+
+// jwk is a single entry of a JSON Web Key Set
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// verificationKey is a parsed signing key
+type verificationKey struct {
+	kty string
+	alg string // Algorithm the key is restricted to, if any
+	key crypto.PublicKey
+}
+
+// JWTAuthenticator accepts bearer JWTs signed by a key from a JWKS file
+type JWTAuthenticator struct {
+	keys     map[string]verificationKey // kid -> key
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// NewJWTAuthenticator loads the signing keys from opts.JWKSFile
+func NewJWTAuthenticator(opts JWTOptions) (*JWTAuthenticator, error) {
+	data, err := os.ReadFile(opts.JWKSFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file %s: %w", opts.JWKSFile, err)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %s: %w", opts.JWKSFile, err)
+	}
+
+	a := &JWTAuthenticator{
+		keys:     make(map[string]verificationKey),
+		issuer:   opts.Issuer,
+		audience: opts.Audience,
+		now:      time.Now,
+	}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q in JWKS file %s: %w", k.Kid, opts.JWKSFile, err)
+		}
+		a.keys[k.Kid] = key
+	}
+	if len(a.keys) == 0 {
+		return nil, fmt.Errorf("JWKS file %s contains no signing keys", opts.JWKSFile)
+	}
+
+	return a, nil
+}
+
+// parseJWK converts an RSA or EC JWK into a public key
+func parseJWK(k jwk) (verificationKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return verificationKey{}, fmt.Errorf("modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() {
+			return verificationKey{}, fmt.Errorf("invalid exponent")
+		}
+		return verificationKey{kty: k.Kty, alg: k.Alg, key: &rsa.PublicKey{N: n, E: int(e.Int64())}}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return verificationKey{}, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return verificationKey{}, fmt.Errorf("x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return verificationKey{}, fmt.Errorf("y coordinate: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return verificationKey{}, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+		return verificationKey{kty: k.Kty, alg: k.Alg, key: &ecdsa.PublicKey{Curve: curve, X: x, Y: y}}, nil
+
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// jwtClaims holds the registered claims checked by the authenticator
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *float64        `json:"exp"`
+	NotBefore *float64        `json:"nbf"`
+	Groups    json.RawMessage `json:"groups"`
+
+	raw map[string]interface{} // Every claim, for policy matching
+}
+
+// Authenticate implements Authenticator
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := a.verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT: %w", err)
+	}
+	return &Identity{Subject: claims.Subject, Method: MethodJWT, Groups: claimStrings(claims.Groups), Claims: claims.raw}, nil
+}
+
+// verify checks the signature and claims of a compact JWS token
+func (a *JWTAuthenticator) verify(token string) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+
+	alg, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm %q", header.Alg)
+	}
+	key, err := a.key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if key.kty != alg.kty || (key.alg != "" && key.alg != header.Alg) {
+		return nil, fmt.Errorf("algorithm %s does not match key %q", header.Alg, header.Kid)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("signature: invalid encoding")
+	}
+	h := alg.hash.New()
+	h.Write([]byte(parts[0] + "." + parts[1]))
+	if err := verifySignature(key.key, alg, h.Sum(nil), signature); err != nil {
+		return nil, err
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("claims: %w", err)
+	}
+	if err := decodeSegment(parts[1], &claims.raw); err != nil {
+		return nil, fmt.Errorf("claims: %w", err)
+	}
+	if err := a.checkClaims(&claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// key returns the verification key for kid. Tokens without kid are accepted when the set has a single key.
+func (a *JWTAuthenticator) key(kid string) (verificationKey, error) {
+	if key, ok := a.keys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key, nil
+		}
+	}
+	return verificationKey{}, fmt.Errorf("unknown key %q", kid)
+}
+
+// verifySignature checks a signature over digest
+func verifySignature(key crypto.PublicKey, alg jwtAlgorithm, digest, signature []byte) error {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		var err error
+		if alg.pss {
+			err = rsa.VerifyPSS(k, alg.hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			err = rsa.VerifyPKCS1v15(k, alg.hash, digest, signature)
+		}
+		if err != nil {
+			return fmt.Errorf("signature verification failed")
+		}
+		return nil
+
+	case *ecdsa.PublicKey:
+		// JWS encodes ECDSA signatures as the fixed-size concatenation r || s
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("signature verification failed")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return fmt.Errorf("signature verification failed")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// checkClaims validates the time, issuer, audience and subject claims
+func (a *JWTAuthenticator) checkClaims(claims *jwtClaims) error {
+	now := a.now()
+
+	if claims.ExpiresAt == nil {
+		return fmt.Errorf("missing exp claim")
+	}
+	if now.After(time.Unix(int64(*claims.ExpiresAt), 0).Add(jwtLeeway)) {
+		return fmt.Errorf("token expired")
+	}
+	if claims.NotBefore != nil && now.Add(jwtLeeway).Before(time.Unix(int64(*claims.NotBefore), 0)) {
+		return fmt.Errorf("token not valid yet")
+	}
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+	if a.audience != "" && !hasAudience(claims.Audience, a.audience) {
+		return fmt.Errorf("token is not intended for audience %q", a.audience)
+	}
+	if claims.Subject == "" {
+		return fmt.Errorf("missing sub claim")
+	}
+	return nil
+}
+
+// hasAudience reports whether the aud claim, a string or an array of strings, contains audience
+func hasAudience(raw json.RawMessage, audience string) bool {
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return single == audience
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		for _, aud := range list {
+			if aud == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// claimStrings flattens a claim given as a string or an array of strings.
+// Other values yield no strings.
+func claimStrings(raw json.RawMessage) []string {
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return []string{single}
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	return nil
+}
+
+// decodeSegment decodes a base64url JSON segment of a token
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("invalid encoding")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid JSON")
+	}
+	return nil
+}
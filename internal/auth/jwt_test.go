@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// b64 encodes bytes as base64url without padding
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// signJWT builds a compact JWT signed with key
+func signJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signingInput := b64(header) + "." + b64(payload)
+
+	h := jwtAlgorithms[alg].hash.New()
+	h.Write([]byte(signingInput))
+	digest := h.Sum(nil)
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, jwtAlgorithms[alg].hash, digest)
+		if err != nil {
+			t.Fatalf("Failed to sign: %v", err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		if err != nil {
+			t.Fatalf("Failed to sign: %v", err)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		signature = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+	}
+	return signingInput + "." + b64(signature)
+}
+
+// TestJWTAuthenticator tests signature, algorithm and claim validation
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate EC key: %v", err)
+	}
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	jwks, _ := json.Marshal(map[string]interface{}{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa1", "use": "sig", "alg": "RS256", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec1", "crv": "P-256", "x": b64(ecKey.X.Bytes()), "y": b64(ecKey.Y.Bytes())},
+	}})
+	a, err := NewJWTAuthenticator(JWTOptions{
+		JWKSFile: writeFile(t, "jwks.json", string(jwks)),
+		Issuer:   "https://idp.example.com",
+		Audience: "haproxy-mcp",
+	})
+	if err != nil {
+		t.Fatalf("Failed to load JWKS: %v", err)
+	}
+	now := time.Unix(1_800_000_000, 0)
+	a.now = func() time.Time { return now }
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":    "alice",
+			"iss":    "https://idp.example.com",
+			"aud":    []string{"other", "haproxy-mcp"},
+			"exp":    now.Add(time.Hour).Unix(),
+			"groups": []string{"sre"},
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	testCases := []struct {
+		name  string
+		token string
+		err   string
+	}{
+		{name: "RS256", token: signJWT(t, "RS256", "rsa1", rsaKey, claims(nil))},
+		{name: "ES256", token: signJWT(t, "ES256", "ec1", ecKey, claims(map[string]interface{}{"aud": "haproxy-mcp"}))},
+		{name: "Single group", token: signJWT(t, "RS256", "rsa1", rsaKey, claims(map[string]interface{}{"groups": "sre"}))},
+		{name: "Wrong signing key", token: signJWT(t, "RS256", "rsa1", otherKey, claims(nil)), err: "signature verification failed"},
+		{name: "Algorithm not allowed for key", token: signJWT(t, "RS384", "rsa1", rsaKey, claims(nil)), err: "does not match key"},
+		{name: "Unsupported algorithm", token: b64([]byte(`{"alg":"none"}`)) + "." + b64([]byte(`{"sub":"alice"}`)) + ".", err: "unsupported algorithm"},
+		{name: "Unknown key", token: signJWT(t, "RS256", "missing", rsaKey, claims(nil)), err: "unknown key"},
+		{name: "Expired", token: signJWT(t, "RS256", "rsa1", rsaKey, claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})), err: "token expired"},
+		{name: "Not yet valid", token: signJWT(t, "RS256", "rsa1", rsaKey, claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()})), err: "not valid yet"},
+		{name: "Wrong issuer", token: signJWT(t, "RS256", "rsa1", rsaKey, claims(map[string]interface{}{"iss": "https://evil.example.com"})), err: "unexpected issuer"},
+		{name: "Wrong audience", token: signJWT(t, "RS256", "rsa1", rsaKey, claims(map[string]interface{}{"aud": "other"})), err: "audience"},
+		{name: "Malformed", token: "not-a-jwt", err: "malformed token"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			r.Header.Set("Authorization", "Bearer "+tc.token)
+			identity, err := a.Authenticate(r)
+
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("Expected error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if identity.Subject != "alice" || identity.Method != MethodJWT || len(identity.Groups) != 1 || identity.Groups[0] != "sre" {
+				t.Errorf("Unexpected identity: %+v", identity)
+			}
+		})
+	}
+}
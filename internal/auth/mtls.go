@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// CertificateAuthenticator accepts TLS client certificates verified against the
+// server's client CA pool (see NewServerTLSConfig)
+type CertificateAuthenticator struct{}
+
+// Authenticate implements Authenticator. The subject is the certificate's common
+// name, falling back to its first DNS or email SAN; organizational units become groups.
+func (CertificateAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	leaf := r.TLS.VerifiedChains[0][0]
+	subject := leaf.Subject.CommonName
+	if subject == "" && len(leaf.DNSNames) > 0 {
+		subject = leaf.DNSNames[0]
+	}
+	if subject == "" && len(leaf.EmailAddresses) > 0 {
+		subject = leaf.EmailAddresses[0]
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("client certificate has no usable subject")
+	}
+
+	return &Identity{Subject: subject, Method: MethodMTLS, Groups: leaf.Subject.OrganizationalUnit}, nil
+}
+
+// NewServerTLSConfig builds the TLS configuration of the HTTP listener. When
+// clientCAFile is set, client certificates signed by those CAs are verified;
+// they stay optional at the TLS layer so other authenticators can still be used.
+func NewServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("both server certificate and key files must be provided")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file %s: %w", clientCAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates found in client CA file %s", clientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
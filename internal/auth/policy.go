@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy decisions for requests no rule matches
+const (
+	DecisionAllow = "allow"
+	DecisionDeny  = "deny"
+)
+
+// Policy maps identities to the tools, backends and servers they may use.
+// A request is allowed when any rule matching its identity allows it.
+type Policy struct {
+	// Default applies to identities no rule matches: "deny" (default) or "allow"
+	Default string       `yaml:"default"`
+	Rules   []PolicyRule `yaml:"rules"`
+}
+
+// PolicyRule grants an identity access to tools and targets. Patterns use
+// path.Match syntax, e.g. "payments-*".
+type PolicyRule struct {
+	Name string `yaml:"name"`
+
+	// Identity selectors. An identity matches when it authenticated with one of
+	// Methods (any, if empty) and matches any of Subjects, Groups or Claims.
+	// A rule without subject, group or claim selectors matches every
+	// authenticated identity, unless it is an Anonymous rule, which matches
+	// unauthenticated callers.
+	Subjects  []string            `yaml:"subjects"`
+	Groups    []string            `yaml:"groups"`
+	Claims    map[string][]string `yaml:"claims"` // JWT claim -> accepted values
+	Methods   []string            `yaml:"methods"`
+	Anonymous bool                `yaml:"anonymous"`
+
+	// Grants. Tools must be listed; empty Backends or Servers allow all.
+	// When Backends is set, tools that do not target a backend are only
+	// granted if Tools names them exactly, as patterns could grant them by
+	// accident.
+	Tools    []string `yaml:"tools"`
+	Backends []string `yaml:"backends"`
+	Servers  []string `yaml:"servers"`
+}
+
+// Request describes a tool call to authorize
+type Request struct {
+	Tool        string
+	Backend     string // Backend targeted by the call, if any
+	Server      string // Server targeted by the call, if any
+	AllBackends bool   // The call spans every backend, e.g. show_servers_state without a backend
+	Unscoped    bool   // The tool does not target a backend, e.g. reload_haproxy
+}
+
+// LoadPolicyFile reads and validates a YAML policy file
+func LoadPolicyFile(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file %s: %w", file, err)
+	}
+
+	var p Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", file, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", file, err)
+	}
+	return &p, nil
+}
+
+// Validate checks the default decision and every pattern of the policy
+func (p *Policy) Validate() error {
+	switch p.Default {
+	case "":
+		p.Default = DecisionDeny
+	case DecisionAllow, DecisionDeny:
+	default:
+		return fmt.Errorf("default must be %q or %q, got %q", DecisionAllow, DecisionDeny, p.Default)
+	}
+
+	for i, rule := range p.Rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		if len(rule.Tools) == 0 {
+			return fmt.Errorf("rule %s lists no tools", name)
+		}
+		patterns := [][]string{rule.Subjects, rule.Groups, rule.Tools, rule.Backends, rule.Servers}
+		for _, values := range rule.Claims {
+			patterns = append(patterns, values)
+		}
+		for _, list := range patterns {
+			for _, pattern := range list {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("rule %s: invalid pattern %q", name, pattern)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Authorize returns nil when identity may make the request. identity is nil
+// for unauthenticated callers.
+func (p *Policy) Authorize(identity *Identity, req Request) error {
+	matched := false
+	for _, rule := range p.Rules {
+		if !rule.matchesIdentity(identity) {
+			continue
+		}
+		matched = true
+		if rule.allows(req) {
+			return nil
+		}
+	}
+
+	if !matched && p.Default == DecisionAllow {
+		return nil
+	}
+	return &DeniedError{Identity: identity, Request: req, NoRule: !matched}
+}
+
+// matchesIdentity reports whether the rule selects identity
+func (r PolicyRule) matchesIdentity(identity *Identity) bool {
+	if identity == nil {
+		return r.Anonymous
+	}
+	if len(r.Methods) > 0 && !contains(r.Methods, identity.Method) {
+		return false
+	}
+	if len(r.Subjects) == 0 && len(r.Groups) == 0 && len(r.Claims) == 0 {
+		return !r.Anonymous
+	}
+	if matchAny(r.Subjects, identity.Subject) {
+		return true
+	}
+	for _, group := range identity.Groups {
+		if matchAny(r.Groups, group) {
+			return true
+		}
+	}
+	for claim, patterns := range r.Claims {
+		for _, value := range claimValues(identity.Claims[claim]) {
+			if matchAny(patterns, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allows reports whether the rule grants the request
+func (r PolicyRule) allows(req Request) bool {
+	if !matchAny(r.Tools, req.Tool) {
+		return false
+	}
+	restricted := len(r.Backends) > 0 && !contains(r.Backends, "*")
+	if req.AllBackends && restricted {
+		return false
+	}
+	if req.Unscoped && restricted && !contains(r.Tools, req.Tool) {
+		return false
+	}
+	if req.Backend != "" && len(r.Backends) > 0 && !matchAny(r.Backends, req.Backend) {
+		return false
+	}
+	if req.Server != "" && len(r.Servers) > 0 && !matchAny(r.Servers, req.Server) {
+		return false
+	}
+	return true
+}
+
+// DeniedError is returned by Authorize when the policy denies a request
+type DeniedError struct {
+	Identity *Identity
+	Request  Request
+	NoRule   bool // No rule matches the identity
+}
+
+// Error implements error
+func (e *DeniedError) Error() string {
+	who := "anonymous callers"
+	if e.Identity != nil {
+		who = fmt.Sprintf("%s %q", e.Identity.Method, e.Identity.Subject)
+	}
+	if e.NoRule {
+		return fmt.Sprintf("no policy rule applies to %s", who)
+	}
+
+	target := ""
+	switch {
+	case e.Request.Server != "":
+		target = fmt.Sprintf(" on server %s/%s", e.Request.Backend, e.Request.Server)
+	case e.Request.Backend != "":
+		target = fmt.Sprintf(" on backend %s", e.Request.Backend)
+	case e.Request.AllBackends:
+		target = " across all backends"
+	}
+	return fmt.Sprintf("%s may not call %s%s", who, e.Request.Tool, target)
+}
+
+// claimValues flattens a string or string array claim
+func claimValues(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// matchAny reports whether value matches any of the patterns
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether list holds value
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestPolicyAuthorize tests identity selectors and tool, backend and server grants
+func TestPolicyAuthorize(t *testing.T) {
+	path := writeFile(t, "policy.yaml", `
+rules:
+  - name: payments
+    groups: ["payments"]
+    claims:
+      team: ["payments"]
+    tools: ["list_*", "get_*", "enable_server", "disable_server", "show_peers"]
+    backends: ["payments-*"]
+  - name: sre
+    subjects: ["ops-bot"]
+    methods: ["mtls"]
+    tools: ["*"]
+  - name: local
+    anonymous: true
+    tools: ["show_info"]
+`)
+	policy, err := LoadPolicyFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load policy: %v", err)
+	}
+
+	payments := &Identity{Subject: "alice", Method: MethodToken, Groups: []string{"payments"}}
+	claimed := &Identity{Subject: "carol", Method: MethodJWT, Claims: map[string]interface{}{"team": []interface{}{"web", "payments"}}}
+	sre := &Identity{Subject: "ops-bot", Method: MethodMTLS}
+	impostor := &Identity{Subject: "ops-bot", Method: MethodToken}
+
+	testCases := []struct {
+		name     string
+		identity *Identity
+		req      Request
+		denied   string
+	}{
+		{name: "Own backend", identity: payments, req: Request{Tool: "disable_server", Backend: "payments-api", Server: "web1"}},
+		{name: "Other backend", identity: payments, req: Request{Tool: "disable_server", Backend: "checkout", Server: "web1"}, denied: "on server checkout/web1"},
+		{name: "Tool not granted", identity: payments, req: Request{Tool: "del_server", Backend: "payments-api", Server: "web1"}, denied: "may not call del_server"},
+		{name: "All backends", identity: payments, req: Request{Tool: "get_backend", AllBackends: true}, denied: "across all backends"},
+		{name: "Unscoped tool by pattern", identity: payments, req: Request{Tool: "list_backends", Unscoped: true}, denied: "may not call list_backends"},
+		{name: "Unscoped tool by name", identity: payments, req: Request{Tool: "show_peers", Unscoped: true}},
+		{name: "Unscoped tool without backends", identity: sre, req: Request{Tool: "reload_haproxy", Unscoped: true}},
+		{name: "JWT claim", identity: claimed, req: Request{Tool: "get_server", Backend: "payments-db", Server: "db1"}},
+		{name: "Certificate subject", identity: sre, req: Request{Tool: "del_server", Backend: "checkout", Server: "web1"}},
+		{name: "Wrong method", identity: impostor, req: Request{Tool: "list_backends"}, denied: "no policy rule applies"},
+		{name: "Anonymous", req: Request{Tool: "show_info"}},
+		{name: "Anonymous write", req: Request{Tool: "reload_haproxy"}, denied: "anonymous callers may not call reload_haproxy"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.Authorize(tc.identity, tc.req)
+			if tc.denied == "" {
+				if err != nil {
+					t.Fatalf("Expected request to be allowed, got %v", err)
+				}
+				return
+			}
+			var denied *DeniedError
+			if !errors.As(err, &denied) || !strings.Contains(err.Error(), tc.denied) {
+				t.Fatalf("Expected denial containing %q, got %v", tc.denied, err)
+			}
+		})
+	}
+}
+
+// TestPolicyValidate tests that malformed policies are rejected
+func TestPolicyValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "Bad default", content: "default: maybe\n", err: "default must be"},
+		{name: "No tools", content: "rules:\n  - name: empty\n    subjects: [alice]\n", err: "lists no tools"},
+		{name: "Bad pattern", content: "rules:\n  - tools: [\"[\"]\n", err: "invalid pattern"},
+		{name: "Unknown field", content: "rules:\n  - tool: [\"*\"]\n", err: "field tool not found"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadPolicyFile(writeFile(t, "policy.yaml", tc.content))
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("Expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+
+	policy, err := LoadPolicyFile(writeFile(t, "policy.yaml", "default: allow\n"))
+	if err != nil {
+		t.Fatalf("Failed to load policy: %v", err)
+	}
+	if err := policy.Authorize(&Identity{Subject: "alice"}, Request{Tool: "del_server"}); err != nil {
+		t.Errorf("Expected default allow, got %v", err)
+	}
+}
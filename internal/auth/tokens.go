@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// TokenAuthenticator accepts static bearer tokens
+type TokenAuthenticator struct {
+	subjects map[[sha256.Size]byte]string // SHA-256 of the token -> subject
+}
+
+// LoadTokenFile reads static tokens from a file with one "<subject> <token>" pair
+// per line. Blank lines and lines starting with '#' are ignored.
+func LoadTokenFile(path string) (*TokenAuthenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens file %s: %w", path, err)
+	}
+
+	a := &TokenAuthenticator{subjects: make(map[[sha256.Size]byte]string)}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid tokens file %s line %d: expected \"<subject> <token>\"", path, n)
+		}
+		digest := sha256.Sum256([]byte(fields[1]))
+		if _, exists := a.subjects[digest]; exists {
+			return nil, fmt.Errorf("invalid tokens file %s line %d: duplicate token", path, n)
+		}
+		a.subjects[digest] = fields[0]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tokens file %s: %w", path, err)
+	}
+	if len(a.subjects) == 0 {
+		return nil, fmt.Errorf("tokens file %s contains no tokens", path)
+	}
+
+	return a, nil
+}
+
+// Authenticate implements Authenticator. Tokens are looked up by digest so
+// the comparison does not leak token contents through timing.
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, ok := a.subjects[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, fmt.Errorf("unknown bearer token")
+	}
+	return &Identity{Subject: subject, Method: MethodToken}, nil
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"log/slog"
 	"strings"
 
@@ -12,17 +13,80 @@ type Config struct {
 	// HAProxy Runtime API Settings
 	HAProxyHost          string `mapstructure:"HAPROXY_HOST"`
 	HAProxyPort          int    `mapstructure:"HAPROXY_PORT"`
-	HAProxyRuntimeMode   string `mapstructure:"HAPROXY_RUNTIME_MODE"`   // "tcp4" or "unix"
-	HAProxyRuntimeSocket string `mapstructure:"HAPROXY_RUNTIME_SOCKET"` // Used only when HAProxyRuntimeMode is "unix"
+	HAProxyRuntimeMode   string `mapstructure:"HAPROXY_RUNTIME_MODE"`   // "tcp4", "tls", "unix" or "ssh"
+	HAProxyRuntimeSocket string `mapstructure:"HAPROXY_RUNTIME_SOCKET"` // Used when HAProxyRuntimeMode is "unix" or "ssh"
 	HAProxyRuntimeURL    string `mapstructure:"HAPROXY_RUNTIME_URL"`    // Optional: direct URL to runtime API
 
+	// HAProxy Runtime API Timeout & Retry Settings
+	HAProxyRuntimeTimeout      int `mapstructure:"HAPROXY_RUNTIME_TIMEOUT"`       // Per-command timeout in seconds
+	HAProxyRuntimeMaxAttempts  int `mapstructure:"HAPROXY_RUNTIME_MAX_ATTEMPTS"`  // Connection attempts before giving up
+	HAProxyRuntimeRetryBackoff int `mapstructure:"HAPROXY_RUNTIME_RETRY_BACKOFF"` // Initial wait between attempts in milliseconds
+
+	// HAProxy Runtime API TLS Settings (tls mode or tls:// URLs)
+	HAProxyRuntimeCAFile             string `mapstructure:"HAPROXY_RUNTIME_CA_FILE"`              // CA bundle used to verify the runtime endpoint
+	HAProxyRuntimeCertFile           string `mapstructure:"HAPROXY_RUNTIME_CERT_FILE"`            // Client certificate for mutual TLS
+	HAProxyRuntimeKeyFile            string `mapstructure:"HAPROXY_RUNTIME_KEY_FILE"`             // Client key for mutual TLS
+	HAProxyRuntimeServerName         string `mapstructure:"HAPROXY_RUNTIME_SERVER_NAME"`          // Overrides the TLS server name
+	HAProxyRuntimeInsecureSkipVerify bool   `mapstructure:"HAPROXY_RUNTIME_INSECURE_SKIP_VERIFY"` // Skip TLS verification (testing only)
+
+	// HAProxy Runtime API SSH Tunnel Settings (ssh mode or ssh:// URLs)
+	HAProxySSHHost                  string `mapstructure:"HAPROXY_SSH_HOST"`                     // Host running HAProxy
+	HAProxySSHPort                  int    `mapstructure:"HAPROXY_SSH_PORT"`                     // SSH port
+	HAProxySSHUser                  string `mapstructure:"HAPROXY_SSH_USER"`                     // Remote user with access to the admin socket
+	HAProxySSHPassword              string `mapstructure:"HAPROXY_SSH_PASSWORD"`                 // Password authentication
+	HAProxySSHKeyFile               string `mapstructure:"HAPROXY_SSH_KEY_FILE"`                 // Private key for public key authentication
+	HAProxySSHKeyPassphrase         string `mapstructure:"HAPROXY_SSH_KEY_PASSPHRASE"`           // Passphrase for the private key
+	HAProxySSHUseAgent              bool   `mapstructure:"HAPROXY_SSH_USE_AGENT"`                // Authenticate with the agent at SSH_AUTH_SOCK
+	HAProxySSHKnownHosts            string `mapstructure:"HAPROXY_SSH_KNOWN_HOSTS"`              // known_hosts file for host key verification
+	HAProxySSHInsecureIgnoreHostKey bool   `mapstructure:"HAPROXY_SSH_INSECURE_IGNORE_HOST_KEY"` // Skip host key verification (testing only)
+
 	// HAProxy Stats Settings
 	HAProxyStatsURL     string `mapstructure:"HAPROXY_STATS_URL"`     // URL to HAProxy stats page (e.g., http://127.0.0.1:8404/;json)
 	HAProxyStatsEnabled bool   `mapstructure:"HAPROXY_STATS_ENABLED"` // Whether to use stats API
+	HAProxyStatsTimeout int    `mapstructure:"HAPROXY_STATS_TIMEOUT"` // Timeout for stats requests in seconds
+
+	// HAProxy Stats Authentication & TLS Settings
+	HAProxyStatsUsername           string `mapstructure:"HAPROXY_STATS_USERNAME"`             // Basic auth user for "stats auth"
+	HAProxyStatsPassword           string `mapstructure:"HAPROXY_STATS_PASSWORD"`             // Basic auth password
+	HAProxyStatsBearerToken        string `mapstructure:"HAPROXY_STATS_BEARER_TOKEN"`         // Bearer token (mutually exclusive with basic auth)
+	HAProxyStatsHeaders            string `mapstructure:"HAPROXY_STATS_HEADERS"`              // Extra headers as "Name=value,Other=value"
+	HAProxyStatsCAFile             string `mapstructure:"HAPROXY_STATS_CA_FILE"`              // CA bundle used to verify the stats endpoint
+	HAProxyStatsCertFile           string `mapstructure:"HAPROXY_STATS_CERT_FILE"`            // Client certificate for mutual TLS
+	HAProxyStatsKeyFile            string `mapstructure:"HAPROXY_STATS_KEY_FILE"`             // Client key for mutual TLS
+	HAProxyStatsInsecureSkipVerify bool   `mapstructure:"HAPROXY_STATS_INSECURE_SKIP_VERIFY"` // Skip TLS verification (testing only)
 
 	// MCP Server Settings
-	MCPTransport string `mapstructure:"MCP_TRANSPORT"`
-	MCPPort      int    `mapstructure:"MCP_PORT"`
+	MCPTransport          string `mapstructure:"MCP_TRANSPORT"`
+	MCPPort               int    `mapstructure:"MCP_PORT"`
+	MCPStreamablePath     string `mapstructure:"MCP_STREAMABLE_PATH"`      // Endpoint of the streamable HTTP transport
+	MCPHTTPServeBoth      bool   `mapstructure:"MCP_HTTP_SERVE_BOTH"`      // Serve SSE and streamable HTTP on the same listener
+	MCPSessionIdleTimeout int    `mapstructure:"MCP_SESSION_IDLE_TIMEOUT"` // Seconds before an idle streamable HTTP session expires
+
+	// MCP HTTP TLS and Authentication Settings
+	MCPTLSCertFile     string `mapstructure:"MCP_TLS_CERT_FILE"`      // Server certificate; enables HTTPS
+	MCPTLSKeyFile      string `mapstructure:"MCP_TLS_KEY_FILE"`       // Server private key
+	MCPTLSClientCAFile string `mapstructure:"MCP_TLS_CLIENT_CA_FILE"` // CA bundle for client certificate (mTLS) authentication
+	MCPAuthTokensFile  string `mapstructure:"MCP_AUTH_TOKENS_FILE"`   // File of "<subject> <token>" bearer tokens
+	MCPJWTJWKSFile     string `mapstructure:"MCP_JWT_JWKS_FILE"`      // JWKS file with the keys that sign accepted JWTs
+	MCPJWTIssuer       string `mapstructure:"MCP_JWT_ISSUER"`         // Required JWT issuer, if set
+	MCPJWTAudience     string `mapstructure:"MCP_JWT_AUDIENCE"`       // Required JWT audience, if set
+	MCPPolicyFile      string `mapstructure:"MCP_POLICY_FILE"`        // YAML policy restricting tools and backends per identity
+
+	// MCP Approval Settings
+	MCPApprovalMode  bool   `mapstructure:"MCP_APPROVAL_MODE"`  // Require approve_change before destructive tools run
+	MCPApprovalTools string `mapstructure:"MCP_APPROVAL_TOOLS"` // Comma separated tool name patterns to gate; empty uses the defaults
+	MCPApprovalTTL   int    `mapstructure:"MCP_APPROVAL_TTL"`   // Seconds a pending change can be approved
+
+	// MCP Raw Command Settings
+	MCPCommandAllow       string `mapstructure:"MCP_COMMAND_ALLOW"`        // Comma separated command prefixes execute_command may send; empty allows all
+	MCPCommandDeny        string `mapstructure:"MCP_COMMAND_DENY"`         // Comma separated command prefixes execute_command rejects; empty uses the defaults
+	MCPCommandAllowWrites bool   `mapstructure:"MCP_COMMAND_ALLOW_WRITES"` // Let execute_command send commands that change state
+
+	// MCP Resource Subscription Settings
+	MCPResourcePollInterval int `mapstructure:"MCP_RESOURCE_POLL_INTERVAL"` // Seconds between server state polls for subscribed resources; 0 disables
+
+	// MCP Completion Settings
+	MCPCompletionCacheTTL int `mapstructure:"MCP_COMPLETION_CACHE_TTL"` // Seconds backend, server and map names are cached for argument completion
 
 	// Logging Settings
 	LogLevel string `mapstructure:"LOG_LEVEL"`
@@ -39,14 +103,61 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault("HAPROXY_RUNTIME_MODE", "tcp4")                          // Default to TCP4 connections
 	viper.SetDefault("HAPROXY_RUNTIME_SOCKET", "/var/run/haproxy/admin.sock") // Only used in unix mode
 	viper.SetDefault("HAPROXY_RUNTIME_URL", "")                               // Optional direct URL
+	viper.SetDefault("HAPROXY_RUNTIME_TIMEOUT", 10)                           // Seconds
+	viper.SetDefault("HAPROXY_RUNTIME_MAX_ATTEMPTS", 3)                       // Including the first attempt
+	viper.SetDefault("HAPROXY_RUNTIME_RETRY_BACKOFF", 100)                    // Milliseconds, doubled after each attempt
+	viper.SetDefault("HAPROXY_RUNTIME_CA_FILE", "")
+	viper.SetDefault("HAPROXY_RUNTIME_CERT_FILE", "")
+	viper.SetDefault("HAPROXY_RUNTIME_KEY_FILE", "")
+	viper.SetDefault("HAPROXY_RUNTIME_SERVER_NAME", "")
+	viper.SetDefault("HAPROXY_RUNTIME_INSECURE_SKIP_VERIFY", false)
+
+	// Set Defaults - Runtime API SSH tunnel
+	viper.SetDefault("HAPROXY_SSH_HOST", "")
+	viper.SetDefault("HAPROXY_SSH_PORT", 22)
+	viper.SetDefault("HAPROXY_SSH_USER", "")
+	viper.SetDefault("HAPROXY_SSH_PASSWORD", "")
+	viper.SetDefault("HAPROXY_SSH_KEY_FILE", "")
+	viper.SetDefault("HAPROXY_SSH_KEY_PASSPHRASE", "")
+	viper.SetDefault("HAPROXY_SSH_USE_AGENT", false)
+	viper.SetDefault("HAPROXY_SSH_KNOWN_HOSTS", "")
+	viper.SetDefault("HAPROXY_SSH_INSECURE_IGNORE_HOST_KEY", false)
 
 	// Set Defaults - Stats API
 	viper.SetDefault("HAPROXY_STATS_URL", "http://127.0.0.1:8404/stats") // Default stats URL
 	viper.SetDefault("HAPROXY_STATS_ENABLED", true)                      // Enable stats by default
+	viper.SetDefault("HAPROXY_STATS_TIMEOUT", 10)                        // Seconds
+	viper.SetDefault("HAPROXY_STATS_USERNAME", "")
+	viper.SetDefault("HAPROXY_STATS_PASSWORD", "")
+	viper.SetDefault("HAPROXY_STATS_BEARER_TOKEN", "")
+	viper.SetDefault("HAPROXY_STATS_HEADERS", "")
+	viper.SetDefault("HAPROXY_STATS_CA_FILE", "")
+	viper.SetDefault("HAPROXY_STATS_CERT_FILE", "")
+	viper.SetDefault("HAPROXY_STATS_KEY_FILE", "")
+	viper.SetDefault("HAPROXY_STATS_INSECURE_SKIP_VERIFY", false)
 
 	// Set Defaults - MCP Server
 	viper.SetDefault("MCP_TRANSPORT", "stdio") // Default to stdio
 	viper.SetDefault("MCP_PORT", 8080)         // Default port for http transport
+	viper.SetDefault("MCP_STREAMABLE_PATH", "/mcp")
+	viper.SetDefault("MCP_HTTP_SERVE_BOTH", false)
+	viper.SetDefault("MCP_SESSION_IDLE_TIMEOUT", 1800)
+	viper.SetDefault("MCP_TLS_CERT_FILE", "")
+	viper.SetDefault("MCP_TLS_KEY_FILE", "")
+	viper.SetDefault("MCP_TLS_CLIENT_CA_FILE", "")
+	viper.SetDefault("MCP_AUTH_TOKENS_FILE", "")
+	viper.SetDefault("MCP_JWT_JWKS_FILE", "")
+	viper.SetDefault("MCP_JWT_ISSUER", "")
+	viper.SetDefault("MCP_JWT_AUDIENCE", "")
+	viper.SetDefault("MCP_POLICY_FILE", "")
+	viper.SetDefault("MCP_APPROVAL_MODE", false)
+	viper.SetDefault("MCP_APPROVAL_TOOLS", "")
+	viper.SetDefault("MCP_APPROVAL_TTL", 300)
+	viper.SetDefault("MCP_COMMAND_ALLOW", "")
+	viper.SetDefault("MCP_COMMAND_DENY", "")
+	viper.SetDefault("MCP_COMMAND_ALLOW_WRITES", false)
+	viper.SetDefault("MCP_RESOURCE_POLL_INTERVAL", 5)
+	viper.SetDefault("MCP_COMPLETION_CACHE_TTL", 30)
 	viper.SetDefault("LOG_LEVEL", "info")
 
 	var config Config
@@ -56,6 +167,73 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
-	slog.Info("Configuration loaded", "config", config) // Be careful logging sensitive defaults
+	if _, err := ParseHeaders(config.HAProxyStatsHeaders); err != nil {
+		return nil, fmt.Errorf("invalid HAPROXY_STATS_HEADERS: %w", err)
+	}
+
+	if (config.MCPTLSCertFile == "") != (config.MCPTLSKeyFile == "") {
+		return nil, fmt.Errorf("MCP_TLS_CERT_FILE and MCP_TLS_KEY_FILE must be set together")
+	}
+	if config.MCPTLSClientCAFile != "" && config.MCPTLSCertFile == "" {
+		return nil, fmt.Errorf("MCP_TLS_CLIENT_CA_FILE requires MCP_TLS_CERT_FILE and MCP_TLS_KEY_FILE")
+	}
+
+	slog.Info("Configuration loaded", "config", config) // Secrets are redacted by LogValue
 	return &config, nil
 }
+
+// LogValue implements slog.LogValuer so that secrets never reach the logs.
+func (c Config) LogValue() slog.Value {
+	redacted := c
+	redacted.HAProxyStatsPassword = redact(c.HAProxyStatsPassword)
+	redacted.HAProxyStatsBearerToken = redact(c.HAProxyStatsBearerToken)
+	redacted.HAProxyStatsHeaders = redact(c.HAProxyStatsHeaders)
+	redacted.HAProxySSHPassword = redact(c.HAProxySSHPassword)
+	redacted.HAProxySSHKeyPassphrase = redact(c.HAProxySSHKeyPassphrase)
+	return slog.AnyValue(configView(redacted))
+}
+
+// configView strips the LogValue method to avoid infinite recursion when logging.
+type configView Config
+
+// redact hides a non-empty secret value
+func redact(v string) string {
+	if v == "" {
+		return ""
+	}
+	return "REDACTED"
+}
+
+// ParseList splits a comma separated list, dropping empty entries.
+func ParseList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ParseHeaders parses a comma separated list of "Name=value" pairs into a header map.
+func ParseHeaders(raw string) (map[string]string, error) {
+	headers := make(map[string]string)
+	if strings.TrimSpace(raw) == "" {
+		return headers, nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("header %q must be in Name=value form", pair)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+
+	return headers, nil
+}
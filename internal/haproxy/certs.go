@@ -0,0 +1,123 @@
+package haproxy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+// Default thresholds of CertExpiryReport, in days before notAfter
+const (
+	DefaultCertWarningDays  = 30
+	DefaultCertCriticalDays = 7
+)
+
+// Expiry statuses of the certificates in a CertExpiryReport
+const (
+	CertExpired  = "expired"
+	CertCritical = "critical"
+	CertWarning  = "warning"
+	CertOK       = "ok"
+	CertUnknown  = "unknown" // The certificate could not be shown or its notAfter parsed
+)
+
+// sslDateLayout is the layout of the dates reported by 'show ssl cert'
+const sslDateLayout = "Jan _2 15:04:05 2006 MST"
+
+// CertExpiry is a certificate of a CertExpiryReport
+type CertExpiry struct {
+	Certificate     string   `json:"certificate"`
+	Subject         string   `json:"subject,omitempty"`
+	SubjectAltNames []string `json:"subject_alt_names,omitempty"`
+	Issuer          string   `json:"issuer,omitempty"`
+	NotAfter        string   `json:"not_after,omitempty"`
+	DaysLeft        int      `json:"days_left"` // Whole days until notAfter, negative once expired
+	Status          string   `json:"status"`
+	Error           string   `json:"error,omitempty"` // Why the status is unknown
+
+	notAfter time.Time
+}
+
+// CertExpiryReport lists the loaded certificates by days to expiry
+type CertExpiryReport struct {
+	GeneratedAt  string         `json:"generated_at"`
+	WarningDays  int            `json:"warning_days"`
+	CriticalDays int            `json:"critical_days"`
+	Summary      map[string]int `json:"summary"` // Certificates per status
+	Certificates []CertExpiry   `json:"certificates"`
+}
+
+// expiryThresholds applies the defaults to the thresholds of a CertExpiryReport
+func expiryThresholds(warningDays, criticalDays int) (int, int, error) {
+	if warningDays <= 0 {
+		warningDays = DefaultCertWarningDays
+	}
+	if criticalDays <= 0 {
+		criticalDays = DefaultCertCriticalDays
+	}
+	if criticalDays > warningDays {
+		return 0, 0, fmt.Errorf("critical threshold (%d days) must not exceed the warning threshold (%d days)", criticalDays, warningDays)
+	}
+	return warningDays, criticalDays, nil
+}
+
+// newCertExpiry classifies a certificate shown by 'show ssl cert' at now
+func newCertExpiry(name string, info *runtimeclient.SSLCertInfo, now time.Time, warningDays, criticalDays int) CertExpiry {
+	expiry := CertExpiry{
+		Certificate:     name,
+		Subject:         info.Subject,
+		SubjectAltNames: info.SubjectAltNames,
+		Issuer:          info.Issuer,
+		NotAfter:        info.NotAfter,
+		Status:          CertUnknown,
+	}
+	notAfter, err := time.Parse(sslDateLayout, info.NotAfter)
+	if err != nil {
+		expiry.Error = fmt.Sprintf("invalid notAfter %q", info.NotAfter)
+		return expiry
+	}
+
+	expiry.notAfter = notAfter
+	expiry.DaysLeft = int(math.Floor(notAfter.Sub(now).Hours() / 24))
+	switch {
+	case !now.Before(notAfter):
+		expiry.Status = CertExpired
+	case expiry.DaysLeft < criticalDays:
+		expiry.Status = CertCritical
+	case expiry.DaysLeft < warningDays:
+		expiry.Status = CertWarning
+	default:
+		expiry.Status = CertOK
+	}
+	return expiry
+}
+
+// newCertExpiryReport sorts certificates by notAfter, soonest first, with
+// certificates of unknown expiry last, and counts them per status
+func newCertExpiryReport(certificates []CertExpiry, now time.Time, warningDays, criticalDays int) *CertExpiryReport {
+	sort.SliceStable(certificates, func(i, j int) bool {
+		a, b := certificates[i], certificates[j]
+		if (a.Status == CertUnknown) != (b.Status == CertUnknown) {
+			return b.Status == CertUnknown
+		}
+		if !a.notAfter.Equal(b.notAfter) {
+			return a.notAfter.Before(b.notAfter)
+		}
+		return a.Certificate < b.Certificate
+	})
+
+	report := &CertExpiryReport{
+		GeneratedAt:  now.UTC().Format(time.RFC3339),
+		WarningDays:  warningDays,
+		CriticalDays: criticalDays,
+		Summary:      map[string]int{CertExpired: 0, CertCritical: 0, CertWarning: 0, CertOK: 0, CertUnknown: 0},
+		Certificates: certificates,
+	}
+	for _, certificate := range certificates {
+		report.Summary[certificate.Status]++
+	}
+	return report
+}
@@ -0,0 +1,55 @@
+package haproxy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+// TestCertExpiryReport tests the classification and ordering of certificates by expiry
+func TestCertExpiryReport(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+	certs := map[string]string{
+		"ok.pem":       "Jan  1 00:00:00 2027 GMT",
+		"warning.pem":  "Nov  1 00:00:00 2026 GMT",
+		"critical.pem": "Oct 20 00:00:00 2026 GMT",
+		"expired.pem":  "Oct 18 11:59:59 2026 GMT",
+		"invalid.pem":  "soon",
+	}
+
+	var certificates []CertExpiry
+	for name, notAfter := range certs {
+		certificates = append(certificates, newCertExpiry(name, &runtimeclient.SSLCertInfo{Filename: name, NotAfter: notAfter}, now, 30, 7))
+	}
+	report := newCertExpiryReport(certificates, now, 30, 7)
+
+	var order, statuses []string
+	for _, c := range report.Certificates {
+		order = append(order, c.Certificate)
+		statuses = append(statuses, c.Status)
+	}
+	if got := strings.Join(order, ","); got != "expired.pem,critical.pem,warning.pem,ok.pem,invalid.pem" {
+		t.Errorf("Unexpected order: %s", got)
+	}
+	if got := strings.Join(statuses, ","); got != "expired,critical,warning,ok,unknown" {
+		t.Errorf("Unexpected statuses: %s", got)
+	}
+	if days := report.Certificates[0].DaysLeft; days != -1 {
+		t.Errorf("Expected an expired certificate to have -1 days left, got %d", days)
+	}
+	if days := report.Certificates[1].DaysLeft; days != 1 {
+		t.Errorf("Expected 1 day left, got %d", days)
+	}
+	if report.Summary[CertWarning] != 1 || report.Summary[CertUnknown] != 1 || report.GeneratedAt != "2026-10-18T12:00:00Z" {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	if _, _, err := expiryThresholds(7, 30); err == nil {
+		t.Error("Expected a critical threshold above the warning threshold to be rejected")
+	}
+	if warning, critical, _ := expiryThresholds(0, 0); warning != DefaultCertWarningDays || critical != DefaultCertCriticalDays {
+		t.Errorf("Expected default thresholds, got %d and %d", warning, critical)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
 	statsclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/stats"
@@ -17,6 +18,9 @@ type HAProxyClient struct {
 	StatsURL      string
 }
 
+// Ensure HAProxyClient satisfies the interface used by the MCP tools
+var _ Client = (*HAProxyClient)(nil)
+
 // ensureRuntime verifies the runtime client is initialized.
 func (c *HAProxyClient) ensureRuntime() error {
 	if c.RuntimeClient == nil {
@@ -25,22 +29,19 @@ func (c *HAProxyClient) ensureRuntime() error {
 	return nil
 }
 
-
-
-
-
-// toggleCheck performs enable/disable for health or agent checks
-func (c *HAProxyClient) toggleCheck(action, checkType, backend, server string) error {
-    if err := c.ensureRuntime(); err != nil {
-        return err
-    }
-    cmd := fmt.Sprintf("%s %s %s/%s", action, checkType, backend, server)
-    _, err := c.ExecuteRuntimeCommand(cmd)
-    return err
+// Options holds optional settings for the underlying runtime and stats clients
+type Options struct {
+	Runtime runtimeclient.ClientOptions
+	Stats   statsclient.ClientOptions
 }
 
 // NewHAProxyClient creates a new HAProxy client using the provided configurations
 func NewHAProxyClient(runtimeAPIURL string, statsURL string) (*HAProxyClient, error) {
+	return NewHAProxyClientWithOptions(runtimeAPIURL, statsURL, Options{})
+}
+
+// NewHAProxyClientWithOptions creates a new HAProxy client with authentication and TLS options
+func NewHAProxyClientWithOptions(runtimeAPIURL string, statsURL string, opts Options) (*HAProxyClient, error) {
 	client := &HAProxyClient{
 		StatsURL: statsURL,
 	}
@@ -48,7 +49,7 @@ func NewHAProxyClient(runtimeAPIURL string, statsURL string) (*HAProxyClient, er
 	// Initialize runtime client if URL is provided
 	if runtimeAPIURL != "" {
 		slog.Info("Initializing HAProxy Runtime API client", "url", runtimeAPIURL)
-		runtimeClient, err := runtimeclient.NewHAProxyClient(runtimeAPIURL)
+		runtimeClient, err := runtimeclient.NewHAProxyClientWithOptions(runtimeAPIURL, opts.Runtime)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize HAProxy Runtime API client: %w", err)
 		}
@@ -59,7 +60,7 @@ func NewHAProxyClient(runtimeAPIURL string, statsURL string) (*HAProxyClient, er
 	// Initialize stats client if URL is provided
 	if statsURL != "" {
 		slog.Info("Initializing HAProxy Stats client", "url", statsURL)
-		statsClient, err := statsclient.NewStatsClient(statsURL)
+		statsClient, err := statsclient.NewStatsClientWithOptions(statsURL, opts.Stats)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize HAProxy Stats client: %w", err)
 		}
@@ -86,7 +87,24 @@ func (c *HAProxyClient) Close() error {
 	return nil
 }
 
+// Ping checks that HAProxy is reachable
+func (c *HAProxyClient) Ping() error {
+	return c.PingWithContext(context.Background())
+}
 
+// PingWithContext checks that HAProxy is reachable with context support.
+// The Runtime API is preferred; the stats page is used when it is the only configured API.
+func (c *HAProxyClient) PingWithContext(ctx context.Context) error {
+	if c.RuntimeClient != nil {
+		_, err := c.RuntimeClient.GetProcessInfoWithContext(ctx)
+		return err
+	}
+	if c.StatsClient != nil {
+		_, err := c.StatsClient.GetStatsWithContext(ctx)
+		return err
+	}
+	return fmt.Errorf("neither stats client nor runtime client is initialized")
+}
 
 // ExecuteRuntimeCommand executes a command on HAProxy's Runtime API
 func (c *HAProxyClient) ExecuteRuntimeCommand(command string) (string, error) {
@@ -95,51 +113,75 @@ func (c *HAProxyClient) ExecuteRuntimeCommand(command string) (string, error) {
 
 // ExecuteRuntimeCommandWithContext executes a command on HAProxy's Runtime API with context
 func (c *HAProxyClient) ExecuteRuntimeCommandWithContext(ctx context.Context, command string) (string, error) {
-	if c.RuntimeClient == nil {
-		return "", fmt.Errorf("runtime client is not initialized")
+	if err := c.ensureRuntime(); err != nil {
+		return "", err
 	}
+	return c.RuntimeClient.ExecuteRuntimeCommandWithContext(ctx, command)
+}
 
-	// Use context-aware version if available
-	if ctxClient, ok := c.RuntimeClient.(interface {
-		ExecuteRuntimeCommandWithContext(ctx context.Context, command string) (string, error)
-	}); ok {
-		return ctxClient.ExecuteRuntimeCommandWithContext(ctx, command)
-	}
+// ProbeCapabilities reads the version and the Runtime API commands of the connected HAProxy
+func (c *HAProxyClient) ProbeCapabilities() (*runtimeclient.Capabilities, error) {
+	return c.ProbeCapabilitiesWithContext(context.Background())
+}
 
-	// Fall back to non-context version
-	return c.RuntimeClient.ExecuteRuntimeCommand(command)
+// ProbeCapabilitiesWithContext reads the version and the Runtime API commands of the connected HAProxy with context support.
+func (c *HAProxyClient) ProbeCapabilitiesWithContext(ctx context.Context) (*runtimeclient.Capabilities, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ProbeCapabilitiesWithContext(ctx)
 }
 
 // GetRuntimeInfo retrieves HAProxy process information from runtime API
 func (c *HAProxyClient) GetRuntimeInfo() (map[string]string, error) {
+	return c.GetRuntimeInfoWithContext(context.Background())
+}
+
+// GetRuntimeInfoWithContext retrieves HAProxy process information from runtime API with context support.
+func (c *HAProxyClient) GetRuntimeInfoWithContext(ctx context.Context) (map[string]string, error) {
     if err := c.ensureRuntime(); err != nil {
         return nil, err
     }
-    return c.RuntimeClient.GetProcessInfo()
+    return c.RuntimeClient.GetProcessInfoWithContext(ctx)
 }
 
 // GetStats retrieves HAProxy statistics from stats page
 func (c *HAProxyClient) GetStats() (*statsclient.HAProxyStats, error) {
+	return c.GetStatsWithContext(context.Background())
+}
+
+// GetStatsWithContext retrieves HAProxy statistics from stats page with context support.
+func (c *HAProxyClient) GetStatsWithContext(ctx context.Context) (*statsclient.HAProxyStats, error) {
 	if c.StatsClient == nil {
 		return nil, fmt.Errorf("stats client is not initialized")
 	}
-	return c.StatsClient.GetStats()
+	return c.StatsClient.GetStatsWithContext(ctx)
 }
 
 // GetBackends returns a list of all backends
 func (c *HAProxyClient) GetBackends() ([]string, error) {
+	return c.GetBackendsWithContext(context.Background())
+}
+
+// GetBackendsWithContext returns a list of all backends with context support.
+func (c *HAProxyClient) GetBackendsWithContext(ctx context.Context) ([]string, error) {
     if err := c.ensureRuntime(); err != nil {
         return nil, err
     }
-    return c.RuntimeClient.ListBackends()
+    return c.RuntimeClient.ListBackendsWithContext(ctx)
 }
 
 // GetBackendDetails returns detailed information about a backend
 func (c *HAProxyClient) GetBackendDetails(name string) (map[string]interface{}, error) {
+	return c.GetBackendDetailsWithContext(context.Background(), name)
+}
+
+// GetBackendDetailsWithContext returns detailed information about a backend with context support.
+func (c *HAProxyClient) GetBackendDetailsWithContext(ctx context.Context, name string) (map[string]interface{}, error) {
 	if err := c.ensureRuntime(); err != nil {
 		return nil, err
 	}
-	info, err := c.RuntimeClient.GetBackendInfo(name)
+	info, err := c.RuntimeClient.GetBackendInfoWithContext(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -156,18 +198,28 @@ func (c *HAProxyClient) GetBackendDetails(name string) (map[string]interface{},
 
 // ListServers returns a list of servers for a backend
 func (c *HAProxyClient) ListServers(backend string) ([]string, error) {
+	return c.ListServersWithContext(context.Background(), backend)
+}
+
+// ListServersWithContext returns a list of servers for a backend with context support.
+func (c *HAProxyClient) ListServersWithContext(ctx context.Context, backend string) ([]string, error) {
     if err := c.ensureRuntime(); err != nil {
         return nil, err
     }
-    return c.RuntimeClient.ListServers(backend)
+    return c.RuntimeClient.ListServersWithContext(ctx, backend)
 }
 
 // GetServerDetails returns detailed information about a server
 func (c *HAProxyClient) GetServerDetails(backend, server string) (map[string]interface{}, error) {
+	return c.GetServerDetailsWithContext(context.Background(), backend, server)
+}
+
+// GetServerDetailsWithContext returns detailed information about a server with context support.
+func (c *HAProxyClient) GetServerDetailsWithContext(ctx context.Context, backend, server string) (map[string]interface{}, error) {
 	if err := c.ensureRuntime(); err != nil {
 		return nil, err
 	}
-	serverInfo, err := c.RuntimeClient.GetServerDetails(backend, server)
+	serverInfo, err := c.RuntimeClient.GetServerDetailsWithContext(ctx, backend, server)
 	if err != nil {
 		return nil, err
 	}
@@ -179,69 +231,147 @@ func (c *HAProxyClient) GetServerDetails(backend, server string) (map[string]int
 
 // EnableServer enables a server in a backend
 func (c *HAProxyClient) EnableServer(backend, server string) error {
+	return c.EnableServerWithContext(context.Background(), backend, server)
+}
+
+// EnableServerWithContext enables a server in a backend with context support.
+func (c *HAProxyClient) EnableServerWithContext(ctx context.Context, backend, server string) error {
     if err := c.ensureRuntime(); err != nil {
         return err
     }
-    return c.RuntimeClient.EnableServer(backend, server)
+    return c.RuntimeClient.EnableServerWithContext(ctx, backend, server)
 }
 
 // DisableServer disables a server in a backend
 func (c *HAProxyClient) DisableServer(backend, server string) error {
+	return c.DisableServerWithContext(context.Background(), backend, server)
+}
+
+// DisableServerWithContext disables a server in a backend with context support.
+func (c *HAProxyClient) DisableServerWithContext(ctx context.Context, backend, server string) error {
     if err := c.ensureRuntime(); err != nil {
         return err
     }
-    return c.RuntimeClient.DisableServer(backend, server)
+    return c.RuntimeClient.DisableServerWithContext(ctx, backend, server)
 }
 
 // SetWeight sets the weight for a server in a backend
 func (c *HAProxyClient) SetWeight(backend, server string, weight int) (string, error) {
-	if c.RuntimeClient == nil {
-		return "", fmt.Errorf("runtime client is not initialized")
-	}
+	return c.SetWeightWithContext(context.Background(), backend, server, weight)
+}
 
-	// Directly execute the command since it might be different across versions
-	cmd := fmt.Sprintf("set weight %s/%s %d", backend, server, weight)
-	_, err := c.RuntimeClient.ExecuteRuntimeCommand(cmd)
-	if err != nil {
+// SetWeightWithContext sets the weight for a server in a backend with context support.
+func (c *HAProxyClient) SetWeightWithContext(ctx context.Context, backend, server string, weight int) (string, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return "", err
+	}
+	if _, err := c.RuntimeClient.SetWeightWithContext(ctx, backend, server, weight); err != nil {
 		return "", err
 	}
-
 	return fmt.Sprintf("Weight for %s/%s set to %d", backend, server, weight), nil
 }
 
 // SetServerMaxconn sets the maximum connections for a server
 func (c *HAProxyClient) SetServerMaxconn(backend, server string, maxconn int) error {
+	return c.SetServerMaxconnWithContext(context.Background(), backend, server, maxconn)
+}
+
+// SetServerMaxconnWithContext sets the maximum connections for a server with context support.
+func (c *HAProxyClient) SetServerMaxconnWithContext(ctx context.Context, backend, server string, maxconn int) error {
+    if err := c.ensureRuntime(); err != nil {
+        return err
+    }
+    return c.RuntimeClient.SetServerMaxconnWithContext(ctx, backend, server, maxconn)
+}
+
+// ShutdownSession terminates a session by its ID
+func (c *HAProxyClient) ShutdownSession(id string) error {
+	return c.ShutdownSessionWithContext(context.Background(), id)
+}
+
+// ShutdownSessionWithContext terminates a session by its ID with context support.
+func (c *HAProxyClient) ShutdownSessionWithContext(ctx context.Context, id string) error {
+    if err := c.ensureRuntime(); err != nil {
+        return err
+    }
+    return c.RuntimeClient.ShutdownSessionWithContext(ctx, id)
+}
+
+// ShutdownServerSessions terminates every session attached to a server
+func (c *HAProxyClient) ShutdownServerSessions(backend, server string) error {
+	return c.ShutdownServerSessionsWithContext(context.Background(), backend, server)
+}
+
+// ShutdownServerSessionsWithContext terminates every session attached to a server with context support.
+func (c *HAProxyClient) ShutdownServerSessionsWithContext(ctx context.Context, backend, server string) error {
     if err := c.ensureRuntime(); err != nil {
         return err
     }
-    return c.RuntimeClient.SetServerMaxconn(backend, server, maxconn)
+    return c.RuntimeClient.ShutdownServerSessionsWithContext(ctx, backend, server)
 }
 
 // EnableHealth enables health checks for a server
 func (c *HAProxyClient) EnableHealth(backend, server string) error {
-    return c.toggleCheck("enable", "health", backend, server)
+	return c.EnableHealthWithContext(context.Background(), backend, server)
+}
+
+// EnableHealthWithContext enables health checks for a server with context support.
+func (c *HAProxyClient) EnableHealthWithContext(ctx context.Context, backend, server string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.EnableHealthWithContext(ctx, backend, server)
 }
 
 // DisableHealth disables health checks for a server
 func (c *HAProxyClient) DisableHealth(backend, server string) error {
-    return c.toggleCheck("disable", "health", backend, server)
+	return c.DisableHealthWithContext(context.Background(), backend, server)
+}
+
+// DisableHealthWithContext disables health checks for a server with context support.
+func (c *HAProxyClient) DisableHealthWithContext(ctx context.Context, backend, server string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.DisableHealthWithContext(ctx, backend, server)
 }
 
 // EnableAgent enables agent checks for a server
 func (c *HAProxyClient) EnableAgent(backend, server string) error {
-    return c.toggleCheck("enable", "agent", backend, server)
+	return c.EnableAgentWithContext(context.Background(), backend, server)
+}
+
+// EnableAgentWithContext enables agent checks for a server with context support.
+func (c *HAProxyClient) EnableAgentWithContext(ctx context.Context, backend, server string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.EnableAgentWithContext(ctx, backend, server)
 }
 
 // DisableAgent disables agent checks for a server
 func (c *HAProxyClient) DisableAgent(backend, server string) error {
-    return c.toggleCheck("disable", "agent", backend, server)
+	return c.DisableAgentWithContext(context.Background(), backend, server)
+}
+
+// DisableAgentWithContext disables agent checks for a server with context support.
+func (c *HAProxyClient) DisableAgentWithContext(ctx context.Context, backend, server string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.DisableAgentWithContext(ctx, backend, server)
 }
 
 // ShowStat executes the show stat command
 func (c *HAProxyClient) ShowStat(filter string) ([]map[string]string, error) {
+	return c.ShowStatWithContext(context.Background(), filter)
+}
+
+// ShowStatWithContext executes the show stat command with context support.
+func (c *HAProxyClient) ShowStatWithContext(ctx context.Context, filter string) ([]map[string]string, error) {
 	// Try stats client first if available
 	if c.StatsClient != nil {
-		stats, err := c.StatsClient.GetStats()
+		stats, err := c.StatsClient.GetStatsWithContext(ctx)
 		if err == nil {
 			result := []map[string]string{}
 			for _, item := range stats.Stats {
@@ -249,6 +379,7 @@ func (c *HAProxyClient) ShowStat(filter string) ([]map[string]string, error) {
 					row := map[string]string{
 						"pxname": item.PxName,
 						"svname": item.SvName,
+						"type":   fmt.Sprintf("%d", item.Type),
 						"status": item.Status,
 						"weight": fmt.Sprintf("%d", item.Weight),
 					}
@@ -263,165 +394,711 @@ func (c *HAProxyClient) ShowStat(filter string) ([]map[string]string, error) {
 
 	// Use runtime client as fallback or primary if stats client not available
 	if c.RuntimeClient != nil {
-		cmd := "show stat"
-		if filter != "" {
-			cmd = fmt.Sprintf("%s %s", cmd, filter)
-		}
-		response, err := c.RuntimeClient.ExecuteRuntimeCommand(cmd)
-		if err != nil {
-			return nil, err
-		}
-
-		// Parse CSV-like output (simplified implementation)
-		result := []map[string]string{}
-		lines := strings.Split(response, "\n")
-		if len(lines) > 0 {
-			headers := strings.Split(lines[0], ",")
-			for i := 1; i < len(lines); i++ {
-				if lines[i] == "" {
-					continue
-				}
-				values := strings.Split(lines[i], ",")
-				row := make(map[string]string)
-				for j := 0; j < len(headers) && j < len(values); j++ {
-					row[headers[j]] = values[j]
-				}
-				result = append(result, row)
-			}
-		}
-		return result, nil
+		return c.RuntimeClient.ShowStatWithContext(ctx, filter)
 	}
 
 	return nil, fmt.Errorf("neither stats client nor runtime client is initialized")
 }
 
+// ShowStatRows returns show stat rows with every column
+func (c *HAProxyClient) ShowStatRows(filter string) ([]map[string]string, error) {
+	return c.ShowStatRowsWithContext(context.Background(), filter)
+}
+
+// ShowStatRowsWithContext returns show stat rows with every column with context support.
+// Rows are read from the Runtime API, whose show stat CSV carries every column.
+// Stats page rows only carry pxname, svname, type, status and weight, so the
+// stats page is only used when the Runtime API is not configured or fails.
+func (c *HAProxyClient) ShowStatRowsWithContext(ctx context.Context, filter string) ([]map[string]string, error) {
+	if c.RuntimeClient == nil {
+		return c.ShowStatWithContext(ctx, filter)
+	}
+	rows, err := c.RuntimeClient.ShowStatWithContext(ctx, filter)
+	if err != nil && c.StatsClient != nil {
+		slog.Warn("Runtime client failed, falling back to the stats page with fewer columns", "error", err)
+		return c.ShowStatWithContext(ctx, filter)
+	}
+	return rows, err
+}
+
 // ShowServersState returns server state information
 func (c *HAProxyClient) ShowServersState(backend string) ([]map[string]string, error) {
-	if c.RuntimeClient == nil {
-		return nil, fmt.Errorf("runtime client is not initialized")
+	return c.ShowServersStateWithContext(context.Background(), backend)
+}
+
+// ShowServersStateWithContext returns server state information with context support.
+func (c *HAProxyClient) ShowServersStateWithContext(ctx context.Context, backend string) ([]map[string]string, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
 	}
+	return c.RuntimeClient.ShowServersStateWithContext(ctx, backend)
+}
+
+// ShowStatPage returns one page of show stat rows selected by query
+func (c *HAProxyClient) ShowStatPage(filter string, query RowQuery) (*RowPage, error) {
+	return c.ShowStatPageWithContext(context.Background(), filter, query)
+}
 
-	cmd := "show servers state"
-	if backend != "" {
-		cmd = fmt.Sprintf("%s %s", cmd, backend)
+// ShowStatPageWithContext returns one page of show stat rows selected by query with context support.
+func (c *HAProxyClient) ShowStatPageWithContext(ctx context.Context, filter string, query RowQuery) (*RowPage, error) {
+	rows, err := c.ShowStatRowsWithContext(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
+	return query.apply(rows, statColumns)
+}
 
-	response, err := c.RuntimeClient.ExecuteRuntimeCommand(cmd)
+// ShowServersStatePage returns one page of server state rows selected by query
+func (c *HAProxyClient) ShowServersStatePage(backend string, query RowQuery) (*RowPage, error) {
+	return c.ShowServersStatePageWithContext(context.Background(), backend, query)
+}
+
+// ShowServersStatePageWithContext returns one page of server state rows selected by query with context support.
+func (c *HAProxyClient) ShowServersStatePageWithContext(ctx context.Context, backend string, query RowQuery) (*RowPage, error) {
+	rows, err := c.ShowServersStateWithContext(ctx, backend)
 	if err != nil {
 		return nil, err
 	}
+	return query.apply(rows, serversStateColumns)
+}
 
-	// Parse output (simplified implementation)
-	result := []map[string]string{}
-	lines := strings.Split(response, "\n")
-	if len(lines) > 0 {
-		headers := strings.Fields(lines[0])
-		for i := 1; i < len(lines); i++ {
-			if lines[i] == "" {
-				continue
-			}
-			values := strings.Fields(lines[i])
-			row := make(map[string]string)
-			for j := 0; j < len(headers) && j < len(values); j++ {
-				row[headers[j]] = values[j]
-			}
-			result = append(result, row)
-		}
+// DumpStatsFile dumps stats to a file
+func (c *HAProxyClient) DumpStatsFile(filepath string) (string, error) {
+	return c.DumpStatsFileWithContext(context.Background(), filepath)
+}
+
+// DumpStatsFileWithContext dumps stats to a file with context support.
+func (c *HAProxyClient) DumpStatsFileWithContext(ctx context.Context, filepath string) (string, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return "", err
+	}
+	return c.RuntimeClient.DumpStatsFileWithContext(ctx, filepath)
+}
+
+// DebugCounters returns debug counters
+func (c *HAProxyClient) DebugCounters() (map[string]interface{}, error) {
+	return c.DebugCountersWithContext(context.Background())
+}
+
+// DebugCountersWithContext returns debug counters with context support.
+func (c *HAProxyClient) DebugCountersWithContext(ctx context.Context) (map[string]interface{}, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	counters, err := c.RuntimeClient.DebugCountersWithContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
+	result := make(map[string]interface{}, len(counters))
+	for key, value := range counters {
+		result[key] = value
+	}
 	return result, nil
 }
 
-// Other methods like DumpStatsFile, DebugCounters, etc. would be added here
-// to support the full tool set in tools.go
+// ClearCountersAll clears all counters
+func (c *HAProxyClient) ClearCountersAll() error {
+	return c.ClearCountersAllWithContext(context.Background())
+}
 
-// DumpStatsFile dumps stats to a file
-func (c *HAProxyClient) DumpStatsFile(filepath string) (string, error) {
-	if c.RuntimeClient == nil {
-		return "", fmt.Errorf("runtime client is not initialized")
+// ClearCountersAllWithContext clears all counters with context support.
+func (c *HAProxyClient) ClearCountersAllWithContext(ctx context.Context) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
 	}
+	return c.RuntimeClient.ClearCountersAllWithContext(ctx)
+}
 
-	cmd := fmt.Sprintf("show stat > %s", filepath)
-	_, err := c.RuntimeClient.ExecuteRuntimeCommand(cmd)
-	if err != nil {
+// AddServer adds a server to a backend
+func (c *HAProxyClient) AddServer(backend, name, addr string, port, weight int) error {
+	return c.AddServerWithContext(context.Background(), backend, name, addr, port, weight)
+}
+
+// AddServerWithContext adds a server to a backend with context support.
+func (c *HAProxyClient) AddServerWithContext(ctx context.Context, backend, name, addr string, port, weight int) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.AddServerWithContext(ctx, backend, name, addr, port, weight)
+}
+
+// DelServer removes a server from a backend
+func (c *HAProxyClient) DelServer(backend, name string) error {
+	return c.DelServerWithContext(context.Background(), backend, name)
+}
+
+// DelServerWithContext removes a server from a backend with context support.
+func (c *HAProxyClient) DelServerWithContext(ctx context.Context, backend, name string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.DelServerWithContext(ctx, backend, name)
+}
+
+// ShowErrors returns the last protocol errors captured by HAProxy
+func (c *HAProxyClient) ShowErrors(proxy string) (string, error) {
+	return c.ShowErrorsWithContext(context.Background(), proxy)
+}
+
+// ShowErrorsWithContext returns the last protocol errors captured by HAProxy with context support.
+func (c *HAProxyClient) ShowErrorsWithContext(ctx context.Context, proxy string) (string, error) {
+	if err := c.ensureRuntime(); err != nil {
 		return "", err
 	}
+	return c.RuntimeClient.ShowErrorsWithContext(ctx, proxy)
+}
 
-	return filepath, nil
+// ShowErrorCaptures returns the parsed protocol error captures, optionally of one proxy and direction
+func (c *HAProxyClient) ShowErrorCaptures(proxy, direction string) ([]runtimeclient.ErrorCapture, error) {
+	return c.ShowErrorCapturesWithContext(context.Background(), proxy, direction)
 }
 
-// DebugCounters returns debug counters
-func (c *HAProxyClient) DebugCounters() (map[string]interface{}, error) {
-	if c.RuntimeClient == nil {
-		return nil, fmt.Errorf("runtime client is not initialized")
+// ShowErrorCapturesWithContext returns the parsed protocol error captures with context support.
+func (c *HAProxyClient) ShowErrorCapturesWithContext(ctx context.Context, proxy, direction string) ([]runtimeclient.ErrorCapture, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
 	}
+	return c.RuntimeClient.ShowErrorCapturesWithContext(ctx, proxy, direction)
+}
 
-	response, err := c.RuntimeClient.ExecuteRuntimeCommand("debug dev state")
+// ShowResolvers returns the nameserver counters of the resolvers sections, or of the section named id
+func (c *HAProxyClient) ShowResolvers(id string) ([]runtimeclient.ResolversSection, error) {
+	return c.ShowResolversWithContext(context.Background(), id)
+}
+
+// ShowResolversWithContext returns the nameserver counters of the resolvers sections with context support.
+func (c *HAProxyClient) ShowResolversWithContext(ctx context.Context, id string) ([]runtimeclient.ResolversSection, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowResolversWithContext(ctx, id)
+}
+
+// ResolverHealth flags the nameservers of the resolvers sections, or of the
+// section named id, that failed at least maxFailurePercent of their queries or
+// never answered. A threshold of 0 uses DefaultMaxNameserverFailurePercent.
+// Counters accumulate since HAProxy started.
+func (c *HAProxyClient) ResolverHealth(id string, maxFailurePercent int) (*ResolverHealthReport, error) {
+	return c.ResolverHealthWithContext(context.Background(), id, maxFailurePercent)
+}
+
+// ResolverHealthWithContext flags failing nameservers with context support.
+func (c *HAProxyClient) ResolverHealthWithContext(ctx context.Context, id string, maxFailurePercent int) (*ResolverHealthReport, error) {
+	if maxFailurePercent <= 0 {
+		maxFailurePercent = DefaultMaxNameserverFailurePercent
+	}
+	if maxFailurePercent > 100 {
+		return nil, fmt.Errorf("failure threshold must be at most 100%%, got %d", maxFailurePercent)
+	}
+	sections, err := c.ShowResolversWithContext(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	return newResolverHealthReport(sections, maxFailurePercent), nil
+}
+
+// ShowPeers returns the peers sections, or the section named name, with the state of each peer
+func (c *HAProxyClient) ShowPeers(name string) ([]runtimeclient.PeersSection, error) {
+	return c.ShowPeersWithContext(context.Background(), name)
+}
 
-	// Parse output into structured format
-	counters := make(map[string]interface{})
-	lines := strings.Split(response, "\n")
-	for _, line := range lines {
-		parts := strings.SplitN(line, ":", 2)
-		if len(parts) == 2 {
-			counters[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+// ShowPeersWithContext returns the peers sections with context support.
+func (c *HAProxyClient) ShowPeersWithContext(ctx context.Context, name string) ([]runtimeclient.PeersSection, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowPeersWithContext(ctx, name)
+}
+
+// PeersReport reports whether every remote peer of the peers sections, or of
+// the section named name, is connected and has received and acknowledged
+// every local update of the stick tables it shares.
+func (c *HAProxyClient) PeersReport(name string) (*PeersReport, error) {
+	return c.PeersReportWithContext(context.Background(), name)
+}
+
+// PeersReportWithContext reports the connection and synchronization of peers with context support.
+func (c *HAProxyClient) PeersReportWithContext(ctx context.Context, name string) (*PeersReport, error) {
+	sections, err := c.ShowPeersWithContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return newPeersReport(sections), nil
+}
+
+// ShowActivity returns the scheduler and poller counters, totaled and per thread
+func (c *HAProxyClient) ShowActivity() (*runtimeclient.ProcessActivity, error) {
+	return c.ShowActivityWithContext(context.Background())
+}
+
+// ShowActivityWithContext returns the scheduler and poller counters with context support.
+func (c *HAProxyClient) ShowActivityWithContext(ctx context.Context) (*runtimeclient.ProcessActivity, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowActivityWithContext(ctx)
+}
+
+// ShowThreads returns the state of each thread
+func (c *HAProxyClient) ShowThreads() ([]runtimeclient.ThreadInfo, error) {
+	return c.ShowThreadsWithContext(context.Background())
+}
+
+// ShowThreadsWithContext returns the state of each thread with context support.
+func (c *HAProxyClient) ShowThreadsWithContext(ctx context.Context) ([]runtimeclient.ThreadInfo, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowThreadsWithContext(ctx)
+}
+
+// ShowPools returns the memory pools and their usage
+func (c *HAProxyClient) ShowPools() (*runtimeclient.PoolsInfo, error) {
+	return c.ShowPoolsWithContext(context.Background())
+}
+
+// ShowPoolsWithContext returns the memory pools and their usage with context support.
+func (c *HAProxyClient) ShowPoolsWithContext(ctx context.Context) (*runtimeclient.PoolsInfo, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowPoolsWithContext(ctx)
+}
+
+// ShowTasks returns the tasks waiting in the run queues, grouped by function
+func (c *HAProxyClient) ShowTasks() (*runtimeclient.TasksInfo, error) {
+	return c.ShowTasksWithContext(context.Background())
+}
+
+// ShowTasksWithContext returns the tasks waiting in the run queues with context support.
+func (c *HAProxyClient) ShowTasksWithContext(ctx context.Context) (*runtimeclient.TasksInfo, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowTasksWithContext(ctx)
+}
+
+// ShowFDs returns the open file descriptors counted by type
+func (c *HAProxyClient) ShowFDs() (*runtimeclient.FDInfo, error) {
+	return c.ShowFDsWithContext(context.Background())
+}
+
+// ShowFDsWithContext returns the open file descriptors counted by type with context support.
+func (c *HAProxyClient) ShowFDsWithContext(ctx context.Context) (*runtimeclient.FDInfo, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowFDsWithContext(ctx)
+}
+
+// ListMaps lists the maps loaded by HAProxy
+func (c *HAProxyClient) ListMaps() ([]runtimeclient.MapInfo, error) {
+	return c.ListMapsWithContext(context.Background())
+}
+
+// ListMapsWithContext lists the maps loaded by HAProxy with context support.
+func (c *HAProxyClient) ListMapsWithContext(ctx context.Context) ([]runtimeclient.MapInfo, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ListMapsWithContext(ctx)
+}
+
+// ShowMap returns the entries of an HAProxy map
+func (c *HAProxyClient) ShowMap(id string) ([]runtimeclient.MapEntry, error) {
+	return c.ShowMapWithContext(context.Background(), id)
+}
+
+// ShowMapWithContext returns the entries of an HAProxy map with context support.
+func (c *HAProxyClient) ShowMapWithContext(ctx context.Context, id string) ([]runtimeclient.MapEntry, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowMapWithContext(ctx, id)
+}
+
+// ListSSLCerts lists the certificates loaded by HAProxy
+func (c *HAProxyClient) ListSSLCerts() (*runtimeclient.SSLCertList, error) {
+	return c.ListSSLCertsWithContext(context.Background())
+}
+
+// ListSSLCertsWithContext lists the certificates loaded by HAProxy with context support.
+func (c *HAProxyClient) ListSSLCertsWithContext(ctx context.Context) (*runtimeclient.SSLCertList, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ListSSLCertsWithContext(ctx)
+}
+
+// ShowSSLCert returns the details of a certificate; prefix name with '*' for the uncommitted transaction
+func (c *HAProxyClient) ShowSSLCert(name string) (*runtimeclient.SSLCertInfo, error) {
+	return c.ShowSSLCertWithContext(context.Background(), name)
+}
+
+// ShowSSLCertWithContext returns the details of a certificate with context support.
+func (c *HAProxyClient) ShowSSLCertWithContext(ctx context.Context, name string) (*runtimeclient.SSLCertInfo, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowSSLCertWithContext(ctx, name)
+}
+
+// CertExpiryReport shows every loaded certificate and sorts them by days to
+// expiry. Certificates expiring within criticalDays or warningDays are flagged;
+// thresholds of 0 use DefaultCertCriticalDays and DefaultCertWarningDays.
+func (c *HAProxyClient) CertExpiryReport(warningDays, criticalDays int) (*CertExpiryReport, error) {
+	return c.CertExpiryReportWithContext(context.Background(), warningDays, criticalDays)
+}
+
+// CertExpiryReportWithContext reports certificates by days to expiry with context support.
+func (c *HAProxyClient) CertExpiryReportWithContext(ctx context.Context, warningDays, criticalDays int) (*CertExpiryReport, error) {
+	warningDays, criticalDays, err := expiryThresholds(warningDays, criticalDays)
+	if err != nil {
+		return nil, err
+	}
+	list, err := c.ListSSLCertsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	certificates := make([]CertExpiry, 0, len(list.Certificates))
+	for _, name := range list.Certificates {
+		info, err := c.ShowSSLCertWithContext(ctx, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			certificates = append(certificates, CertExpiry{Certificate: name, Status: CertUnknown, Error: err.Error()})
+			continue
 		}
+		certificates = append(certificates, newCertExpiry(name, info, now, warningDays, criticalDays))
 	}
+	return newCertExpiryReport(certificates, now, warningDays, criticalDays), nil
+}
 
-	return counters, nil
+// NewSSLCert creates an empty certificate store
+func (c *HAProxyClient) NewSSLCert(name string) error {
+	return c.NewSSLCertWithContext(context.Background(), name)
 }
 
-// ClearCountersAll clears all counters
-func (c *HAProxyClient) ClearCountersAll() error {
-	if c.RuntimeClient == nil {
-		return fmt.Errorf("runtime client is not initialized")
+// NewSSLCertWithContext creates an empty certificate store with context support.
+func (c *HAProxyClient) NewSSLCertWithContext(ctx context.Context, name string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
 	}
+	return c.RuntimeClient.NewSSLCertWithContext(ctx, name)
+}
 
-	_, err := c.RuntimeClient.ExecuteRuntimeCommand("clear counters all")
-	return err
+// SetSSLCert stages a PEM payload for a certificate in a transaction
+func (c *HAProxyClient) SetSSLCert(name, payload string) error {
+	return c.SetSSLCertWithContext(context.Background(), name, payload)
 }
 
-// AddServer adds a server to a backend
-func (c *HAProxyClient) AddServer(backend, name, addr string, port, weight int) error {
-	if c.RuntimeClient == nil {
-		return fmt.Errorf("runtime client is not initialized")
+// SetSSLCertWithContext stages a PEM payload for a certificate in a transaction with context support.
+func (c *HAProxyClient) SetSSLCertWithContext(ctx context.Context, name, payload string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
 	}
+	return c.RuntimeClient.SetSSLCertWithContext(ctx, name, payload)
+}
 
-	cmd := fmt.Sprintf("add server %s/%s %s", backend, name, addr)
-	if port > 0 {
-		cmd = fmt.Sprintf("%s:%d", cmd, port)
+// CommitSSLCert applies the transaction of a certificate
+func (c *HAProxyClient) CommitSSLCert(name string) error {
+	return c.CommitSSLCertWithContext(context.Background(), name)
+}
+
+// CommitSSLCertWithContext applies the transaction of a certificate with context support.
+func (c *HAProxyClient) CommitSSLCertWithContext(ctx context.Context, name string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
 	}
-	if weight > 0 {
-		cmd = fmt.Sprintf("%s weight %d", cmd, weight)
+	return c.RuntimeClient.CommitSSLCertWithContext(ctx, name)
+}
+
+// AbortSSLCert discards the transaction of a certificate
+func (c *HAProxyClient) AbortSSLCert(name string) error {
+	return c.AbortSSLCertWithContext(context.Background(), name)
+}
+
+// AbortSSLCertWithContext discards the transaction of a certificate with context support.
+func (c *HAProxyClient) AbortSSLCertWithContext(ctx context.Context, name string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
 	}
+	return c.RuntimeClient.AbortSSLCertWithContext(ctx, name)
+}
 
-	_, err := c.RuntimeClient.ExecuteRuntimeCommand(cmd)
-	return err
+// DelSSLCert deletes an unused certificate
+func (c *HAProxyClient) DelSSLCert(name string) error {
+	return c.DelSSLCertWithContext(context.Background(), name)
 }
 
-// DelServer removes a server from a backend
-func (c *HAProxyClient) DelServer(backend, name string) error {
-	if c.RuntimeClient == nil {
-		return fmt.Errorf("runtime client is not initialized")
+// DelSSLCertWithContext deletes an unused certificate with context support.
+func (c *HAProxyClient) DelSSLCertWithContext(ctx context.Context, name string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.DelSSLCertWithContext(ctx, name)
+}
+
+// ListCrtLists lists the crt-list files loaded by HAProxy
+func (c *HAProxyClient) ListCrtLists() ([]string, error) {
+	return c.ListCrtListsWithContext(context.Background())
+}
+
+// ListCrtListsWithContext lists the crt-list files loaded by HAProxy with context support.
+func (c *HAProxyClient) ListCrtListsWithContext(ctx context.Context) ([]string, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ListCrtListsWithContext(ctx)
+}
+
+// ShowCrtList returns the entries of a crt-list
+func (c *HAProxyClient) ShowCrtList(crtList string) ([]runtimeclient.CrtListEntry, error) {
+	return c.ShowCrtListWithContext(context.Background(), crtList)
+}
+
+// ShowCrtListWithContext returns the entries of a crt-list with context support.
+func (c *HAProxyClient) ShowCrtListWithContext(ctx context.Context, crtList string) ([]runtimeclient.CrtListEntry, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
 	}
+	return c.RuntimeClient.ShowCrtListWithContext(ctx, crtList)
+}
 
-	cmd := fmt.Sprintf("del server %s/%s", backend, name)
-	_, err := c.RuntimeClient.ExecuteRuntimeCommand(cmd)
-	return err
+// AddCrtListEntry adds a certificate to a crt-list
+func (c *HAProxyClient) AddCrtListEntry(crtList string, entry runtimeclient.CrtListEntry) error {
+	return c.AddCrtListEntryWithContext(context.Background(), crtList, entry)
+}
+
+// AddCrtListEntryWithContext adds a certificate to a crt-list with context support.
+func (c *HAProxyClient) AddCrtListEntryWithContext(ctx context.Context, crtList string, entry runtimeclient.CrtListEntry) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.AddCrtListEntryWithContext(ctx, crtList, entry)
+}
+
+// DelCrtListEntry removes a certificate from a crt-list
+func (c *HAProxyClient) DelCrtListEntry(crtList, certificate string, line int) error {
+	return c.DelCrtListEntryWithContext(context.Background(), crtList, certificate, line)
+}
+
+// DelCrtListEntryWithContext removes a certificate from a crt-list with context support.
+func (c *HAProxyClient) DelCrtListEntryWithContext(ctx context.Context, crtList, certificate string, line int) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.DelCrtListEntryWithContext(ctx, crtList, certificate, line)
+}
+
+// ListCAFiles lists the CA files loaded by HAProxy
+func (c *HAProxyClient) ListCAFiles() (*runtimeclient.SSLFileList, error) {
+	return c.ListCAFilesWithContext(context.Background())
+}
+
+// ListCAFilesWithContext lists the CA files loaded by HAProxy with context support.
+func (c *HAProxyClient) ListCAFilesWithContext(ctx context.Context) (*runtimeclient.SSLFileList, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ListCAFilesWithContext(ctx)
+}
+
+// ShowCAFile returns the certificates of a CA file; prefix name with '*' for the uncommitted transaction
+func (c *HAProxyClient) ShowCAFile(name string) (*runtimeclient.CAFileInfo, error) {
+	return c.ShowCAFileWithContext(context.Background(), name)
+}
+
+// ShowCAFileWithContext returns the certificates of a CA file with context support.
+func (c *HAProxyClient) ShowCAFileWithContext(ctx context.Context, name string) (*runtimeclient.CAFileInfo, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowCAFileWithContext(ctx, name)
+}
+
+// NewCAFile creates an empty CA file
+func (c *HAProxyClient) NewCAFile(name string) error {
+	return c.NewCAFileWithContext(context.Background(), name)
+}
+
+// NewCAFileWithContext creates an empty CA file with context support.
+func (c *HAProxyClient) NewCAFileWithContext(ctx context.Context, name string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.NewCAFileWithContext(ctx, name)
+}
+
+// SetCAFile stages PEM CA certificates replacing a CA file in a transaction
+func (c *HAProxyClient) SetCAFile(name, payload string) error {
+	return c.SetCAFileWithContext(context.Background(), name, payload)
+}
+
+// SetCAFileWithContext stages PEM CA certificates replacing a CA file with context support.
+func (c *HAProxyClient) SetCAFileWithContext(ctx context.Context, name, payload string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.SetCAFileWithContext(ctx, name, payload)
+}
+
+// AddCAFile stages PEM CA certificates appended to a CA file in a transaction
+func (c *HAProxyClient) AddCAFile(name, payload string) error {
+	return c.AddCAFileWithContext(context.Background(), name, payload)
+}
+
+// AddCAFileWithContext stages PEM CA certificates appended to a CA file with context support.
+func (c *HAProxyClient) AddCAFileWithContext(ctx context.Context, name, payload string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.AddCAFileWithContext(ctx, name, payload)
+}
+
+// CommitCAFile applies the transaction of a CA file
+func (c *HAProxyClient) CommitCAFile(name string) error {
+	return c.CommitCAFileWithContext(context.Background(), name)
+}
+
+// CommitCAFileWithContext applies the transaction of a CA file with context support.
+func (c *HAProxyClient) CommitCAFileWithContext(ctx context.Context, name string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.CommitCAFileWithContext(ctx, name)
+}
+
+// AbortCAFile discards the transaction of a CA file
+func (c *HAProxyClient) AbortCAFile(name string) error {
+	return c.AbortCAFileWithContext(context.Background(), name)
+}
+
+// AbortCAFileWithContext discards the transaction of a CA file with context support.
+func (c *HAProxyClient) AbortCAFileWithContext(ctx context.Context, name string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.AbortCAFileWithContext(ctx, name)
+}
+
+// ListCRLFiles lists the CRL files loaded by HAProxy
+func (c *HAProxyClient) ListCRLFiles() (*runtimeclient.SSLFileList, error) {
+	return c.ListCRLFilesWithContext(context.Background())
+}
+
+// ListCRLFilesWithContext lists the CRL files loaded by HAProxy with context support.
+func (c *HAProxyClient) ListCRLFilesWithContext(ctx context.Context) (*runtimeclient.SSLFileList, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ListCRLFilesWithContext(ctx)
+}
+
+// ShowCRLFile returns the revocation lists of a CRL file; prefix name with '*' for the uncommitted transaction
+func (c *HAProxyClient) ShowCRLFile(name string) (*runtimeclient.CRLFileInfo, error) {
+	return c.ShowCRLFileWithContext(context.Background(), name)
+}
+
+// ShowCRLFileWithContext returns the revocation lists of a CRL file with context support.
+func (c *HAProxyClient) ShowCRLFileWithContext(ctx context.Context, name string) (*runtimeclient.CRLFileInfo, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowCRLFileWithContext(ctx, name)
+}
+
+// SetCRLFile stages PEM CRLs replacing a CRL file in a transaction
+func (c *HAProxyClient) SetCRLFile(name, payload string) error {
+	return c.SetCRLFileWithContext(context.Background(), name, payload)
+}
+
+// SetCRLFileWithContext stages PEM CRLs replacing a CRL file with context support.
+func (c *HAProxyClient) SetCRLFileWithContext(ctx context.Context, name, payload string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.SetCRLFileWithContext(ctx, name, payload)
+}
+
+// CommitCRLFile applies the transaction of a CRL file
+func (c *HAProxyClient) CommitCRLFile(name string) error {
+	return c.CommitCRLFileWithContext(context.Background(), name)
+}
+
+// CommitCRLFileWithContext applies the transaction of a CRL file with context support.
+func (c *HAProxyClient) CommitCRLFileWithContext(ctx context.Context, name string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.CommitCRLFileWithContext(ctx, name)
+}
+
+// AbortCRLFile discards the transaction of a CRL file
+func (c *HAProxyClient) AbortCRLFile(name string) error {
+	return c.AbortCRLFileWithContext(context.Background(), name)
+}
+
+// AbortCRLFileWithContext discards the transaction of a CRL file with context support.
+func (c *HAProxyClient) AbortCRLFileWithContext(ctx context.Context, name string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.AbortCRLFileWithContext(ctx, name)
+}
+
+// ListOCSPResponses lists the OCSP responses stapled by HAProxy
+func (c *HAProxyClient) ListOCSPResponses() ([]runtimeclient.OCSPResponseID, error) {
+	return c.ListOCSPResponsesWithContext(context.Background())
+}
+
+// ListOCSPResponsesWithContext lists the OCSP responses stapled by HAProxy with context support.
+func (c *HAProxyClient) ListOCSPResponsesWithContext(ctx context.Context) ([]runtimeclient.OCSPResponseID, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ListOCSPResponsesWithContext(ctx)
+}
+
+// ShowOCSPResponse returns the status and validity of an OCSP response, by certificate ID key or certificate path
+func (c *HAProxyClient) ShowOCSPResponse(id string) (*runtimeclient.OCSPResponse, error) {
+	return c.ShowOCSPResponseWithContext(context.Background(), id)
+}
+
+// ShowOCSPResponseWithContext returns the status and validity of an OCSP response with context support.
+func (c *HAProxyClient) ShowOCSPResponseWithContext(ctx context.Context, id string) (*runtimeclient.OCSPResponse, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+	return c.RuntimeClient.ShowOCSPResponseWithContext(ctx, id)
+}
+
+// UpdateOCSPResponse refreshes the OCSP response stapled for a certificate
+func (c *HAProxyClient) UpdateOCSPResponse(certificate string) error {
+	return c.UpdateOCSPResponseWithContext(context.Background(), certificate)
+}
+
+// UpdateOCSPResponseWithContext refreshes the OCSP response stapled for a certificate with context support.
+func (c *HAProxyClient) UpdateOCSPResponseWithContext(ctx context.Context, certificate string) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.UpdateOCSPResponseWithContext(ctx, certificate)
 }
 
 // ReloadHAProxy reloads the HAProxy configuration
 func (c *HAProxyClient) ReloadHAProxy() error {
-	if c.RuntimeClient == nil {
-		return fmt.Errorf("runtime client is not initialized")
-	}
+	return c.ReloadHAProxyWithContext(context.Background())
+}
 
-	// Note: This is a simplified implementation
-	// In a real-world scenario, this would likely call a system command
-	// to trigger a reload of HAProxy
-	_, err := c.RuntimeClient.ExecuteRuntimeCommand("reload")
-	return err
+// ReloadHAProxyWithContext reloads the HAProxy configuration with context support.
+func (c *HAProxyClient) ReloadHAProxyWithContext(ctx context.Context) error {
+	if err := c.ensureRuntime(); err != nil {
+		return err
+	}
+	return c.RuntimeClient.ReloadHAProxyWithContext(ctx)
 }
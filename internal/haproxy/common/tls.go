@@ -0,0 +1,56 @@
+package common
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSOptions describes the TLS settings shared by the stats and runtime clients
+type TLSOptions struct {
+	CAFile             string // PEM bundle used to verify the server certificate
+	CertFile           string // Client certificate for mutual TLS
+	KeyFile            string // Private key matching CertFile
+	ServerName         string // Overrides the server name used for verification
+	InsecureSkipVerify bool   // Disables server certificate verification
+}
+
+// IsZero reports whether no TLS settings were provided
+func (o TLSOptions) IsZero() bool {
+	return o == TLSOptions{}
+}
+
+// NewTLSConfig builds a *tls.Config from the given options
+func NewTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         opts.ServerName,
+		InsecureSkipVerify: opts.InsecureSkipVerify, // #nosec G402 -- explicitly requested by configuration
+	}
+
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file %s: %w", opts.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates found in CA file %s", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		if opts.CertFile == "" || opts.KeyFile == "" {
+			return nil, fmt.Errorf("both client certificate and key files must be provided")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
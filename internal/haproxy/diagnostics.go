@@ -0,0 +1,158 @@
+package haproxy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+const (
+	// maxDiagnosisEntries bounds the pools and tasks listed in a ProcessDiagnosis
+	maxDiagnosisEntries = 10
+
+	// longRunQueuePercent is the share of loops with a long run queue flagged as an anomaly
+	longRunQueuePercent = 1
+
+	// fdUsagePercent is the share of maxsock in use flagged as an anomaly
+	fdUsagePercent = 80
+)
+
+// ProcessDiagnosis is a snapshot of the scheduler, threads, memory pools, run
+// queues and file descriptors of the HAProxy process, with anomalies found in
+// them. Sections that could not be collected are left out and listed in Errors.
+type ProcessDiagnosis struct {
+	GeneratedAt string                         `json:"generated_at"`
+	Anomalies   []string                       `json:"anomalies"`
+	Activity    *runtimeclient.ProcessActivity `json:"activity,omitempty"`
+	Threads     []runtimeclient.ThreadInfo     `json:"threads,omitempty"`
+	Pools       *runtimeclient.PoolsInfo       `json:"pools,omitempty"` // Largest pools only
+	Tasks       *runtimeclient.TasksInfo       `json:"tasks,omitempty"` // Most queued functions only
+	FDs         *runtimeclient.FDInfo          `json:"fds,omitempty"`
+	Errors      map[string]string              `json:"errors,omitempty"`
+}
+
+// DiagnoseProcess collects show activity, show threads, show pools, show
+// tasks and show fd into one snapshot and flags stuck threads, allocation
+// failures, long run queues, buffer waits and file descriptor exhaustion.
+func (c *HAProxyClient) DiagnoseProcess() (*ProcessDiagnosis, error) {
+	return c.DiagnoseProcessWithContext(context.Background())
+}
+
+// DiagnoseProcessWithContext collects a snapshot of the HAProxy process with context support.
+func (c *HAProxyClient) DiagnoseProcessWithContext(ctx context.Context) (*ProcessDiagnosis, error) {
+	if err := c.ensureRuntime(); err != nil {
+		return nil, err
+	}
+
+	diagnosis := &ProcessDiagnosis{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Anomalies:   make([]string, 0),
+		Errors:      make(map[string]string),
+	}
+	var maxsock int64
+	var firstErr error
+	collect := func(section string, fn func() error) {
+		if err := fn(); err != nil {
+			diagnosis.Errors[section] = err.Error()
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	collect("activity", func() (err error) {
+		diagnosis.Activity, err = c.RuntimeClient.ShowActivityWithContext(ctx)
+		return err
+	})
+	collect("threads", func() (err error) {
+		diagnosis.Threads, err = c.RuntimeClient.ShowThreadsWithContext(ctx)
+		return err
+	})
+	collect("pools", func() (err error) {
+		diagnosis.Pools, err = c.RuntimeClient.ShowPoolsWithContext(ctx)
+		return err
+	})
+	collect("tasks", func() (err error) {
+		diagnosis.Tasks, err = c.RuntimeClient.ShowTasksWithContext(ctx)
+		return err
+	})
+	collect("fds", func() (err error) {
+		diagnosis.FDs, err = c.RuntimeClient.ShowFDsWithContext(ctx)
+		return err
+	})
+	collect("info", func() error {
+		info, err := c.RuntimeClient.GetProcessInfoWithContext(ctx)
+		if err == nil {
+			maxsock, _ = strconv.ParseInt(info["Maxsock"], 10, 64)
+		}
+		return err
+	})
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(diagnosis.Errors) == 6 {
+		return nil, fmt.Errorf("failed to diagnose process: %w", firstErr)
+	}
+
+	diagnosis.Anomalies = processAnomalies(diagnosis, maxsock)
+	if diagnosis.Pools != nil && len(diagnosis.Pools.Pools) > maxDiagnosisEntries {
+		pools := *diagnosis.Pools
+		pools.Pools = pools.Pools[:maxDiagnosisEntries]
+		diagnosis.Pools = &pools
+	}
+	if diagnosis.Tasks != nil && len(diagnosis.Tasks.Tasks) > maxDiagnosisEntries {
+		tasks := *diagnosis.Tasks
+		tasks.Tasks = tasks.Tasks[:maxDiagnosisEntries]
+		diagnosis.Tasks = &tasks
+	}
+	if len(diagnosis.Errors) == 0 {
+		diagnosis.Errors = nil
+	}
+	return diagnosis, nil
+}
+
+// processAnomalies lists what looks wrong in a diagnosis. maxsock is the
+// process' file descriptor limit, 0 if unknown.
+func processAnomalies(diagnosis *ProcessDiagnosis, maxsock int64) []string {
+	anomalies := make([]string, 0)
+
+	for _, thread := range diagnosis.Threads {
+		if !thread.Stuck {
+			continue
+		}
+		anomaly := fmt.Sprintf("thread %d is stuck", thread.ID)
+		if thread.CurrentTask != "" {
+			anomaly += " running " + thread.CurrentTask
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+
+	if activity := diagnosis.Activity; activity != nil {
+		if failures := activity.Counters["pool_fail"].Total; failures > 0 {
+			anomalies = append(anomalies, fmt.Sprintf("%d memory pool allocations failed", failures))
+		}
+		if loops, long := activity.Counters["loops"].Total, activity.Counters["long_rq"].Total; long > 0 && long*100 >= loops*longRunQueuePercent {
+			anomalies = append(anomalies, fmt.Sprintf("the run queue was long in %d of %d polling loops", long, loops))
+		}
+		if waits := activity.Counters["buf_wait"].Total; waits > 0 {
+			anomalies = append(anomalies, fmt.Sprintf("streams waited %d times for a buffer", waits))
+		}
+	}
+
+	if diagnosis.Pools != nil {
+		for _, pool := range diagnosis.Pools.Pools {
+			if pool.Failures > 0 {
+				anomalies = append(anomalies, fmt.Sprintf("pool %s failed %d allocations", pool.Name, pool.Failures))
+			}
+		}
+	}
+
+	if diagnosis.FDs != nil && maxsock > 0 && int64(diagnosis.FDs.Total)*100 >= maxsock*fdUsagePercent {
+		anomalies = append(anomalies, fmt.Sprintf("%d of %d file descriptors are in use", diagnosis.FDs.Total, maxsock))
+	}
+	return anomalies
+}
@@ -0,0 +1,44 @@
+package haproxy
+
+import (
+	"strings"
+	"testing"
+
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+// TestProcessAnomalies tests the anomalies flagged in a process diagnosis
+func TestProcessAnomalies(t *testing.T) {
+	diagnosis := &ProcessDiagnosis{
+		Activity: &runtimeclient.ProcessActivity{Counters: map[string]runtimeclient.ActivityCounter{
+			"loops":     {Total: 1000},
+			"long_rq":   {Total: 10},
+			"pool_fail": {Total: 2},
+			"buf_wait":  {Total: 5},
+		}},
+		Threads: []runtimeclient.ThreadInfo{{ID: 1}, {ID: 2, Stuck: true, CurrentTask: "h1_io_cb"}, {ID: 3, Stuck: true}},
+		Pools:   &runtimeclient.PoolsInfo{Pools: []runtimeclient.PoolUsage{{Name: "buffer", Failures: 2}, {Name: "filter"}}},
+		FDs:     &runtimeclient.FDInfo{Total: 850},
+	}
+
+	expected := []string{
+		"thread 2 is stuck running h1_io_cb",
+		"thread 3 is stuck",
+		"2 memory pool allocations failed",
+		"the run queue was long in 10 of 1000 polling loops",
+		"streams waited 5 times for a buffer",
+		"pool buffer failed 2 allocations",
+		"850 of 1000 file descriptors are in use",
+	}
+	if got := processAnomalies(diagnosis, 1000); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected anomalies:\n%s", strings.Join(got, "\n"))
+	}
+
+	diagnosis.Activity.Counters["long_rq"] = runtimeclient.ActivityCounter{Total: 9}
+	diagnosis.Activity.Counters["pool_fail"] = runtimeclient.ActivityCounter{}
+	diagnosis.Activity.Counters["buf_wait"] = runtimeclient.ActivityCounter{}
+	diagnosis.Threads, diagnosis.Pools = nil, nil
+	if got := processAnomalies(diagnosis, 0); len(got) != 0 {
+		t.Errorf("Expected no anomalies below the thresholds or without maxsock, got %v", got)
+	}
+}
@@ -15,29 +15,164 @@ type RuntimeClient interface {
 	ExecuteRuntimeCommandWithContext(ctx context.Context, command string) (string, error)
 	GetProcessInfo() (map[string]string, error)
 	GetProcessInfoWithContext(ctx context.Context) (map[string]string, error)
+	ProbeCapabilities() (*runtimeclient.Capabilities, error)
+	ProbeCapabilitiesWithContext(ctx context.Context) (*runtimeclient.Capabilities, error)
 	Close() error
 
 	// Backend operations
 	ListBackends() ([]string, error)
+	ListBackendsWithContext(ctx context.Context) ([]string, error)
 	GetBackendInfo(name string) (*runtimeclient.BackendInfo, error)
+	GetBackendInfoWithContext(ctx context.Context, name string) (*runtimeclient.BackendInfo, error)
 	EnableBackend(name string) error
+	EnableBackendWithContext(ctx context.Context, name string) error
 	DisableBackend(name string) error
+	DisableBackendWithContext(ctx context.Context, name string) error
 
 	// Server operations
 	ListServers(backend string) ([]string, error)
+	ListServersWithContext(ctx context.Context, backend string) ([]string, error)
 	GetServerDetails(backend, server string) (map[string]interface{}, error)
+	GetServerDetailsWithContext(ctx context.Context, backend, server string) (map[string]interface{}, error)
 	EnableServer(backend, server string) error
+	EnableServerWithContext(ctx context.Context, backend, server string) error
 	DisableServer(backend, server string) error
+	DisableServerWithContext(ctx context.Context, backend, server string) error
 	SetServerWeight(backend, server string, weight int) error
+	SetServerWeightWithContext(ctx context.Context, backend, server string, weight int) error
 	SetServerMaxconn(backend, server string, maxconn int) error
+	SetServerMaxconnWithContext(ctx context.Context, backend, server string, maxconn int) error
+	ShutdownSession(id string) error
+	ShutdownSessionWithContext(ctx context.Context, id string) error
+	ShutdownServerSessions(backend, server string) error
+	ShutdownServerSessionsWithContext(ctx context.Context, backend, server string) error
 	GetServerState(backend, server string) (string, error)
+	GetServerStateWithContext(ctx context.Context, backend, server string) (string, error)
+	SetWeight(backend, server string, weight int) (string, error)
+	SetWeightWithContext(ctx context.Context, backend, server string, weight int) (string, error)
+	AddServer(backend, name, addr string, port, weight int) error
+	AddServerWithContext(ctx context.Context, backend, name, addr string, port, weight int) error
+	DelServer(backend, name string) error
+	DelServerWithContext(ctx context.Context, backend, name string) error
+	ShowServersState(backend string) ([]map[string]string, error)
+	ShowServersStateWithContext(ctx context.Context, backend string) ([]map[string]string, error)
+
+	// Health and agent check operations
+	EnableHealth(backend, server string) error
+	EnableHealthWithContext(ctx context.Context, backend, server string) error
+	DisableHealth(backend, server string) error
+	DisableHealthWithContext(ctx context.Context, backend, server string) error
+	EnableAgent(backend, server string) error
+	EnableAgentWithContext(ctx context.Context, backend, server string) error
+	DisableAgent(backend, server string) error
+	DisableAgentWithContext(ctx context.Context, backend, server string) error
+
+	// Statistics operations
+	ShowStat(filter string) ([]map[string]string, error)
+	ShowStatWithContext(ctx context.Context, filter string) ([]map[string]string, error)
+	DebugCounters() (map[string]string, error)
+	DebugCountersWithContext(ctx context.Context) (map[string]string, error)
+	ClearCountersAll() error
+	ClearCountersAllWithContext(ctx context.Context) error
+	DumpStatsFile(filepath string) (string, error)
+	DumpStatsFileWithContext(ctx context.Context, filepath string) (string, error)
+	ShowErrors(proxy string) (string, error)
+	ShowErrorsWithContext(ctx context.Context, proxy string) (string, error)
+	ShowErrorCaptures(proxy, direction string) ([]runtimeclient.ErrorCapture, error)
+	ShowErrorCapturesWithContext(ctx context.Context, proxy, direction string) ([]runtimeclient.ErrorCapture, error)
+	ShowResolvers(id string) ([]runtimeclient.ResolversSection, error)
+	ShowResolversWithContext(ctx context.Context, id string) ([]runtimeclient.ResolversSection, error)
+	ShowPeers(name string) ([]runtimeclient.PeersSection, error)
+	ShowPeersWithContext(ctx context.Context, name string) ([]runtimeclient.PeersSection, error)
+	ShowActivity() (*runtimeclient.ProcessActivity, error)
+	ShowActivityWithContext(ctx context.Context) (*runtimeclient.ProcessActivity, error)
+	ShowThreads() ([]runtimeclient.ThreadInfo, error)
+	ShowThreadsWithContext(ctx context.Context) ([]runtimeclient.ThreadInfo, error)
+	ShowPools() (*runtimeclient.PoolsInfo, error)
+	ShowPoolsWithContext(ctx context.Context) (*runtimeclient.PoolsInfo, error)
+	ShowTasks() (*runtimeclient.TasksInfo, error)
+	ShowTasksWithContext(ctx context.Context) (*runtimeclient.TasksInfo, error)
+	ShowFDs() (*runtimeclient.FDInfo, error)
+	ShowFDsWithContext(ctx context.Context) (*runtimeclient.FDInfo, error)
+
+	// Map operations
+	ListMaps() ([]runtimeclient.MapInfo, error)
+	ListMapsWithContext(ctx context.Context) ([]runtimeclient.MapInfo, error)
+	ShowMap(id string) ([]runtimeclient.MapEntry, error)
+	ShowMapWithContext(ctx context.Context, id string) ([]runtimeclient.MapEntry, error)
+
+	// SSL certificate operations
+	ListSSLCerts() (*runtimeclient.SSLCertList, error)
+	ListSSLCertsWithContext(ctx context.Context) (*runtimeclient.SSLCertList, error)
+	ShowSSLCert(name string) (*runtimeclient.SSLCertInfo, error)
+	ShowSSLCertWithContext(ctx context.Context, name string) (*runtimeclient.SSLCertInfo, error)
+	NewSSLCert(name string) error
+	NewSSLCertWithContext(ctx context.Context, name string) error
+	SetSSLCert(name, payload string) error
+	SetSSLCertWithContext(ctx context.Context, name, payload string) error
+	CommitSSLCert(name string) error
+	CommitSSLCertWithContext(ctx context.Context, name string) error
+	AbortSSLCert(name string) error
+	AbortSSLCertWithContext(ctx context.Context, name string) error
+	DelSSLCert(name string) error
+	DelSSLCertWithContext(ctx context.Context, name string) error
+
+	// crt-list operations
+	ListCrtLists() ([]string, error)
+	ListCrtListsWithContext(ctx context.Context) ([]string, error)
+	ShowCrtList(crtList string) ([]runtimeclient.CrtListEntry, error)
+	ShowCrtListWithContext(ctx context.Context, crtList string) ([]runtimeclient.CrtListEntry, error)
+	AddCrtListEntry(crtList string, entry runtimeclient.CrtListEntry) error
+	AddCrtListEntryWithContext(ctx context.Context, crtList string, entry runtimeclient.CrtListEntry) error
+	DelCrtListEntry(crtList, certificate string, line int) error
+	DelCrtListEntryWithContext(ctx context.Context, crtList, certificate string, line int) error
+
+	// CA and CRL file operations
+	ListCAFiles() (*runtimeclient.SSLFileList, error)
+	ListCAFilesWithContext(ctx context.Context) (*runtimeclient.SSLFileList, error)
+	ShowCAFile(name string) (*runtimeclient.CAFileInfo, error)
+	ShowCAFileWithContext(ctx context.Context, name string) (*runtimeclient.CAFileInfo, error)
+	NewCAFile(name string) error
+	NewCAFileWithContext(ctx context.Context, name string) error
+	SetCAFile(name, payload string) error
+	SetCAFileWithContext(ctx context.Context, name, payload string) error
+	AddCAFile(name, payload string) error
+	AddCAFileWithContext(ctx context.Context, name, payload string) error
+	CommitCAFile(name string) error
+	CommitCAFileWithContext(ctx context.Context, name string) error
+	AbortCAFile(name string) error
+	AbortCAFileWithContext(ctx context.Context, name string) error
+	ListCRLFiles() (*runtimeclient.SSLFileList, error)
+	ListCRLFilesWithContext(ctx context.Context) (*runtimeclient.SSLFileList, error)
+	ShowCRLFile(name string) (*runtimeclient.CRLFileInfo, error)
+	ShowCRLFileWithContext(ctx context.Context, name string) (*runtimeclient.CRLFileInfo, error)
+	SetCRLFile(name, payload string) error
+	SetCRLFileWithContext(ctx context.Context, name, payload string) error
+	CommitCRLFile(name string) error
+	CommitCRLFileWithContext(ctx context.Context, name string) error
+	AbortCRLFile(name string) error
+	AbortCRLFileWithContext(ctx context.Context, name string) error
+
+	// OCSP operations
+	ListOCSPResponses() ([]runtimeclient.OCSPResponseID, error)
+	ListOCSPResponsesWithContext(ctx context.Context) ([]runtimeclient.OCSPResponseID, error)
+	ShowOCSPResponse(id string) (*runtimeclient.OCSPResponse, error)
+	ShowOCSPResponseWithContext(ctx context.Context, id string) (*runtimeclient.OCSPResponse, error)
+	UpdateOCSPResponse(certificate string) error
+	UpdateOCSPResponseWithContext(ctx context.Context, certificate string) error
+
+	// Process operations
+	ReloadHAProxy() error
+	ReloadHAProxyWithContext(ctx context.Context) error
 }
 
 // StatsClient defines the interface for interacting with HAProxy's Stats API
 type StatsClient interface {
 	// Stats API operations
 	GetStats() (*stats.HAProxyStats, error)
+	GetStatsWithContext(ctx context.Context) (*stats.HAProxyStats, error)
 	GetSchema() (*stats.StatsSchema, error)
+	GetSchemaWithContext(ctx context.Context) (*stats.StatsSchema, error)
 
 	// Data filtering operations
 	FilterStats(stats *stats.HAProxyStats, proxyName, serviceName string) []common.StatItem
@@ -46,3 +181,92 @@ type StatsClient interface {
 	GetServers(stats *stats.HAProxyStats) []common.StatItem
 	GetServersByBackend(stats *stats.HAProxyStats, backendName string) []common.StatItem
 }
+
+// Client defines every operation the MCP tools and resources perform against HAProxy.
+// HAProxyClient implements it; tests can drive the tools through a HAProxyClient
+// built on the mocks in the testing package, without a live socket.
+type Client interface {
+	// Raw Runtime API access
+	ExecuteRuntimeCommandWithContext(ctx context.Context, command string) (string, error)
+	ProbeCapabilitiesWithContext(ctx context.Context) (*runtimeclient.Capabilities, error)
+
+	// Process and statistics operations
+	GetRuntimeInfoWithContext(ctx context.Context) (map[string]string, error)
+	ShowStatWithContext(ctx context.Context, filter string) ([]map[string]string, error)
+	ShowStatRowsWithContext(ctx context.Context, filter string) ([]map[string]string, error)
+	ShowStatPageWithContext(ctx context.Context, filter string, query RowQuery) (*RowPage, error)
+	DebugCountersWithContext(ctx context.Context) (map[string]interface{}, error)
+	ClearCountersAllWithContext(ctx context.Context) error
+	DumpStatsFileWithContext(ctx context.Context, filepath string) (string, error)
+	ShowErrorsWithContext(ctx context.Context, proxy string) (string, error)
+	ShowErrorCapturesWithContext(ctx context.Context, proxy, direction string) ([]runtimeclient.ErrorCapture, error)
+	ShowResolversWithContext(ctx context.Context, id string) ([]runtimeclient.ResolversSection, error)
+	ResolverHealthWithContext(ctx context.Context, id string, maxFailurePercent int) (*ResolverHealthReport, error)
+	ShowPeersWithContext(ctx context.Context, name string) ([]runtimeclient.PeersSection, error)
+	PeersReportWithContext(ctx context.Context, name string) (*PeersReport, error)
+	DiagnoseProcessWithContext(ctx context.Context) (*ProcessDiagnosis, error)
+	ReloadHAProxyWithContext(ctx context.Context) error
+
+	// Backend operations
+	GetBackendsWithContext(ctx context.Context) ([]string, error)
+	GetBackendDetailsWithContext(ctx context.Context, name string) (map[string]interface{}, error)
+	ShowServersStateWithContext(ctx context.Context, backend string) ([]map[string]string, error)
+	ShowServersStatePageWithContext(ctx context.Context, backend string, query RowQuery) (*RowPage, error)
+
+	// Server operations
+	ListServersWithContext(ctx context.Context, backend string) ([]string, error)
+	GetServerDetailsWithContext(ctx context.Context, backend, server string) (map[string]interface{}, error)
+	AddServerWithContext(ctx context.Context, backend, name, addr string, port, weight int) error
+	DelServerWithContext(ctx context.Context, backend, name string) error
+	EnableServerWithContext(ctx context.Context, backend, server string) error
+	DisableServerWithContext(ctx context.Context, backend, server string) error
+	SetWeightWithContext(ctx context.Context, backend, server string, weight int) (string, error)
+	SetServerMaxconnWithContext(ctx context.Context, backend, server string, maxconn int) error
+	ShutdownSessionWithContext(ctx context.Context, id string) error
+	ShutdownServerSessionsWithContext(ctx context.Context, backend, server string) error
+
+	// Health and agent check operations
+	EnableHealthWithContext(ctx context.Context, backend, server string) error
+	DisableHealthWithContext(ctx context.Context, backend, server string) error
+	EnableAgentWithContext(ctx context.Context, backend, server string) error
+	DisableAgentWithContext(ctx context.Context, backend, server string) error
+
+	// Map operations
+	ListMapsWithContext(ctx context.Context) ([]runtimeclient.MapInfo, error)
+	ShowMapWithContext(ctx context.Context, id string) ([]runtimeclient.MapEntry, error)
+
+	// SSL certificate operations
+	ListSSLCertsWithContext(ctx context.Context) (*runtimeclient.SSLCertList, error)
+	ShowSSLCertWithContext(ctx context.Context, name string) (*runtimeclient.SSLCertInfo, error)
+	CertExpiryReportWithContext(ctx context.Context, warningDays, criticalDays int) (*CertExpiryReport, error)
+	NewSSLCertWithContext(ctx context.Context, name string) error
+	SetSSLCertWithContext(ctx context.Context, name, payload string) error
+	CommitSSLCertWithContext(ctx context.Context, name string) error
+	AbortSSLCertWithContext(ctx context.Context, name string) error
+	DelSSLCertWithContext(ctx context.Context, name string) error
+
+	// crt-list operations
+	ListCrtListsWithContext(ctx context.Context) ([]string, error)
+	ShowCrtListWithContext(ctx context.Context, crtList string) ([]runtimeclient.CrtListEntry, error)
+	AddCrtListEntryWithContext(ctx context.Context, crtList string, entry runtimeclient.CrtListEntry) error
+	DelCrtListEntryWithContext(ctx context.Context, crtList, certificate string, line int) error
+
+	// CA and CRL file operations
+	ListCAFilesWithContext(ctx context.Context) (*runtimeclient.SSLFileList, error)
+	ShowCAFileWithContext(ctx context.Context, name string) (*runtimeclient.CAFileInfo, error)
+	NewCAFileWithContext(ctx context.Context, name string) error
+	SetCAFileWithContext(ctx context.Context, name, payload string) error
+	AddCAFileWithContext(ctx context.Context, name, payload string) error
+	CommitCAFileWithContext(ctx context.Context, name string) error
+	AbortCAFileWithContext(ctx context.Context, name string) error
+	ListCRLFilesWithContext(ctx context.Context) (*runtimeclient.SSLFileList, error)
+	ShowCRLFileWithContext(ctx context.Context, name string) (*runtimeclient.CRLFileInfo, error)
+	SetCRLFileWithContext(ctx context.Context, name, payload string) error
+	CommitCRLFileWithContext(ctx context.Context, name string) error
+	AbortCRLFileWithContext(ctx context.Context, name string) error
+
+	// OCSP operations
+	ListOCSPResponsesWithContext(ctx context.Context) ([]runtimeclient.OCSPResponseID, error)
+	ShowOCSPResponseWithContext(ctx context.Context, id string) (*runtimeclient.OCSPResponse, error)
+	UpdateOCSPResponseWithContext(ctx context.Context, certificate string) error
+}
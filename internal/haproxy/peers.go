@@ -0,0 +1,101 @@
+package haproxy
+
+import (
+	"fmt"
+
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+// PeerStatus is a remote peer of a PeersReport
+type PeerStatus struct {
+	Section   string                    `json:"section"`
+	Peer      string                    `json:"peer"`
+	Address   string                    `json:"address"`
+	Connected bool                      `json:"connected"`
+	Synced    bool                      `json:"synced"` // Every shared table is in sync
+	Status    string                    `json:"status,omitempty"`
+	Reconnect string                    `json:"reconnect,omitempty"`
+	Issues    []string                  `json:"issues,omitempty"`
+	Tables    []runtimeclient.PeerTable `json:"tables"`
+}
+
+// PeersReport summarizes whether every remote peer is connected and in sync
+type PeersReport struct {
+	Healthy      bool         `json:"healthy"` // Every remote peer is connected and in sync
+	Connected    int          `json:"connected"`
+	Disconnected int          `json:"disconnected"`
+	OutOfSync    int          `json:"out_of_sync"`
+	Peers        []PeerStatus `json:"peers"`
+}
+
+// peerConnected reports whether a peer has an established connection. From
+// HAProxy 2.4 the connection state is reported separately from the status of
+// the last handshake, which stays ESTA after the connection is lost.
+func peerConnected(peer runtimeclient.PeerInfo) bool {
+	if peer.LastHandshake != "" || peer.State != "" {
+		return peer.State == "EST"
+	}
+	return peer.Status == "ESTA"
+}
+
+// newPeerStatus checks the connection and the shared tables of a remote peer
+func newPeerStatus(section string, peer runtimeclient.PeerInfo) PeerStatus {
+	status := PeerStatus{
+		Section:   section,
+		Peer:      peer.Name,
+		Address:   peer.Address,
+		Connected: peerConnected(peer),
+		Synced:    true,
+		Status:    peer.Status,
+		Reconnect: peer.Reconnect,
+		Tables:    peer.Tables,
+	}
+	if !status.Connected {
+		status.Issues = append(status.Issues, fmt.Sprintf("not connected (last status %s, next attempt in %s)", peer.Status, peer.Reconnect))
+	}
+	for _, table := range peer.Tables {
+		if table.Synced {
+			continue
+		}
+		status.Synced = false
+		if pending := table.LocalUpdate - table.LastPushed; pending > 0 {
+			status.Issues = append(status.Issues, fmt.Sprintf("table %s: %d local updates not pushed", table.Table, pending))
+		}
+		if unacked := table.LastPushed - table.LastAcked; unacked > 0 {
+			status.Issues = append(status.Issues, fmt.Sprintf("table %s: %d pushed updates not acknowledged", table.Table, unacked))
+		}
+		if table.LocalUpdate < table.LastPushed || table.LastPushed < table.LastAcked {
+			// Update counters wrap around or were reset by a resync
+			status.Issues = append(status.Issues, fmt.Sprintf("table %s: acknowledged %d, pushed %d, local %d", table.Table, table.LastAcked, table.LastPushed, table.LocalUpdate))
+		}
+	}
+	return status
+}
+
+// newPeersReport checks the remote peers of the enabled sections. The local
+// peer, which is this HAProxy, is left out.
+func newPeersReport(sections []runtimeclient.PeersSection) *PeersReport {
+	report := &PeersReport{Healthy: true, Peers: make([]PeerStatus, 0)}
+	for _, section := range sections {
+		if section.Disabled {
+			continue
+		}
+		for _, peer := range section.Peers {
+			if peer.Local {
+				continue
+			}
+			status := newPeerStatus(section.Name, peer)
+			if status.Connected {
+				report.Connected++
+			} else {
+				report.Disconnected++
+			}
+			if !status.Synced {
+				report.OutOfSync++
+			}
+			report.Healthy = report.Healthy && status.Connected && status.Synced
+			report.Peers = append(report.Peers, status)
+		}
+	}
+	return report
+}
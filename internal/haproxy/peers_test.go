@@ -0,0 +1,44 @@
+package haproxy
+
+import (
+	"strings"
+	"testing"
+
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+// TestPeersReport tests the connection and synchronization checks of remote peers
+func TestPeersReport(t *testing.T) {
+	synced := runtimeclient.PeerTable{Table: "stkt", LastAcked: 7, LastPushed: 7, LocalUpdate: 7, Synced: true}
+	sections := []runtimeclient.PeersSection{
+		{Name: "mypeers", Peers: []runtimeclient.PeerInfo{
+			{Name: "self", Local: true, Status: "NONE"},
+			{Name: "ok", Status: "ESTA", State: "EST", LastHandshake: "2s", Tables: []runtimeclient.PeerTable{synced}},
+			{Name: "lagging", Status: "ESTA", State: "EST", LastHandshake: "2s", Tables: []runtimeclient.PeerTable{{Table: "stkt", LastAcked: 5, LastPushed: 6, LocalUpdate: 9}}},
+			{Name: "lost", Status: "ESTA", LastHandshake: "1m", Reconnect: "3s", Tables: []runtimeclient.PeerTable{synced}},
+		}},
+		{Name: "legacy", Peers: []runtimeclient.PeerInfo{
+			{Name: "old", Status: "ESTA", Tables: []runtimeclient.PeerTable{synced}},
+		}},
+		{Name: "off", Disabled: true, Peers: []runtimeclient.PeerInfo{{Name: "ignored", Status: "NONE"}}},
+	}
+	report := newPeersReport(sections)
+
+	if report.Healthy || report.Connected != 3 || report.Disconnected != 1 || report.OutOfSync != 1 || len(report.Peers) != 4 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	lagging := report.Peers[1]
+	if lagging.Synced || strings.Join(lagging.Issues, "; ") != "table stkt: 3 local updates not pushed; table stkt: 1 pushed updates not acknowledged" {
+		t.Errorf("Unexpected lagging peer: %+v", lagging)
+	}
+	if lost := report.Peers[2]; lost.Connected || len(lost.Issues) != 1 || !strings.Contains(lost.Issues[0], "next attempt in 3s") {
+		t.Errorf("Expected a lost connection to be reported, got %+v", lost)
+	}
+	if old := report.Peers[3]; !old.Connected || !old.Synced {
+		t.Errorf("Expected the HAProxy 2.0 peer to be connected and synced, got %+v", old)
+	}
+
+	if healthy := newPeersReport(nil); !healthy.Healthy || len(healthy.Peers) != 0 {
+		t.Errorf("Expected no sections to be healthy, got %+v", healthy)
+	}
+}
@@ -0,0 +1,251 @@
+package haproxy
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Page size limits for RowQuery
+const (
+	DefaultRowLimit = 100
+	MaxRowLimit     = 1000
+)
+
+// statTypes maps the names accepted by RowQuery.Types to the show stat type column
+var statTypes = map[string]string{
+	"frontend": "0",
+	"backend":  "1",
+	"server":   "2",
+	"listener": "3",
+}
+
+// serverOpStatuses maps the srv_op_state values of show servers state to the
+// statuses of the show stat status column
+var serverOpStatuses = map[string]string{
+	"0": "DOWN",
+	"1": "STARTING",
+	"2": "UP",
+	"3": "STOPPING",
+}
+
+// Bits of the srv_admin_state column of show servers state
+const (
+	adminStateMaint = 0x01 | 0x02 | 0x04 | 0x20 // Forced, inherited, configured or DNS resolution maintenance
+	adminStateDrain = 0x08 | 0x10               // Forced or inherited drain
+)
+
+// ServerStatus returns the status of a show servers state row in the
+// vocabulary of the show stat status column: MAINT or DRAIN when set by the
+// administrative state, otherwise UP, DOWN, STARTING or STOPPING.
+func ServerStatus(row map[string]string) string {
+	if admin, err := strconv.Atoi(row["srv_admin_state"]); err == nil {
+		if admin&adminStateMaint != 0 {
+			return "MAINT"
+		}
+		if admin&adminStateDrain != 0 {
+			return "DRAIN"
+		}
+	}
+	if status, ok := serverOpStatuses[row["srv_op_state"]]; ok {
+		return status
+	}
+	return row["srv_op_state"]
+}
+
+// RowQuery selects, filters, sorts and pages the rows of a table such as show stat
+type RowQuery struct {
+	Fields    []string // Columns to return; all when empty
+	Types     []string // show stat row types: frontend, backend, server or listener
+	Statuses  []string // Status prefixes, e.g. UP, DOWN or MAINT; case-insensitive
+	NameRegex string   // Matches the proxy or server name
+	SortBy    string   // Column to sort by; a leading "-" sorts in descending order
+	Limit     int      // Page size; DefaultRowLimit when 0, at most MaxRowLimit
+	Cursor    string   // NextCursor of the previous page
+}
+
+// RowPage is one page of a queried table
+type RowPage struct {
+	Rows       []map[string]string `json:"rows"`
+	Total      int                 `json:"total"`                 // Rows matching the filters across all pages
+	NextCursor string              `json:"next_cursor,omitempty"` // Cursor of the next page, empty on the last page
+}
+
+// tableColumns names the columns a RowQuery filters on
+type tableColumns struct {
+	names  []string                           // Proxy and server name columns
+	typ    func(row map[string]string) string // Row type as in the show stat type column, if the table has types
+	status func(row map[string]string) []string
+}
+
+// statColumns describes show stat rows
+var statColumns = tableColumns{
+	names: []string{"pxname", "svname"},
+	typ: func(row map[string]string) string {
+		if t, ok := row["type"]; ok {
+			return t
+		}
+		// Rows from the stats page may lack the type column
+		switch row["svname"] {
+		case "FRONTEND":
+			return statTypes["frontend"]
+		case "BACKEND":
+			return statTypes["backend"]
+		default:
+			return statTypes["server"]
+		}
+	},
+	status: func(row map[string]string) []string {
+		return []string{row["status"]}
+	},
+}
+
+// serversStateColumns describes show servers state rows, whose status is
+// derived from the operational and administrative states (see ServerStatus)
+var serversStateColumns = tableColumns{
+	names: []string{"be_name", "srv_name"},
+	status: func(row map[string]string) []string {
+		return []string{ServerStatus(row)}
+	},
+}
+
+// apply runs the query over rows described by columns
+func (q RowQuery) apply(rows []map[string]string, columns tableColumns) (*RowPage, error) {
+	limit := q.Limit
+	switch {
+	case limit < 0:
+		return nil, fmt.Errorf("limit must not be negative")
+	case limit == 0:
+		limit = DefaultRowLimit
+	case limit > MaxRowLimit:
+		limit = MaxRowLimit
+	}
+
+	offset := 0
+	if q.Cursor != "" {
+		var err error
+		if offset, err = strconv.Atoi(q.Cursor); err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid cursor %q", q.Cursor)
+		}
+	}
+
+	var nameRegex *regexp.Regexp
+	if q.NameRegex != "" {
+		var err error
+		if nameRegex, err = regexp.Compile(q.NameRegex); err != nil {
+			return nil, fmt.Errorf("invalid name regex: %w", err)
+		}
+	}
+
+	types := make(map[string]bool, len(q.Types))
+	for _, name := range q.Types {
+		if columns.typ == nil {
+			return nil, fmt.Errorf("rows cannot be filtered by type")
+		}
+		t, ok := statTypes[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q: expected frontend, backend, server or listener", name)
+		}
+		types[t] = true
+	}
+
+	matched := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		if len(types) > 0 && !types[columns.typ(row)] {
+			continue
+		}
+		if len(q.Statuses) > 0 && !matchStatus(columns.status(row), q.Statuses) {
+			continue
+		}
+		if nameRegex != nil && !matchName(row, columns.names, nameRegex) {
+			continue
+		}
+		matched = append(matched, row)
+	}
+
+	if q.SortBy != "" {
+		field, descending := strings.CutPrefix(q.SortBy, "-")
+		if len(matched) > 0 && !hasColumn(matched, field) {
+			return nil, fmt.Errorf("cannot sort by %q: no row has this column", field)
+		}
+		sort.SliceStable(matched, func(i, j int) bool {
+			if descending {
+				return lessValue(matched[j][field], matched[i][field])
+			}
+			return lessValue(matched[i][field], matched[j][field])
+		})
+	}
+
+	page := &RowPage{Rows: []map[string]string{}, Total: len(matched)}
+	if offset >= len(matched) {
+		return page, nil
+	}
+	end := offset + limit
+	if end < len(matched) {
+		page.NextCursor = strconv.Itoa(end)
+	} else {
+		end = len(matched)
+	}
+	for _, row := range matched[offset:end] {
+		page.Rows = append(page.Rows, selectFields(row, q.Fields))
+	}
+	return page, nil
+}
+
+// matchStatus reports whether any status starts with any of the wanted prefixes
+func matchStatus(statuses, wanted []string) bool {
+	for _, status := range statuses {
+		for _, w := range wanted {
+			if w != "" && strings.HasPrefix(strings.ToUpper(status), strings.ToUpper(w)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchName reports whether any name column of row matches re
+func matchName(row map[string]string, names []string, re *regexp.Regexp) bool {
+	for _, column := range names {
+		if re.MatchString(row[column]) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasColumn reports whether any row has column
+func hasColumn(rows []map[string]string, column string) bool {
+	for _, row := range rows {
+		if _, ok := row[column]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// lessValue orders numerically when both values are numbers, otherwise as strings
+func lessValue(a, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return x < y
+	}
+	return a < b
+}
+
+// selectFields returns a copy of row restricted to fields, or row itself when fields is empty
+func selectFields(row map[string]string, fields []string) map[string]string {
+	if len(fields) == 0 {
+		return row
+	}
+	selected := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if value, ok := row[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected
+}
@@ -0,0 +1,100 @@
+package haproxy
+
+import (
+	"strings"
+	"testing"
+)
+
+// statRows is a small show stat table
+var statRows = []map[string]string{
+	{"pxname": "http-in", "svname": "FRONTEND", "type": "0", "status": "OPEN", "scur": "40"},
+	{"pxname": "api", "svname": "api1", "type": "2", "status": "UP", "scur": "12"},
+	{"pxname": "api", "svname": "api2", "type": "2", "status": "DOWN", "scur": "0"},
+	{"pxname": "api", "svname": "api3", "type": "2", "status": "UP 1/3", "scur": "7"},
+	{"pxname": "api", "svname": "BACKEND", "type": "1", "status": "UP", "scur": "19"},
+	{"pxname": "static", "svname": "web1", "type": "2", "status": "MAINT", "scur": "3"},
+}
+
+// names returns the svname of each row
+func names(page *RowPage, column string) string {
+	var out []string
+	for _, row := range page.Rows {
+		out = append(out, row[column])
+	}
+	return strings.Join(out, ",")
+}
+
+// TestRowQuery tests filtering, sorting, field selection and pagination
+func TestRowQuery(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query RowQuery
+		rows  string
+		total int
+		next  string
+	}{
+		{name: "Defaults", query: RowQuery{}, rows: "FRONTEND,api1,api2,api3,BACKEND,web1", total: 6},
+		{name: "Servers only", query: RowQuery{Types: []string{"server"}}, rows: "api1,api2,api3,web1", total: 4},
+		{name: "Status prefixes", query: RowQuery{Statuses: []string{"down", "MAINT"}}, rows: "api2,web1", total: 2},
+		{name: "Partial UP status", query: RowQuery{Types: []string{"server"}, Statuses: []string{"UP"}}, rows: "api1,api3", total: 2},
+		{name: "Name regex", query: RowQuery{NameRegex: "^api[13]$"}, rows: "api1,api3", total: 2},
+		{name: "Numeric sort descending", query: RowQuery{SortBy: "-scur"}, rows: "FRONTEND,BACKEND,api1,api3,web1,api2", total: 6},
+		{name: "First page", query: RowQuery{Types: []string{"server"}, Limit: 3}, rows: "api1,api2,api3", total: 4, next: "3"},
+		{name: "Last page", query: RowQuery{Types: []string{"server"}, Limit: 3, Cursor: "3"}, rows: "web1", total: 4},
+		{name: "Past the end", query: RowQuery{Cursor: "10"}, rows: "", total: 6},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, err := tc.query.apply(statRows, statColumns)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := names(page, "svname"); got != tc.rows || page.Total != tc.total || page.NextCursor != tc.next {
+				t.Errorf("Expected rows %q total %d next %q, got %q total %d next %q", tc.rows, tc.total, tc.next, got, page.Total, page.NextCursor)
+			}
+		})
+	}
+
+	page, err := RowQuery{Fields: []string{"svname", "scur", "missing"}, Limit: 1}.apply(statRows, statColumns)
+	if err != nil || len(page.Rows[0]) != 2 || page.Rows[0]["scur"] != "40" {
+		t.Errorf("Expected only the selected fields, got %v %v", err, page)
+	}
+
+	for _, q := range []RowQuery{{Limit: -1}, {Cursor: "abc"}, {NameRegex: "("}, {Types: []string{"pool"}}, {SortBy: "-rate"}} {
+		if _, err := q.apply(statRows, statColumns); err == nil {
+			t.Errorf("Expected error for query %+v", q)
+		}
+	}
+}
+
+// TestServersStateQuery tests the derived status of show servers state rows
+func TestServersStateQuery(t *testing.T) {
+	rows := []map[string]string{
+		{"be_name": "api", "srv_name": "api1", "srv_op_state": "2", "srv_admin_state": "0"},
+		{"be_name": "api", "srv_name": "api2", "srv_op_state": "0", "srv_admin_state": "1"},
+		{"be_name": "api", "srv_name": "api3", "srv_op_state": "2", "srv_admin_state": "8"},
+		{"be_name": "api", "srv_name": "api4", "srv_op_state": "0", "srv_admin_state": "0"},
+	}
+
+	testCases := []struct {
+		status string
+		rows   string
+	}{
+		{status: "UP", rows: "api1"},
+		{status: "MAINT", rows: "api2"},
+		{status: "DRAIN", rows: "api3"},
+		{status: "DOWN", rows: "api4"},
+		{status: "STOPPED", rows: ""},
+	}
+	for _, tc := range testCases {
+		page, err := RowQuery{Statuses: []string{tc.status}}.apply(rows, serversStateColumns)
+		if err != nil || names(page, "srv_name") != tc.rows {
+			t.Errorf("Expected %s to select %q, got %v %q", tc.status, tc.rows, err, names(page, "srv_name"))
+		}
+	}
+
+	if _, err := (RowQuery{Types: []string{"server"}}).apply(rows, serversStateColumns); err == nil {
+		t.Error("Expected type filter to be rejected for server state rows")
+	}
+}
@@ -0,0 +1,89 @@
+package haproxy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+// DefaultMaxNameserverFailurePercent is the share of queries, in percent, a
+// nameserver of a ResolverHealthReport may fail before it is flagged
+const DefaultMaxNameserverFailurePercent = 5
+
+// Statuses of the nameservers in a ResolverHealthReport
+const (
+	NameserverFailing = "failing"
+	NameserverOK      = "ok"
+	NameserverIdle    = "idle" // No query was sent yet
+)
+
+// NameserverHealth is a nameserver of a ResolverHealthReport
+type NameserverHealth struct {
+	Resolvers      string                        `json:"resolvers"`
+	Nameserver     string                        `json:"nameserver"`
+	Status         string                        `json:"status"`
+	FailurePercent float64                       `json:"failure_percent"` // Timeouts, refused and errors per query sent
+	Issues         []string                      `json:"issues,omitempty"`
+	Counters       runtimeclient.NameserverStats `json:"counters"`
+}
+
+// ResolverHealthReport flags the nameservers failing to answer queries
+type ResolverHealthReport struct {
+	MaxFailurePercent int                `json:"max_failure_percent"`
+	Summary           map[string]int     `json:"summary"` // Nameservers per status
+	Nameservers       []NameserverHealth `json:"nameservers"`
+}
+
+// newNameserverHealth classifies a nameserver from its counters
+func newNameserverHealth(resolvers string, stats runtimeclient.NameserverStats, maxFailurePercent int) NameserverHealth {
+	health := NameserverHealth{Resolvers: resolvers, Nameserver: stats.Name, Status: NameserverOK, Counters: stats}
+	if stats.Sent == 0 {
+		health.Status = NameserverIdle
+		return health
+	}
+
+	failures := stats.Timeouts + stats.Refused + stats.Errors
+	health.FailurePercent = math.Round(float64(failures)*10000/float64(stats.Sent)) / 100
+	if stats.Valid == 0 {
+		health.Issues = append(health.Issues, fmt.Sprintf("none of %d queries got a valid response", stats.Sent))
+	}
+	if health.FailurePercent >= float64(maxFailurePercent) {
+		var causes []string
+		for _, cause := range []struct {
+			count int64
+			name  string
+		}{{stats.Timeouts, "timeouts"}, {stats.Refused, "refused"}, {stats.Errors, "errors"}} {
+			if cause.count > 0 {
+				causes = append(causes, fmt.Sprintf("%d %s", cause.count, cause.name))
+			}
+		}
+		health.Issues = append(health.Issues, fmt.Sprintf("%.2f%% of %d queries failed (%s)", health.FailurePercent, stats.Sent, strings.Join(causes, ", ")))
+	}
+	if len(health.Issues) > 0 {
+		health.Status = NameserverFailing
+	}
+	return health
+}
+
+// newResolverHealthReport classifies the nameservers of sections, failing ones first
+func newResolverHealthReport(sections []runtimeclient.ResolversSection, maxFailurePercent int) *ResolverHealthReport {
+	report := &ResolverHealthReport{
+		MaxFailurePercent: maxFailurePercent,
+		Summary:           map[string]int{NameserverFailing: 0, NameserverOK: 0, NameserverIdle: 0},
+		Nameservers:       make([]NameserverHealth, 0),
+	}
+	for _, section := range sections {
+		for _, stats := range section.Nameservers {
+			health := newNameserverHealth(section.Name, stats, maxFailurePercent)
+			report.Summary[health.Status]++
+			report.Nameservers = append(report.Nameservers, health)
+		}
+	}
+	sort.SliceStable(report.Nameservers, func(i, j int) bool {
+		return report.Nameservers[i].Status == NameserverFailing && report.Nameservers[j].Status != NameserverFailing
+	})
+	return report
+}
@@ -0,0 +1,49 @@
+package haproxy
+
+import (
+	"strings"
+	"testing"
+
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+// TestResolverHealthReport tests the flagging of failing nameservers
+func TestResolverHealthReport(t *testing.T) {
+	sections := []runtimeclient.ResolversSection{
+		{Name: "dc1", Nameservers: []runtimeclient.NameserverStats{
+			{Name: "healthy", Sent: 1000, Valid: 990, NX: 10, Timeouts: 3},
+			{Name: "slow", Sent: 200, Valid: 180, Timeouts: 12, Refused: 8},
+			{Name: "idle", Sent: 0},
+		}},
+		{Name: "dc2", Nameservers: []runtimeclient.NameserverStats{
+			{Name: "dead", Sent: 3, SendErrors: 3, Errors: 3},
+		}},
+	}
+	report := newResolverHealthReport(sections, DefaultMaxNameserverFailurePercent)
+
+	var order, statuses []string
+	for _, ns := range report.Nameservers {
+		order = append(order, ns.Resolvers+"/"+ns.Nameserver)
+		statuses = append(statuses, ns.Status)
+	}
+	if got := strings.Join(order, ","); got != "dc1/slow,dc2/dead,dc1/healthy,dc1/idle" {
+		t.Errorf("Unexpected order: %s", got)
+	}
+	if got := strings.Join(statuses, ","); got != "failing,failing,ok,idle" {
+		t.Errorf("Unexpected statuses: %s", got)
+	}
+	if report.Summary[NameserverFailing] != 2 || report.Summary[NameserverOK] != 1 || report.Summary[NameserverIdle] != 1 {
+		t.Errorf("Unexpected summary: %v", report.Summary)
+	}
+
+	slow := report.Nameservers[0]
+	if slow.FailurePercent != 10 || len(slow.Issues) != 1 || slow.Issues[0] != "10.00% of 200 queries failed (12 timeouts, 8 refused)" {
+		t.Errorf("Unexpected slow nameserver: %+v", slow)
+	}
+	if dead := report.Nameservers[1]; len(dead.Issues) != 2 || !strings.Contains(dead.Issues[0], "none of 3 queries") {
+		t.Errorf("Unexpected dead nameserver issues: %v", dead.Issues)
+	}
+	if healthy := report.Nameservers[2]; healthy.FailurePercent != 0.3 || healthy.Issues != nil {
+		t.Errorf("Unexpected healthy nameserver: %+v", healthy)
+	}
+}
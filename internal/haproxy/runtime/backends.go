@@ -1,18 +1,25 @@
 package haproxy
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strconv"
 	"strings"
 )
 
 // ListBackends returns a list of all HAProxy backends.
 func (c *HAProxyClient) ListBackends() ([]string, error) {
+	return c.ListBackendsWithContext(context.Background())
+}
+
+// ListBackendsWithContext returns a list of all HAProxy backends with context support.
+func (c *HAProxyClient) ListBackendsWithContext(ctx context.Context) ([]string, error) {
 	slog.Debug("Listing all HAProxy backends")
 
 	// Use show stat command to get all stats and extract backend names
-	result, err := c.ExecuteRuntimeCommand("show stat")
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show stat")
 	if err != nil {
 		slog.Error("Failed to get stats for backends", "error", err)
 		return nil, fmt.Errorf("failed to get stats for backends: %w", err)
@@ -29,7 +36,7 @@ func (c *HAProxyClient) ListBackends() ([]string, error) {
 	backendSet := make(map[string]bool)
 	for _, stat := range stats {
 		// Only process backend entries
-		if statType, ok := stat["type"]; ok && statType == "backend" {
+		if statType(stat) == "backend" {
 			if name, ok := stat["pxname"]; ok && name != "" {
 				backendSet[name] = true
 			}
@@ -41,6 +48,7 @@ func (c *HAProxyClient) ListBackends() ([]string, error) {
 	for backend := range backendSet {
 		backends = append(backends, backend)
 	}
+	sort.Strings(backends)
 
 	slog.Debug("Successfully listed backends", "count", len(backends))
 	return backends, nil
@@ -48,11 +56,20 @@ func (c *HAProxyClient) ListBackends() ([]string, error) {
 
 // GetBackendInfo returns detailed information about a specific backend.
 func (c *HAProxyClient) GetBackendInfo(backendName string) (*BackendInfo, error) {
+	return c.GetBackendInfoWithContext(context.Background(), backendName)
+}
+
+// GetBackendInfoWithContext returns detailed information about a specific backend with context support.
+func (c *HAProxyClient) GetBackendInfoWithContext(ctx context.Context, backendName string) (*BackendInfo, error) {
 	slog.Debug("Getting backend info", "backend", backendName)
 
+	if err := ValidateName("backend", backendName); err != nil {
+		return nil, err
+	}
+
 	// Use show stat to get stats for this backend
 	cmd := fmt.Sprintf("show stat %s", backendName)
-	result, err := c.ExecuteRuntimeCommand(cmd)
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		// Check if this is a structured HAProxy error
 		if haErr, ok := err.(HAProxyError); ok {
@@ -85,11 +102,11 @@ func (c *HAProxyClient) GetBackendInfo(backendName string) (*BackendInfo, error)
 	foundBackend := false
 	for _, stat := range stats {
 		// Get the type and name
-		statType, hasType := stat["type"]
+		kind := statType(stat)
 		name, hasName := stat["pxname"]
 
 		// Find the backend entry
-		if hasType && hasName && statType == "backend" && name == backendName {
+		if hasName && kind == "backend" && name == backendName {
 			foundBackend = true
 
 			// Extract status if available
@@ -108,7 +125,7 @@ func (c *HAProxyClient) GetBackendInfo(backendName string) (*BackendInfo, error)
 			for k, v := range stat {
 				backendInfo.Stats[k] = v
 			}
-		} else if hasType && hasName && statType == "server" && name == backendName {
+		} else if hasName && kind == "server" && name == backendName {
 			// This is a server in the backend we're looking for
 			serverName, hasServerName := stat["svname"]
 			if !hasServerName {
@@ -177,11 +194,20 @@ func (c *HAProxyClient) GetBackendInfo(backendName string) (*BackendInfo, error)
 
 // EnableBackend enables a backend.
 func (c *HAProxyClient) EnableBackend(backendName string) error {
+	return c.EnableBackendWithContext(context.Background(), backendName)
+}
+
+// EnableBackendWithContext enables a backend with context support.
+func (c *HAProxyClient) EnableBackendWithContext(ctx context.Context, backendName string) error {
 	slog.Debug("Enabling backend", "backend", backendName)
 
+	if err := ValidateName("backend", backendName); err != nil {
+		return err
+	}
+
 	// Set the backend state to ready using direct command
 	cmd := fmt.Sprintf("set server %s/default-backend state ready", backendName)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to enable backend", "backend", backendName, "error", err)
 		return fmt.Errorf("failed to enable backend %s: %w", backendName, err)
@@ -193,11 +219,20 @@ func (c *HAProxyClient) EnableBackend(backendName string) error {
 
 // DisableBackend disables a backend.
 func (c *HAProxyClient) DisableBackend(backendName string) error {
+	return c.DisableBackendWithContext(context.Background(), backendName)
+}
+
+// DisableBackendWithContext disables a backend with context support.
+func (c *HAProxyClient) DisableBackendWithContext(ctx context.Context, backendName string) error {
 	slog.Debug("Disabling backend", "backend", backendName)
 
+	if err := ValidateName("backend", backendName); err != nil {
+		return err
+	}
+
 	// Set the backend state to maint using direct command
 	cmd := fmt.Sprintf("set server %s/default-backend state maint", backendName)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to disable backend", "backend", backendName, "error", err)
 		return fmt.Errorf("failed to disable backend %s: %w", backendName, err)
@@ -212,13 +247,27 @@ func (c *HAProxyClient) DisableBackend(backendName string) error {
 // GetBackends returns a list of all HAProxy backends
 // This is an alias for ListBackends for API consistency
 func (c *HAProxyClient) GetBackends() ([]string, error) {
-	return c.ListBackends()
+	return c.GetBackendsWithContext(context.Background())
+}
+
+// GetBackendsWithContext returns a list of all HAProxy backends with context support.
+func (c *HAProxyClient) GetBackendsWithContext(ctx context.Context) ([]string, error) {
+	return c.ListBackendsWithContext(ctx)
 }
 
 // GetBackendDetails retrieves details of a specific backend in a map format
 // for tools.go compatibility
 func (c *HAProxyClient) GetBackendDetails(backend string) (map[string]interface{}, error) {
-	backendInfo, err := c.GetBackendInfo(backend)
+	return c.GetBackendDetailsWithContext(context.Background(), backend)
+}
+
+// GetBackendDetailsWithContext retrieves details of a specific backend in a map format with context support.
+func (c *HAProxyClient) GetBackendDetailsWithContext(ctx context.Context, backend string) (map[string]interface{}, error) {
+	if err := ValidateName("backend", backend); err != nil {
+		return nil, err
+	}
+
+	backendInfo, err := c.GetBackendInfoWithContext(ctx, backend)
 	if err != nil {
 		return nil, err
 	}
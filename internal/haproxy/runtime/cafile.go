@@ -0,0 +1,165 @@
+package haproxy
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ListCAFiles lists the CA files loaded by HAProxy and the one with an
+// uncommitted transaction, if any.
+func (c *HAProxyClient) ListCAFiles() (*SSLFileList, error) {
+	return c.ListCAFilesWithContext(context.Background())
+}
+
+// ListCAFilesWithContext lists the CA files loaded by HAProxy with context support.
+func (c *HAProxyClient) ListCAFilesWithContext(ctx context.Context) (*SSLFileList, error) {
+	slog.Debug("HAProxyClient.ListCAFiles called")
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show ssl ca-file")
+	if err != nil {
+		slog.Error("Failed to list CA files", "error", err)
+		return nil, fmt.Errorf("failed to list CA files: %w", err)
+	}
+
+	files, transaction := parseSSLList(result)
+	list := &SSLFileList{Files: files, Transaction: transaction}
+
+	slog.Debug("Successfully listed CA files", "count", len(list.Files), "transaction", list.Transaction)
+	return list, nil
+}
+
+// ShowCAFile returns the certificates of a CA file. A name prefixed with '*'
+// shows the CA file of the uncommitted transaction instead.
+func (c *HAProxyClient) ShowCAFile(name string) (*CAFileInfo, error) {
+	return c.ShowCAFileWithContext(context.Background(), name)
+}
+
+// ShowCAFileWithContext returns the certificates of a CA file with context support.
+func (c *HAProxyClient) ShowCAFileWithContext(ctx context.Context, name string) (*CAFileInfo, error) {
+	slog.Debug("HAProxyClient.ShowCAFile called", "ca_file", name)
+
+	if err := validatePath("CA file", name); err != nil {
+		return nil, err
+	}
+
+	if name == "" || name == "*" {
+		return nil, fmt.Errorf("CA file name is required")
+	}
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show ssl ca-file "+name)
+	if err != nil {
+		slog.Error("Failed to show CA file", "ca_file", name, "error", err)
+		return nil, fmt.Errorf("failed to show CA file %s: %w", name, err)
+	}
+
+	info, err := parseCAFile(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to show CA file %s: %w", name, err)
+	}
+	return info, nil
+}
+
+// parseCAFile parses 'show ssl ca-file <name>': the Filename and Status of the
+// file, then a "Certificate #<n>:" section per certificate. Each certificate
+// is named "<file>:<n>", which 'show ssl ca-file' accepts to show it alone.
+func parseCAFile(output string) (*CAFileInfo, error) {
+	info := &CAFileInfo{Certificates: make([]SSLCertInfo, 0)}
+	var header []string
+	var sections [][]string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "Certificate #") {
+			sections = append(sections, []string{line})
+		} else if len(sections) > 0 {
+			sections[len(sections)-1] = append(sections[len(sections)-1], line)
+		} else {
+			header = append(header, line)
+		}
+	}
+
+	var file SSLCertInfo
+	parseSSLCertFields(header, &file)
+	if file.Filename == "" {
+		// Unknown CA files are reported with a plain message
+		return nil, fmt.Errorf("%s", strings.TrimSpace(output))
+	}
+	info.Filename, info.Status = file.Filename, file.Status
+
+	for _, section := range sections {
+		index := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(section[0]), "Certificate #"), ":")
+		certificate := SSLCertInfo{}
+		parseSSLCertFields(section[1:], &certificate)
+		certificate.Filename = strings.TrimPrefix(info.Filename, "*") + ":" + index
+		info.Certificates = append(info.Certificates, certificate)
+	}
+	return info, nil
+}
+
+// NewCAFile creates an empty CA file, to be filled with SetCAFile and
+// CommitCAFile before it is referenced by a crt-list entry.
+func (c *HAProxyClient) NewCAFile(name string) error {
+	return c.NewCAFileWithContext(context.Background(), name)
+}
+
+// NewCAFileWithContext creates an empty CA file with context support.
+func (c *HAProxyClient) NewCAFileWithContext(ctx context.Context, name string) error {
+	return c.sslCommand(ctx, "new ssl ca-file "+name, name, "create CA file", "New CA file created")
+}
+
+// SetCAFile stages PEM CA certificates replacing the content of a CA file in a
+// transaction. The change takes effect on CommitCAFile.
+func (c *HAProxyClient) SetCAFile(name, payload string) error {
+	return c.SetCAFileWithContext(context.Background(), name, payload)
+}
+
+// SetCAFileWithContext stages PEM CA certificates for a CA file with context support.
+func (c *HAProxyClient) SetCAFileWithContext(ctx context.Context, name, payload string) error {
+	return c.caFileCommand(ctx, "set", name, payload)
+}
+
+// AddCAFile stages PEM CA certificates appended to the content of a CA file in
+// a transaction, e.g. the next CA of a rotation. The change takes effect on
+// CommitCAFile. It requires HAProxy 2.8 or later.
+func (c *HAProxyClient) AddCAFile(name, payload string) error {
+	return c.AddCAFileWithContext(context.Background(), name, payload)
+}
+
+// AddCAFileWithContext stages PEM CA certificates appended to a CA file with context support.
+func (c *HAProxyClient) AddCAFileWithContext(ctx context.Context, name, payload string) error {
+	return c.caFileCommand(ctx, "add", name, payload)
+}
+
+// caFileCommand sends PEM CA certificates with 'set ssl ca-file' or 'add ssl ca-file'
+func (c *HAProxyClient) caFileCommand(ctx context.Context, verb, name, payload string) error {
+	action := verb + " CA file"
+	payload, err := pemPayload(payload, "CERTIFICATE", func(der []byte) error {
+		_, err := x509.ParseCertificate(der)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to %s %s: %w", action, name, err)
+	}
+	return c.sslCommand(ctx, fmt.Sprintf("%s ssl ca-file %s <<\n%s\n", verb, name, payload), name, action, "Transaction ")
+}
+
+// CommitCAFile applies the transaction of a CA file to the running process.
+func (c *HAProxyClient) CommitCAFile(name string) error {
+	return c.CommitCAFileWithContext(context.Background(), name)
+}
+
+// CommitCAFileWithContext applies the transaction of a CA file with context support.
+func (c *HAProxyClient) CommitCAFileWithContext(ctx context.Context, name string) error {
+	return c.sslCommand(ctx, "commit ssl ca-file "+name, name, "commit CA file", "Success!")
+}
+
+// AbortCAFile discards the transaction of a CA file.
+func (c *HAProxyClient) AbortCAFile(name string) error {
+	return c.AbortCAFileWithContext(context.Background(), name)
+}
+
+// AbortCAFileWithContext discards the transaction of a CA file with context support.
+func (c *HAProxyClient) AbortCAFileWithContext(ctx context.Context, name string) error {
+	return c.sslCommand(ctx, "abort ssl ca-file "+name, name, "abort CA file", "Transaction aborted")
+}
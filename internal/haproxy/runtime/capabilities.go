@@ -0,0 +1,195 @@
+package haproxy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// commandVersions lists the commands introduced after HAProxy 2.0 with the
+// release that introduced them. Commands that are not listed are assumed to
+// be supported.
+var commandVersions = []struct {
+	command      string
+	major, minor int
+}{
+	{"set ssl cert", 2, 1},
+	{"commit ssl cert", 2, 1},
+	{"abort ssl cert", 2, 1},
+	{"show ssl cert", 2, 2},
+	{"new ssl cert", 2, 2},
+	{"del ssl cert", 2, 2},
+	{"show ssl crt-list", 2, 2},
+	{"add ssl crt-list", 2, 2},
+	{"del ssl crt-list", 2, 2},
+	{"add server", 2, 4},
+	{"del server", 2, 4},
+	{"show ssl ca-file", 2, 5},
+	{"new ssl ca-file", 2, 5},
+	{"set ssl ca-file", 2, 5},
+	{"commit ssl ca-file", 2, 5},
+	{"abort ssl ca-file", 2, 5},
+	{"del ssl ca-file", 2, 5},
+	{"show ssl crl-file", 2, 5},
+	{"new ssl crl-file", 2, 5},
+	{"set ssl crl-file", 2, 5},
+	{"commit ssl crl-file", 2, 5},
+	{"abort ssl crl-file", 2, 5},
+	{"del ssl crl-file", 2, 5},
+	{"show ssl ocsp-response", 2, 7},
+	{"add ssl ca-file", 2, 8},
+	{"update ssl ocsp-response", 2, 8},
+	{"dump stats-file", 3, 0},
+	{"debug counters", 3, 1},
+}
+
+// versionPattern matches the major and minor version at the start of 'show info' Version
+var versionPattern = regexp.MustCompile(`^(\d+)\.(\d+)`)
+
+// commandWordPattern matches a word of a command name in 'help' output, as
+// opposed to its arguments such as <bk>/<srv> or [all]
+var commandWordPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// ProbeCapabilities reads the version and the command list of the connected
+// HAProxy. Afterwards, commands it does not support fail with an
+// UnsupportedCommandError instead of being sent.
+func (c *HAProxyClient) ProbeCapabilities() (*Capabilities, error) {
+	return c.ProbeCapabilitiesWithContext(context.Background())
+}
+
+// ProbeCapabilitiesWithContext reads the version and the command list of the connected HAProxy with context support.
+func (c *HAProxyClient) ProbeCapabilitiesWithContext(ctx context.Context) (*Capabilities, error) {
+	slog.Debug("HAProxyClient.ProbeCapabilities called")
+
+	info, err := c.GetProcessInfoWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe capabilities: %w", err)
+	}
+
+	help, err := c.ExecuteRuntimeCommandWithContext(ctx, "help")
+	if err != nil {
+		// The version alone still tells most commands apart
+		slog.Warn("Failed to list Runtime API commands, using the version only", "error", err)
+		help = ""
+	}
+
+	capabilities := NewCapabilities(info["Version"], help)
+	c.capabilities.Store(capabilities)
+
+	slog.Debug("Successfully probed capabilities", "version", capabilities.Version, "commands", len(capabilities.Commands))
+	return capabilities, nil
+}
+
+// NewCapabilities builds the capabilities of an HAProxy from the Version of
+// 'show info' and the output of 'help'. Either may be empty.
+func NewCapabilities(version, help string) *Capabilities {
+	capabilities := &Capabilities{Version: strings.TrimSpace(version), Commands: parseHelpCommands(help)}
+	if m := versionPattern.FindStringSubmatch(capabilities.Version); m != nil {
+		capabilities.Major, _ = strconv.Atoi(m[1])
+		capabilities.Minor, _ = strconv.Atoi(m[2])
+	}
+	return capabilities
+}
+
+// parseHelpCommands returns the command names of 'help' output, whose command
+// lines are indented: "  add server <bk>/<srv> [args]*  : create a new server"
+func parseHelpCommands(output string) []string {
+	seen := make(map[string]bool)
+	var commands []string
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, " ") {
+			continue
+		}
+		usage, _, ok := strings.Cut(line, " : ")
+		if !ok {
+			usage, _, _ = strings.Cut(line, ":")
+		}
+
+		var words []string
+		for _, word := range strings.Fields(usage) {
+			if !commandWordPattern.MatchString(word) {
+				break
+			}
+			words = append(words, word)
+		}
+		if command := strings.Join(words, " "); command != "" && !seen[command] {
+			seen[command] = true
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// Supports reports whether the HAProxy supports command. Commands listed by
+// 'help' are supported; without a command list, the version decides.
+func (c *Capabilities) Supports(command string) bool {
+	return c.Check(command) == nil
+}
+
+// Check returns an UnsupportedCommandError if the HAProxy does not support
+// command. Each ';'-separated command of its first line is checked.
+func (c *Capabilities) Check(command string) error {
+	line, _, _ := strings.Cut(command, "\n")
+	for _, part := range strings.Split(line, ";") {
+		words := strings.Fields(strings.ToLower(part))
+		if len(words) > 0 && strings.HasPrefix(words[0], "@") {
+			words = words[1:] // Master CLI routing prefix
+		}
+		if name, ok := c.unsupported(words); ok {
+			return &UnsupportedCommandError{Command: name, Release: c.release()}
+		}
+	}
+	return nil
+}
+
+// unsupported returns the name of the versioned command words start with, if
+// the HAProxy does not support it
+func (c *Capabilities) unsupported(words []string) (string, bool) {
+	for _, required := range commandVersions {
+		name := strings.Fields(required.command)
+		if !hasWords(words, name) {
+			continue
+		}
+		if len(c.Commands) > 0 {
+			for _, listed := range c.Commands {
+				if hasWords(strings.Fields(listed), name) {
+					return "", false
+				}
+			}
+			return required.command, true
+		}
+		if c.Major == 0 {
+			return "", false // Unknown version: let HAProxy decide
+		}
+		older := c.Major < required.major || (c.Major == required.major && c.Minor < required.minor)
+		return required.command, older
+	}
+	return "", false
+}
+
+// release returns the major and minor version for messages
+func (c *Capabilities) release() string {
+	if c.Major == 0 {
+		if c.Version != "" {
+			return c.Version
+		}
+		return "(unknown version)"
+	}
+	return fmt.Sprintf("%d.%d", c.Major, c.Minor)
+}
+
+// hasWords reports whether words start with prefix
+func hasWords(words, prefix []string) bool {
+	if len(prefix) > len(words) {
+		return false
+	}
+	for i, word := range prefix {
+		if words[i] != word {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,196 @@
+package haproxy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxErrorExcerpt is the most bytes of a captured message kept in an ErrorCapture
+const maxErrorExcerpt = 1024
+
+var (
+	// errorCaptureHeader matches the first line of a capture:
+	// "[<date>] frontend <name> (#<id>): invalid request"
+	errorCaptureHeader = regexp.MustCompile(`^\[([^\]]+)\] (frontend|backend) (\S+) \(#(-?\d+)\): invalid (request|response)`)
+
+	// errorCapturePeer matches the other proxy and the server of a capture:
+	// "backend <name> (#<id>), server <name> (#<id>)"
+	errorCapturePeer = regexp.MustCompile(`(frontend|backend) (\S+) \(#-?\d+\), server (\S+) \(#-?\d+\)`)
+
+	// errorCaptureDump matches a line of the message dump: "  <offset><'+' when continued> <escaped text>"
+	errorCaptureDump = regexp.MustCompile(`^  (\d{5})[ +] (.*)$`)
+
+	errorCaptureEvent    = regexp.MustCompile(`event #(\d+)`)
+	errorCaptureSource   = regexp.MustCompile(`src ([^ ,]+)`)
+	errorCaptureLength   = regexp.MustCompile(`\blen (\d+)`)
+	errorCapturePosition = regexp.MustCompile(`error at position (\d+)`)
+)
+
+// ShowErrorCaptures returns the last invalid request or response captured by
+// each proxy, parsed from 'show errors'. proxy restricts the captures to those
+// of a proxy, by name or '#'-prefixed ID; direction to "request" or "response".
+func (c *HAProxyClient) ShowErrorCaptures(proxy, direction string) ([]ErrorCapture, error) {
+	return c.ShowErrorCapturesWithContext(context.Background(), proxy, direction)
+}
+
+// ShowErrorCapturesWithContext returns the parsed protocol error captures with context support.
+func (c *HAProxyClient) ShowErrorCapturesWithContext(ctx context.Context, proxy, direction string) ([]ErrorCapture, error) {
+	slog.Debug("HAProxyClient.ShowErrorCaptures called", "proxy", proxy, "direction", direction)
+
+	if err := ValidateName("proxy", proxy); err != nil {
+		return nil, err
+	}
+
+	if direction != "" && direction != "request" && direction != "response" {
+		return nil, fmt.Errorf("invalid direction %q: expected request or response", direction)
+	}
+
+	// 'show errors' takes the direction as second argument, after a proxy or -1 for all
+	cmd := "show errors"
+	if proxy != "" || direction != "" {
+		if proxy == "" {
+			proxy = "-1"
+		}
+		cmd = strings.TrimSpace(fmt.Sprintf("%s %s %s", cmd, proxy, direction))
+	}
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
+	if err != nil {
+		slog.Error("Failed to execute 'show errors' command", "error", err, "proxy", proxy)
+		return nil, fmt.Errorf("failed to show errors: %w", err)
+	}
+
+	captures := parseErrorCaptures(result)
+	slog.Debug("Successfully parsed error captures", "count", len(captures))
+	return captures, nil
+}
+
+// parseErrorCaptures parses the output of 'show errors'. Each capture starts
+// with a "[<date>] <proxy type> <proxy> (#<id>): invalid <direction>" line,
+// followed by indented details and a dump of the message with escaped bytes.
+func parseErrorCaptures(output string) []ErrorCapture {
+	captures := make([]ErrorCapture, 0)
+	var message []byte
+	flush := func() {
+		if len(captures) > 0 {
+			capture := &captures[len(captures)-1]
+			capture.ExcerptOffset, capture.Excerpt = errorExcerpt(message, capture.ErrorPosition)
+		}
+		message = nil
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := errorCaptureHeader.FindStringSubmatch(line); m != nil {
+			flush()
+			capture := ErrorCapture{Timestamp: m[1], Proxy: m[3], Direction: m[5]}
+			capture.ProxyID, _ = strconv.Atoi(m[4])
+			if m[2] == "frontend" {
+				capture.Frontend = m[3]
+			} else {
+				capture.Backend = m[3]
+			}
+			captures = append(captures, capture)
+			continue
+		}
+		if len(captures) == 0 {
+			continue // "Total events captured" header
+		}
+		capture := &captures[len(captures)-1]
+
+		if m := errorCaptureDump.FindStringSubmatch(line); m != nil {
+			message = append(message, unescapeCapture(m[2])...)
+			continue
+		}
+		if m := errorCapturePeer.FindStringSubmatch(line); m != nil {
+			if m[1] == "frontend" {
+				capture.Frontend = captureName(m[2])
+			} else {
+				capture.Backend = captureName(m[2])
+			}
+			capture.Server = captureName(m[3])
+		}
+		if m := errorCaptureEvent.FindStringSubmatch(line); m != nil {
+			capture.Event, _ = strconv.Atoi(m[1])
+		}
+		if m := errorCaptureSource.FindStringSubmatch(line); m != nil {
+			capture.Source = m[1]
+		}
+		if m := errorCaptureLength.FindStringSubmatch(line); m != nil {
+			capture.Length, _ = strconv.Atoi(m[1])
+		}
+		if m := errorCapturePosition.FindStringSubmatch(line); m != nil {
+			capture.ErrorPosition, _ = strconv.Atoi(m[1])
+		}
+	}
+	flush()
+	return captures
+}
+
+// captureName returns a proxy or server name of a capture, or "" for <NONE>
+func captureName(name string) string {
+	if name == "<NONE>" {
+		return ""
+	}
+	return name
+}
+
+// unescapeCapture decodes a line of a 'show errors' dump, where \t, \n, \r,
+// \e, \\ and \xHH stand for the original bytes
+func unescapeCapture(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' || i+1 == len(text) {
+			out = append(out, text[i])
+			continue
+		}
+		i++
+		switch text[i] {
+		case 't':
+			out = append(out, '\t')
+		case 'n':
+			out = append(out, '\n')
+		case 'r':
+			out = append(out, '\r')
+		case 'e':
+			out = append(out, 0x1b)
+		case 'x':
+			if i+2 < len(text) {
+				if b, err := strconv.ParseUint(text[i+1:i+3], 16, 8); err == nil {
+					out = append(out, byte(b))
+					i += 2
+					continue
+				}
+			}
+			out = append(out, '\\', 'x')
+		default:
+			out = append(out, text[i])
+		}
+	}
+	return out
+}
+
+// errorExcerpt returns at most maxErrorExcerpt bytes of message around the
+// error position, and the offset they start at. Bytes other than printable
+// ASCII, tabs and line breaks are shown as \xHH.
+func errorExcerpt(message []byte, position int) (int, string) {
+	start := 0
+	if len(message) > maxErrorExcerpt && position > maxErrorExcerpt/2 {
+		start = min(position-maxErrorExcerpt/2, len(message)-maxErrorExcerpt)
+	}
+	end := min(start+maxErrorExcerpt, len(message))
+
+	var b strings.Builder
+	for _, c := range message[start:end] {
+		if c == '\t' || c == '\n' || c == '\r' || (c >= 0x20 && c < 0x7f) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "\\x%02x", c)
+		}
+	}
+	return start, b.String()
+}
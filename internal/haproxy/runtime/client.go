@@ -4,239 +4,187 @@ package haproxy
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net"
 	"net/url"
-	"os/exec"
 	"strings"
 	"time"
 )
 
+// defaultCommandTimeout bounds a command when the caller's context has no deadline
+const defaultCommandTimeout = 5 * time.Second
+
 // NewHAProxyClient creates a new HAProxy client
 func NewHAProxyClient(runtimeAPIURL string) (*HAProxyClient, error) {
+	return NewHAProxyClientWithOptions(runtimeAPIURL, ClientOptions{})
+}
+
+// NewHAProxyClientWithOptions creates a new HAProxy client with transport, retry and timeout settings.
+// Supported URLs are unix:///path, tcp://host:port, tls://host:port and
+// ssh://user@host[:port]/path/to/admin.sock.
+func NewHAProxyClientWithOptions(runtimeAPIURL string, opts ClientOptions) (*HAProxyClient, error) {
 	// Parse URL to determine connection type
 	u, err := url.Parse(runtimeAPIURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse runtime API URL: %w", err)
 	}
 
-	// Validate URL scheme
-	switch u.Scheme {
-	case "unix":
-		slog.Debug("Initializing client for Unix socket connection", "path", u.Path)
-	case "tcp":
-		slog.Debug("Initializing client for TCP connection", "host", u.Host)
-	default:
-		return nil, fmt.Errorf("unsupported URL scheme: %s", u.Scheme)
+	transport := opts.Transport
+	if transport == nil {
+		transport, err = newTransport(u, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	slog.Debug("Initializing client", "transport", transport.Name(), "address", transport.Address())
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultCommandTimeout
 	}
 
 	client := &HAProxyClient{
 		RuntimeAPIURL: runtimeAPIURL,
 		ParsedURL:     u,
 		Mode:          ClientModeDirect,
+		transport:     transport,
+		retry:         opts.Retry.withDefaults(),
+		timeout:       timeout,
 	}
 
 	// Test direct connection by executing a simple command
 	_, err = client.ExecuteRuntimeCommand("show info")
 	if err != nil {
+		_ = transport.Close()
 		return nil, fmt.Errorf("failed to connect to HAProxy Runtime API: %w", err)
 	}
 
-	slog.Info("Successfully connected to HAProxy Runtime API", "url", runtimeAPIURL, "mode", client.Mode)
+	slog.Info("Successfully connected to HAProxy Runtime API", "url", runtimeAPIURL, "transport", transport.Name())
 
 	return client, nil
 }
 
-// executeSocketCommand is a shared helper function that handles command execution via sockets
-// with support for context cancellation and timeouts
-func (c *HAProxyClient) executeSocketCommand(ctx context.Context, network string, address string, command string) (string, error) {
-	slog.Debug("Executing socket command", "network", network, "address", address, "command", command)
+// dial opens a connection using the client's transport, retrying according to the retry policy.
+// Failures are reported as *ConnectionError; context cancellation is returned as is.
+func (c *HAProxyClient) dial(ctx context.Context) (net.Conn, error) {
+	var lastErr error
+	attempts := 0
+	for attempt := 1; attempt <= c.retry.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			wait := c.retry.backoff(attempt - 1)
+			slog.Debug("Retrying runtime API connection", "transport", c.transport.Name(),
+				"attempt", attempt, "backoff", wait, "error", lastErr)
+			if err := sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+
+		attempts = attempt
+		conn, err := c.transport.Dial(ctx)
+		if err == nil {
+			return conn, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		lastErr = err
+		if isPermanent(err) {
+			break
+		}
+	}
+
+	return nil, &ConnectionError{
+		Transport: c.transport.Name(),
+		Address:   c.transport.Address(),
+		Attempts:  attempts,
+		Err:       lastErr,
+	}
+}
+
+// executeSocketCommand sends a single command over a fresh connection and reads the
+// response until HAProxy closes the connection, honouring ctx throughout.
+func (c *HAProxyClient) executeSocketCommand(ctx context.Context, command string) (string, error) {
+	slog.Debug("Executing socket command", "transport", c.transport.Name(), "address", c.transport.Address(), "command", commandLine(command))
+
+	// Apply the default timeout when the caller did not set a deadline
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
 
 	// Check if context is already canceled
 	if err := ctx.Err(); err != nil {
 		return "", err
 	}
 
-	// Try socket connection with timeout from context
-	var d net.Dialer
-	connCh := make(chan net.Conn, 1)
-	errCh := make(chan error, 1)
-
-	go func() {
-		conn, err := d.DialContext(ctx, network, address)
-		if err != nil {
-			errCh <- err
-			return
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := conn.Close(); closeErr != nil {
+			slog.Debug("Error closing runtime connection", "transport", c.transport.Name(), "error", closeErr)
 		}
-		connCh <- conn
 	}()
 
-	// Wait for connection or context cancellation
-	var conn net.Conn
-	select {
-	case <-ctx.Done():
-		return "", ctx.Err()
-	case err := <-errCh:
-		// Connection failed, try using socat as fallback
-		slog.Debug("Direct socket connection failed, trying socat instead",
-			"network", network, "error", err)
-
-		if network == "tcp" {
-			return c.executeSocatTCPCommand(command)
-		} else {
-			return c.executeSocatUnixCommand(command)
-		}
-	case conn = <-connCh:
-		defer func() {
-			if closeErr := conn.Close(); closeErr != nil {
-				slog.Error("Error closing socket connection",
-					"network", network, "error", closeErr)
-			}
-		}()
-	}
+	// Closing the connection unblocks any pending read or write on cancellation,
+	// including transports without deadline support such as SSH channels
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
 
-	// Use context deadline if available
-	deadline, ok := ctx.Deadline()
-	if ok {
-		err := conn.SetDeadline(deadline)
-		if err != nil {
-			slog.Error("Failed to set deadline on socket connection",
-				"network", network, "error", err)
-			return "", fmt.Errorf("failed to set deadline: %w", err)
-		}
-	} else {
-		err := conn.SetDeadline(time.Now().Add(5 * time.Second))
-		if err != nil {
-			slog.Error("Failed to set deadline on socket connection",
-				"network", network, "error", err)
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
 			return "", fmt.Errorf("failed to set deadline: %w", err)
 		}
 	}
 
 	// Send command
-	slog.Debug("Sending command over socket", "command", command)
-	_, err := conn.Write([]byte(command + "\n"))
-	if err != nil {
+	slog.Debug("Sending command over socket", "command", commandLine(command))
+	if _, err := conn.Write([]byte(command + "\n")); err != nil {
+		if ctxErr := contextError(ctx, err); ctxErr != nil {
+			return "", ctxErr
+		}
 		slog.Error("Failed to send command over socket", "error", err)
 		return "", fmt.Errorf("failed to send command: %w", err)
 	}
 
-	// Read response using dynamic buffer
+	// HAProxy closes the connection after answering in non-interactive mode
 	var buffer bytes.Buffer
-	buf := make([]byte, 4096)
-	for {
-		// Check if context is cancelled
-		select {
-		case <-ctx.Done():
-			return "", ctx.Err()
-		default:
-			// Continue with read
-		}
-
-		// Set a short read deadline to allow for context cancellation
-		if err := conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond)); err != nil {
-			return "", fmt.Errorf("failed to set read deadline: %w", err)
-		}
-
-		n, err := conn.Read(buf)
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
-				// If we timeout on read, check context and try again
-				if ctx.Err() != nil {
-					return "", ctx.Err()
-				}
-				continue
-			}
-			slog.Error("Failed to read response from socket", "error", err)
-			return "", fmt.Errorf("failed to read response: %w", err)
-		}
-		if n == 0 {
-			break
-		}
-		buffer.Write(buf[:n])
-
-		// Check if we've read all available data
-		// This is a heuristic - we assume done if we read less than buffer size
-		if n < len(buf) {
-			break
+	if _, err := io.Copy(&buffer, conn); err != nil {
+		if ctxErr := contextError(ctx, err); ctxErr != nil {
+			return "", ctxErr
 		}
+		slog.Error("Failed to read response from socket", "error", err)
+		return "", fmt.Errorf("failed to read response: %w", err)
 	}
 
 	response := buffer.String()
-	slog.Debug("Received response from socket", "network", network, "response_length", len(response))
+	slog.Debug("Received response from socket", "transport", c.transport.Name(), "response_length", len(response))
 	return response, nil
 }
 
-// executeDirectUnixCommandWithContext executes a command directly via Unix socket with context
-func (c *HAProxyClient) executeDirectUnixCommandWithContext(ctx context.Context, command string) (string, error) {
-	slog.Debug("Executing direct Unix command with context", "socket", c.ParsedURL.Path, "command", command)
-	return c.executeSocketCommand(ctx, "unix", c.ParsedURL.Path, command)
-}
-
-// executeDirectTCPCommandWithContext executes a command directly via TCP with context
-func (c *HAProxyClient) executeDirectTCPCommandWithContext(ctx context.Context, command string) (string, error) {
-	slog.Debug("Executing direct TCP command with context", "host", c.ParsedURL.Host, "command", command)
-	return c.executeSocketCommand(ctx, "tcp", c.ParsedURL.Host, command)
+// commandLine returns the first line of command for logs and errors, leaving
+// out multi-line payloads such as certificates and private keys
+func commandLine(command string) string {
+	line, _, _ := strings.Cut(command, "\n")
+	return line
 }
 
-// executeSocatCommand executes a command via socat over the given target
-func (c *HAProxyClient) executeSocatCommand(target, command string) (string, error) {
-	slog.Debug("Executing socat command", "target", target, "command", command)
-
-	// Check if socat is available
-	socatPath, err := exec.LookPath("socat")
-	if err != nil {
-		slog.Error("Socat not found in system PATH", "error", err)
-		return "", fmt.Errorf("socat not found: %w", err)
+// contextError returns the context error when an I/O failure was caused by the
+// context ending, including deadline timeouts that fire just before ctx.Err is set
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
 	}
-	slog.Debug("Found socat binary", "path", socatPath)
-
-	// Execute command with socat
-	cmd := exec.Command(socatPath, target, "stdio")
-	cmd.Stdin = bytes.NewBufferString(command + "\n")
-
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	var errOut bytes.Buffer
-	cmd.Stderr = &errOut
-
-	slog.Debug("Running socat command", "full_command", fmt.Sprintf("%s %s stdio", socatPath, target))
-	err = cmd.Run()
-	if err != nil {
-		stderr := errOut.String()
-		slog.Error("Socat command failed", "error", err, "stderr", stderr)
-		return "", fmt.Errorf("socat command failed: %w, stderr: %s", err, stderr)
-	}
-
-	response := out.String()
-	slog.Debug("Socat command successful", "response_length", len(response))
-	return response, nil
-}
-
-// executeSocatTCPCommand executes a command via socat over TCP
-func (c *HAProxyClient) executeSocatTCPCommand(command string) (string, error) {
-	target := fmt.Sprintf("tcp-connect:%s", c.ParsedURL.Host)
-	return c.executeSocatCommand(target, command)
-}
-
-// executeSocatUnixCommand executes a command via socat over Unix socket
-func (c *HAProxyClient) executeSocatUnixCommand(command string) (string, error) {
-	target := fmt.Sprintf("unix-connect:%s", c.ParsedURL.Path)
-	return c.executeSocatCommand(target, command)
-}
-
-// executeDirectCommandWithContext executes a command directly via TCP or Unix socket with context
-func (c *HAProxyClient) executeDirectCommandWithContext(ctx context.Context, command string) (string, error) {
-	if c.ParsedURL.Scheme == "tcp" {
-		return c.executeDirectTCPCommandWithContext(ctx, command)
-	} else {
-		return c.executeDirectUnixCommandWithContext(ctx, command)
+	var netErr net.Error
+	if _, ok := ctx.Deadline(); ok && errors.As(err, &netErr) && netErr.Timeout() {
+		return context.DeadlineExceeded
 	}
+	return nil
 }
 
 // executeWithErrorHandling is a helper method that executes a command with context
@@ -262,12 +210,19 @@ func (c *HAProxyClient) ExecuteRuntimeCommand(command string) (string, error) {
 
 // ExecuteRuntimeCommandWithContext executes a command on HAProxy's Runtime API with context and processes the response.
 func (c *HAProxyClient) ExecuteRuntimeCommandWithContext(ctx context.Context, command string) (string, error) {
-	slog.Debug("Executing runtime command with context", "command", command)
+	slog.Debug("Executing runtime command with context", "command", commandLine(command))
 
-	// Only use direct connection
-	result, err := c.executeDirectCommandWithContext(ctx, command)
+	// Once probed, commands the connected HAProxy lacks are not sent
+	if capabilities := c.capabilities.Load(); capabilities != nil {
+		if err := capabilities.Check(command); err != nil {
+			slog.Warn("Unsupported runtime command", "command", commandLine(command), "error", err)
+			return "", err
+		}
+	}
+
+	result, err := c.executeSocketCommand(ctx, command)
 	if err != nil {
-		slog.Error("Failed to execute runtime command", "command", command, "error", err)
+		slog.Error("Failed to execute runtime command", "command", commandLine(command), "error", err)
 		return "", fmt.Errorf("failed to execute runtime command: %w", err)
 	}
 
@@ -277,11 +232,11 @@ func (c *HAProxyClient) ExecuteRuntimeCommandWithContext(ctx context.Context, co
 			code := int(result[1] - '0')
 			message := strings.TrimSpace(result[4:])
 			slog.Debug("HAProxy returned error code", "code", code, "message", message)
-			return "", NewHAProxyError(code, message, command)
+			return "", NewHAProxyError(code, message, commandLine(command))
 		}
 	}
 
-	slog.Debug("Successfully executed runtime command", "command", command)
+	slog.Debug("Successfully executed runtime command", "command", commandLine(command))
 	return result, nil
 }
 
@@ -329,7 +284,7 @@ func (c *HAProxyClient) GetProcessInfoWithContext(ctx context.Context) (map[stri
 // Close closes the HAProxy client connection.
 func (c *HAProxyClient) Close() error {
 	slog.Debug("Closing HAProxy client")
-	return nil
+	return c.transport.Close()
 }
 
 // GetHaproxyAPIEndpoint returns the URL for the HAProxy API from socket path.
@@ -354,33 +309,3 @@ func GetHaproxyAPIEndpoint(socketPath string) (string, error) {
 
 	return apiURL, nil
 }
-
-// executeDirectCommand executes a command directly via TCP or Unix socket
-// Deprecated: Use executeDirectCommandWithContext instead
-// Kept for backward compatibility
-// nolint:unused
-func (c *HAProxyClient) executeDirectCommand(command string) (string, error) {
-	return c.executeDirectCommandWithContext(context.Background(), command)
-}
-
-// executeDirectTCPCommand executes a command directly via TCP
-// Deprecated: Use executeDirectTCPCommandWithContext instead
-// Kept for backward compatibility
-// nolint:unused
-func (c *HAProxyClient) executeDirectTCPCommand(command string) (string, error) {
-	// Use default background context
-	ctx := context.Background()
-	return c.executeDirectTCPCommandWithContext(ctx, command)
-}
-
-// executeDirectUnixCommand executes a command directly via Unix socket
-// Deprecated: Use executeDirectUnixCommandWithContext instead
-// Kept for backward compatibility
-// nolint:unused
-func (c *HAProxyClient) executeDirectUnixCommand(command string) (string, error) {
-	slog.Debug("Executing direct Unix command", "socket", c.ParsedURL.Path, "command", command)
-
-	// Use default background context
-	ctx := context.Background()
-	return c.executeDirectUnixCommandWithContext(ctx, command)
-}
@@ -0,0 +1,159 @@
+package haproxy
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ListCRLFiles lists the CRL files loaded by HAProxy and the one with an
+// uncommitted transaction, if any.
+func (c *HAProxyClient) ListCRLFiles() (*SSLFileList, error) {
+	return c.ListCRLFilesWithContext(context.Background())
+}
+
+// ListCRLFilesWithContext lists the CRL files loaded by HAProxy with context support.
+func (c *HAProxyClient) ListCRLFilesWithContext(ctx context.Context) (*SSLFileList, error) {
+	slog.Debug("HAProxyClient.ListCRLFiles called")
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show ssl crl-file")
+	if err != nil {
+		slog.Error("Failed to list CRL files", "error", err)
+		return nil, fmt.Errorf("failed to list CRL files: %w", err)
+	}
+
+	files, transaction := parseSSLList(result)
+	list := &SSLFileList{Files: files, Transaction: transaction}
+
+	slog.Debug("Successfully listed CRL files", "count", len(list.Files), "transaction", list.Transaction)
+	return list, nil
+}
+
+// ShowCRLFile returns the revocation lists of a CRL file and the serials they
+// revoke. A name prefixed with '*' shows the CRL file of the uncommitted
+// transaction instead.
+func (c *HAProxyClient) ShowCRLFile(name string) (*CRLFileInfo, error) {
+	return c.ShowCRLFileWithContext(context.Background(), name)
+}
+
+// ShowCRLFileWithContext returns the revocation lists of a CRL file with context support.
+func (c *HAProxyClient) ShowCRLFileWithContext(ctx context.Context, name string) (*CRLFileInfo, error) {
+	slog.Debug("HAProxyClient.ShowCRLFile called", "crl_file", name)
+
+	if err := validatePath("CRL file", name); err != nil {
+		return nil, err
+	}
+
+	if name == "" || name == "*" {
+		return nil, fmt.Errorf("CRL file name is required")
+	}
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show ssl crl-file "+name)
+	if err != nil {
+		slog.Error("Failed to show CRL file", "crl_file", name, "error", err)
+		return nil, fmt.Errorf("failed to show CRL file %s: %w", name, err)
+	}
+
+	info, err := parseCRLFile(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to show CRL file %s: %w", name, err)
+	}
+	return info, nil
+}
+
+// parseCRLFile parses 'show ssl crl-file <name>': the Filename and Status of
+// the file, then a "Certificate Revocation List #<n>:" section per CRL, whose
+// "Revoked Certificates:" block pairs each "Serial Number:" with the
+// "Revocation Date:" that follows it.
+func parseCRLFile(output string) (*CRLFileInfo, error) {
+	info := &CRLFileInfo{CRLs: make([]CRLInfo, 0)}
+	var crl *CRLInfo
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Certificate Revocation List #") {
+			info.CRLs = append(info.CRLs, CRLInfo{Revoked: make([]RevokedCert, 0)})
+			crl = &info.CRLs[len(info.CRLs)-1]
+			continue
+		}
+		if crl == nil {
+			key, value, _ := strings.Cut(line, ":")
+			switch key {
+			case "Filename":
+				info.Filename = strings.TrimSpace(value)
+			case "Status":
+				info.Status = strings.TrimSpace(value)
+			}
+			continue
+		}
+
+		if version, ok := strings.CutPrefix(line, "Version "); ok {
+			crl.Version = strings.TrimSpace(version)
+			continue
+		}
+		key, value, _ := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Signature Algorithm":
+			crl.SignatureAlgorithm = value
+		case "Issuer":
+			crl.Issuer = value
+		case "Last Update":
+			crl.LastUpdate = value
+		case "Next Update":
+			crl.NextUpdate = value
+		case "Serial Number":
+			crl.Revoked = append(crl.Revoked, RevokedCert{Serial: value})
+		case "Revocation Date":
+			if n := len(crl.Revoked); n > 0 {
+				crl.Revoked[n-1].RevocationDate = value
+			}
+		}
+	}
+
+	if info.Filename == "" {
+		// Unknown CRL files are reported with a plain message
+		return nil, fmt.Errorf("%s", strings.TrimSpace(output))
+	}
+	return info, nil
+}
+
+// SetCRLFile stages PEM CRLs replacing the content of a CRL file in a
+// transaction, e.g. to push new revocations. The change takes effect on
+// CommitCRLFile.
+func (c *HAProxyClient) SetCRLFile(name, payload string) error {
+	return c.SetCRLFileWithContext(context.Background(), name, payload)
+}
+
+// SetCRLFileWithContext stages PEM CRLs for a CRL file with context support.
+func (c *HAProxyClient) SetCRLFileWithContext(ctx context.Context, name, payload string) error {
+	payload, err := pemPayload(payload, "X509 CRL", func(der []byte) error {
+		_, err := x509.ParseRevocationList(der)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set CRL file %s: %w", name, err)
+	}
+	return c.sslCommand(ctx, fmt.Sprintf("set ssl crl-file %s <<\n%s\n", name, payload), name, "set CRL file", "Transaction ")
+}
+
+// CommitCRLFile applies the transaction of a CRL file to the running process.
+func (c *HAProxyClient) CommitCRLFile(name string) error {
+	return c.CommitCRLFileWithContext(context.Background(), name)
+}
+
+// CommitCRLFileWithContext applies the transaction of a CRL file with context support.
+func (c *HAProxyClient) CommitCRLFileWithContext(ctx context.Context, name string) error {
+	return c.sslCommand(ctx, "commit ssl crl-file "+name, name, "commit CRL file", "Success!")
+}
+
+// AbortCRLFile discards the transaction of a CRL file.
+func (c *HAProxyClient) AbortCRLFile(name string) error {
+	return c.AbortCRLFileWithContext(context.Background(), name)
+}
+
+// AbortCRLFileWithContext discards the transaction of a CRL file with context support.
+func (c *HAProxyClient) AbortCRLFileWithContext(ctx context.Context, name string) error {
+	return c.sslCommand(ctx, "abort ssl crl-file "+name, name, "abort CRL file", "Transaction aborted")
+}
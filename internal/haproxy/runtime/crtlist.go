@@ -0,0 +1,151 @@
+package haproxy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// ListCrtLists lists the crt-list files loaded by HAProxy.
+func (c *HAProxyClient) ListCrtLists() ([]string, error) {
+	return c.ListCrtListsWithContext(context.Background())
+}
+
+// ListCrtListsWithContext lists the crt-list files loaded by HAProxy with context support.
+func (c *HAProxyClient) ListCrtListsWithContext(ctx context.Context) ([]string, error) {
+	slog.Debug("HAProxyClient.ListCrtLists called")
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show ssl crt-list")
+	if err != nil {
+		slog.Error("Failed to list crt-lists", "error", err)
+		return nil, fmt.Errorf("failed to list crt-lists: %w", err)
+	}
+
+	lists := make([]string, 0)
+	for _, line := range strings.Split(strings.TrimSpace(result), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			lists = append(lists, line)
+		}
+	}
+
+	slog.Debug("Successfully listed crt-lists", "count", len(lists))
+	return lists, nil
+}
+
+// ShowCrtList returns the entries of a crt-list, with their line numbers.
+func (c *HAProxyClient) ShowCrtList(crtList string) ([]CrtListEntry, error) {
+	return c.ShowCrtListWithContext(context.Background(), crtList)
+}
+
+// ShowCrtListWithContext returns the entries of a crt-list with context support.
+func (c *HAProxyClient) ShowCrtListWithContext(ctx context.Context, crtList string) ([]CrtListEntry, error) {
+	slog.Debug("HAProxyClient.ShowCrtList called", "crt_list", crtList)
+
+	if err := validatePath("crt-list", crtList); err != nil {
+		return nil, err
+	}
+
+	if crtList == "" {
+		return nil, fmt.Errorf("crt-list name is required")
+	}
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show ssl crt-list -n "+crtList)
+	if err != nil {
+		slog.Error("Failed to show crt-list", "crt_list", crtList, "error", err)
+		return nil, fmt.Errorf("failed to show crt-list %s: %w", crtList, err)
+	}
+
+	// The first line names the crt-list: "# <crt-list>"
+	if !strings.HasPrefix(strings.TrimSpace(result), "#") {
+		return nil, fmt.Errorf("failed to show crt-list %s: %s", crtList, strings.TrimSpace(result))
+	}
+
+	entries := make([]CrtListEntry, 0)
+	for _, line := range strings.Split(strings.TrimSpace(result), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, parseCrtListEntry(line))
+	}
+
+	slog.Debug("Successfully retrieved crt-list", "crt_list", crtList, "entries", len(entries))
+	return entries, nil
+}
+
+// parseCrtListEntry parses a 'show ssl crt-list -n' line:
+// "<certificate>:<line> [<ssl options>] <sni filter>..."
+func parseCrtListEntry(line string) CrtListEntry {
+	var entry CrtListEntry
+	certificate, rest, _ := strings.Cut(line, " ")
+	entry.Certificate = certificate
+	if i := strings.LastIndex(certificate, ":"); i >= 0 {
+		if n, err := strconv.Atoi(certificate[i+1:]); err == nil {
+			entry.Certificate, entry.Line = certificate[:i], n
+		}
+	}
+
+	rest = strings.TrimSpace(rest)
+	if strings.HasPrefix(rest, "[") {
+		if options, filters, ok := strings.Cut(rest[1:], "]"); ok {
+			entry.SSLOptions = strings.TrimSpace(options)
+			rest = filters
+		}
+	}
+	entry.SNIFilters = strings.Fields(rest)
+	return entry
+}
+
+// AddCrtListEntry adds a certificate to a crt-list, so the binds using the
+// crt-list serve it for the entry's SNI filters. The certificate must already
+// be loaded, e.g. with NewSSLCert, SetSSLCert and CommitSSLCert.
+func (c *HAProxyClient) AddCrtListEntry(crtList string, entry CrtListEntry) error {
+	return c.AddCrtListEntryWithContext(context.Background(), crtList, entry)
+}
+
+// AddCrtListEntryWithContext adds a certificate to a crt-list with context support.
+func (c *HAProxyClient) AddCrtListEntryWithContext(ctx context.Context, crtList string, entry CrtListEntry) error {
+	if entry.Certificate == "" || validatePath("certificate", entry.Certificate) != nil {
+		return fmt.Errorf("failed to add to crt-list %s: invalid certificate %q", crtList, entry.Certificate)
+	}
+	if strings.ContainsAny(entry.SSLOptions, "[]") || validateWords("SSL options", entry.SSLOptions) != nil {
+		return fmt.Errorf("failed to add to crt-list %s: SSL options must not contain brackets, ';' or newlines", crtList)
+	}
+	for _, filter := range entry.SNIFilters {
+		if filter == "" || strings.ContainsAny(filter, "[]") || validatePath("SNI filter", filter) != nil {
+			return fmt.Errorf("failed to add to crt-list %s: invalid SNI filter %q", crtList, filter)
+		}
+	}
+
+	command := fmt.Sprintf("add ssl crt-list %s %s", crtList, entry.Certificate)
+	if entry.SSLOptions != "" {
+		command += " [" + entry.SSLOptions + "]"
+	}
+	if len(entry.SNIFilters) > 0 {
+		command += " " + strings.Join(entry.SNIFilters, " ")
+	}
+	return c.sslCommand(ctx, command, crtList, "add to crt-list", "Success!")
+}
+
+// DelCrtListEntry removes a certificate from a crt-list. line selects one of
+// several entries of the same certificate; 0 requires the certificate to
+// appear once.
+func (c *HAProxyClient) DelCrtListEntry(crtList, certificate string, line int) error {
+	return c.DelCrtListEntryWithContext(context.Background(), crtList, certificate, line)
+}
+
+// DelCrtListEntryWithContext removes a certificate from a crt-list with context support.
+func (c *HAProxyClient) DelCrtListEntryWithContext(ctx context.Context, crtList, certificate string, line int) error {
+	if certificate == "" {
+		return fmt.Errorf("failed to delete from crt-list %s: certificate is required", crtList)
+	}
+	if err := validatePath("certificate", certificate); err != nil {
+		return fmt.Errorf("failed to delete from crt-list %s: %w", crtList, err)
+	}
+	if line > 0 {
+		certificate = fmt.Sprintf("%s:%d", certificate, line)
+	}
+	return c.sslCommand(ctx, fmt.Sprintf("del ssl crt-list %s %s", crtList, certificate), crtList, "delete from crt-list", "Entry '")
+}
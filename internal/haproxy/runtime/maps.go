@@ -0,0 +1,102 @@
+package haproxy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// mapRef converts a map identifier into the form expected by the Runtime API.
+// Numeric identifiers are map IDs and need a '#' prefix; anything else is a file name.
+func mapRef(id string) string {
+	if _, err := strconv.Atoi(id); err == nil {
+		return "#" + id
+	}
+	return id
+}
+
+// ShowMap retrieves the entries of an HAProxy map.
+func (c *HAProxyClient) ShowMap(id string) ([]MapEntry, error) {
+	return c.ShowMapWithContext(context.Background(), id)
+}
+
+// ShowMapWithContext retrieves the entries of an HAProxy map with context support.
+func (c *HAProxyClient) ShowMapWithContext(ctx context.Context, id string) ([]MapEntry, error) {
+	slog.Debug("HAProxyClient.ShowMap called", "map", id)
+
+	if err := validatePath("map", id); err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("map identifier is required")
+	}
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, fmt.Sprintf("show map %s", mapRef(id)))
+	if err != nil {
+		slog.Error("Failed to show map", "map", id, "error", err)
+		return nil, fmt.Errorf("failed to show map %s: %w", id, err)
+	}
+
+	// Each line is "<entry id> <key> <value>"; the value may contain spaces
+	entries := make([]MapEntry, 0)
+	for _, line := range strings.Split(strings.TrimSpace(result), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) < 2 {
+			continue
+		}
+		entry := MapEntry{ID: fields[0], Key: fields[1]}
+		if len(fields) == 3 {
+			entry.Value = fields[2]
+		}
+		entries = append(entries, entry)
+	}
+
+	slog.Debug("Successfully retrieved map", "map", id, "entries", len(entries))
+	return entries, nil
+}
+
+// ListMaps lists the maps loaded by HAProxy.
+func (c *HAProxyClient) ListMaps() ([]MapInfo, error) {
+	return c.ListMapsWithContext(context.Background())
+}
+
+// ListMapsWithContext lists the maps loaded by HAProxy with context support.
+func (c *HAProxyClient) ListMapsWithContext(ctx context.Context) ([]MapInfo, error) {
+	slog.Debug("HAProxyClient.ListMaps called")
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show map")
+	if err != nil {
+		slog.Error("Failed to list maps", "error", err)
+		return nil, fmt.Errorf("failed to list maps: %w", err)
+	}
+
+	// Each line is "<id> (<file>) <description>"
+	maps := make([]MapInfo, 0)
+	for _, line := range strings.Split(strings.TrimSpace(result), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		id, rest, _ := strings.Cut(line, " ")
+		info := MapInfo{ID: id}
+		if file, description, ok := strings.Cut(strings.TrimPrefix(rest, "("), ") "); ok && strings.HasPrefix(rest, "(") {
+			info.File = file
+			info.Description = description
+		} else {
+			info.Description = rest
+		}
+		maps = append(maps, info)
+	}
+
+	slog.Debug("Successfully listed maps", "count", len(maps))
+	return maps, nil
+}
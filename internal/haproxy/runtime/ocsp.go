@@ -0,0 +1,126 @@
+package haproxy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ocspResponseFields maps the 'show ssl ocsp-response <id>' keys to OCSPResponse fields
+var ocspResponseFields = map[string]func(response *OCSPResponse, value string){
+	"OCSP Response Status": func(response *OCSPResponse, value string) { response.ResponseStatus = value },
+	"Responder Id":         func(response *OCSPResponse, value string) { response.ResponderID = value },
+	"Produced At":          func(response *OCSPResponse, value string) { response.ProducedAt = value },
+	"Serial Number":        func(response *OCSPResponse, value string) { response.SerialNumber = value },
+	"Cert Status":          func(response *OCSPResponse, value string) { response.CertStatus = value },
+	"Revocation Time":      func(response *OCSPResponse, value string) { response.RevocationTime = value },
+	"This Update":          func(response *OCSPResponse, value string) { response.ThisUpdate = value },
+	"Next Update":          func(response *OCSPResponse, value string) { response.NextUpdate = value },
+}
+
+// ListOCSPResponses lists the OCSP responses stapled by HAProxy, with the
+// certificate each one belongs to. It requires HAProxy 2.7 or later.
+func (c *HAProxyClient) ListOCSPResponses() ([]OCSPResponseID, error) {
+	return c.ListOCSPResponsesWithContext(context.Background())
+}
+
+// ListOCSPResponsesWithContext lists the OCSP responses stapled by HAProxy with context support.
+func (c *HAProxyClient) ListOCSPResponsesWithContext(ctx context.Context) ([]OCSPResponseID, error) {
+	slog.Debug("HAProxyClient.ListOCSPResponses called")
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show ssl ocsp-response")
+	if err != nil {
+		slog.Error("Failed to list OCSP responses", "error", err)
+		return nil, fmt.Errorf("failed to list OCSP responses: %w", err)
+	}
+
+	responses := parseOCSPResponseIDs(result)
+	slog.Debug("Successfully listed OCSP responses", "count", len(responses))
+	return responses, nil
+}
+
+// parseOCSPResponseIDs parses 'show ssl ocsp-response': a "Certificate ID key"
+// line per response, followed by its certificate path and certificate ID.
+func parseOCSPResponseIDs(output string) []OCSPResponseID {
+	responses := make([]OCSPResponseID, 0)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if key == "Certificate ID key" {
+			responses = append(responses, OCSPResponseID{ID: value})
+			continue
+		}
+		if len(responses) == 0 {
+			continue
+		}
+		response := &responses[len(responses)-1]
+		switch key {
+		case "Certificate path":
+			response.Certificate = value
+		case "Issuer Name Hash":
+			response.IssuerNameHash = value
+		case "Issuer Key Hash":
+			response.IssuerKeyHash = value
+		case "Serial Number":
+			response.SerialNumber = value
+		}
+	}
+	return responses
+}
+
+// ShowOCSPResponse returns the status and validity of an OCSP response. id is
+// a certificate ID key listed by ListOCSPResponses or, from HAProxy 2.8, the
+// path of the certificate.
+func (c *HAProxyClient) ShowOCSPResponse(id string) (*OCSPResponse, error) {
+	return c.ShowOCSPResponseWithContext(context.Background(), id)
+}
+
+// ShowOCSPResponseWithContext returns the status and validity of an OCSP response with context support.
+func (c *HAProxyClient) ShowOCSPResponseWithContext(ctx context.Context, id string) (*OCSPResponse, error) {
+	slog.Debug("HAProxyClient.ShowOCSPResponse called", "id", id)
+
+	if err := validatePath("OCSP response", id); err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("OCSP response ID or certificate is required")
+	}
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show ssl ocsp-response "+id)
+	if err != nil {
+		slog.Error("Failed to show OCSP response", "id", id, "error", err)
+		return nil, fmt.Errorf("failed to show OCSP response %s: %w", id, err)
+	}
+
+	response := &OCSPResponse{ID: id}
+	for _, line := range strings.Split(result, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		if set, known := ocspResponseFields[key]; known {
+			set(response, strings.TrimSpace(value))
+		}
+	}
+	if response.ResponseStatus == "" {
+		// Unknown responses are reported with a plain message
+		return nil, fmt.Errorf("failed to show OCSP response %s: %s", id, strings.TrimSpace(result))
+	}
+	return response, nil
+}
+
+// UpdateOCSPResponse fetches a fresh OCSP response for a certificate from its
+// responder and staples it. It requires HAProxy 2.8 or later.
+func (c *HAProxyClient) UpdateOCSPResponse(certificate string) error {
+	return c.UpdateOCSPResponseWithContext(context.Background(), certificate)
+}
+
+// UpdateOCSPResponseWithContext refreshes the OCSP response of a certificate with context support.
+func (c *HAProxyClient) UpdateOCSPResponseWithContext(ctx context.Context, certificate string) error {
+	return c.sslCommand(ctx, "update ssl ocsp-response "+certificate, certificate, "update OCSP response", "OCSP Response updated")
+}
@@ -0,0 +1,209 @@
+package haproxy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// peersSectionPattern matches the line opening a peers section:
+	// "0x55871b5ab320: [07/Jul/2020:11:48:02] id=mypeers disabled=0 flags=0x2000 ..."
+	peersSectionPattern = regexp.MustCompile(`^0x[0-9a-f]+: \[[^\]]*\] (.*)$`)
+
+	// peerPattern matches the line opening a peer of a section:
+	// "  0x55871b5b2d50: id=hostB(remote,active) addr=127.0.0.11:10001 ..."
+	peerPattern = regexp.MustCompile(`^\s+0x[0-9a-f]+: (id=.*)$`)
+
+	// peerTablePattern matches the line opening a table shared with a peer:
+	// "    0x55871b5b0800 local_id=1 remote_id=1 flags=0x0 remote_data=0x65"
+	peerTablePattern = regexp.MustCompile(`^\s+0x[0-9a-f]+ (local_id=.*)$`)
+)
+
+// ShowPeers returns the peers sections, or the section named name, with the
+// connection and synchronization state of each peer and its shared tables.
+func (c *HAProxyClient) ShowPeers(name string) ([]PeersSection, error) {
+	return c.ShowPeersWithContext(context.Background(), name)
+}
+
+// ShowPeersWithContext returns the peers sections with context support.
+func (c *HAProxyClient) ShowPeersWithContext(ctx context.Context, name string) ([]PeersSection, error) {
+	slog.Debug("HAProxyClient.ShowPeers called", "peers", name)
+
+	if err := ValidateName("peers section", name); err != nil {
+		return nil, err
+	}
+
+	command := "show peers"
+	if name != "" {
+		command += " " + name
+	}
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, command)
+	if err != nil {
+		slog.Error("Failed to show peers", "peers", name, "error", err)
+		return nil, fmt.Errorf("failed to show peers: %w", err)
+	}
+
+	sections := parsePeers(result)
+	if len(sections) == 0 && strings.TrimSpace(result) != "" {
+		// Unknown sections are reported with a plain message
+		return nil, fmt.Errorf("failed to show peers %s: %s", name, strings.TrimSpace(result))
+	}
+
+	slog.Debug("Successfully retrieved peers", "sections", len(sections))
+	return sections, nil
+}
+
+// parsePeers parses 'show peers'. Each peer line is followed by indented
+// lines of key=value attributes and, after "shared tables:", by the tables it
+// shares. The attributes differ between HAProxy versions: 2.0 reports
+// status=, later versions last_status=, last_hdshk= and the state= of the
+// connection, if any.
+func parsePeers(output string) []PeersSection {
+	sections := make([]PeersSection, 0)
+	var peer *PeerInfo
+	var table *PeerTable
+	inTables := false
+
+	for _, line := range strings.Split(output, "\n") {
+		if m := peersSectionPattern.FindStringSubmatch(line); m != nil {
+			attrs := peerAttributes(m[1])
+			sections = append(sections, PeersSection{
+				Name:          attrs["id"],
+				Disabled:      attrs["disabled"] != "" && attrs["disabled"] != "0",
+				Flags:         attrs["flags"],
+				ResyncTimeout: attrs["resync_timeout"],
+				Peers:         make([]PeerInfo, 0),
+			})
+			peer, table, inTables = nil, nil, false
+			continue
+		}
+		if len(sections) == 0 {
+			continue
+		}
+
+		if m := peerPattern.FindStringSubmatch(line); m != nil {
+			section := &sections[len(sections)-1]
+			section.Peers = append(section.Peers, newPeerInfo(peerAttributes(m[1])))
+			peer, table, inTables = &section.Peers[len(section.Peers)-1], nil, false
+			continue
+		}
+		if peer == nil {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "shared tables:":
+			inTables = true
+		case !inTables:
+			setPeerAttributes(peer, peerAttributes(trimmed))
+		default:
+			if m := peerTablePattern.FindStringSubmatch(line); m != nil {
+				peer.Tables = append(peer.Tables, PeerTable{})
+				table = &peer.Tables[len(peer.Tables)-1]
+			}
+			if table != nil {
+				setPeerTableAttributes(table, trimmed)
+			}
+		}
+	}
+
+	for i := range sections {
+		for j := range sections[i].Peers {
+			for k := range sections[i].Peers[j].Tables {
+				t := &sections[i].Peers[j].Tables[k]
+				t.Synced = t.LastAcked == t.LastPushed && t.LastPushed == t.LocalUpdate
+			}
+		}
+	}
+	return sections
+}
+
+// peerAttributes returns the key=value pairs of a line; the first occurrence of a key wins
+func peerAttributes(line string) map[string]string {
+	attrs := make(map[string]string)
+	for _, field := range strings.Fields(line) {
+		if key, value, ok := strings.Cut(field, "="); ok {
+			if _, seen := attrs[key]; !seen {
+				attrs[key] = value
+			}
+		}
+	}
+	return attrs
+}
+
+// newPeerInfo builds a peer from the attributes of its first line. Its id
+// carries the role: "hostB(remote,active)" or, before HAProxy 2.4, "hostB(remote)".
+func newPeerInfo(attrs map[string]string) PeerInfo {
+	peer := PeerInfo{Name: attrs["id"], Tables: make([]PeerTable, 0)}
+	if name, roles, ok := strings.Cut(peer.Name, "("); ok {
+		peer.Name = name
+		for _, role := range strings.Split(strings.TrimSuffix(roles, ")"), ",") {
+			switch role {
+			case "local":
+				peer.Local = true
+			case "active", "inactive":
+				peer.Activity = role
+			}
+		}
+	}
+	setPeerAttributes(&peer, attrs)
+	return peer
+}
+
+// setPeerAttributes sets the fields of peer from its attribute lines. Fields
+// already set are kept, since later lines describe the connection and reuse
+// some keys, e.g. addr for the connection's destination.
+func setPeerAttributes(peer *PeerInfo, attrs map[string]string) {
+	setString := func(field *string, key string) {
+		if value, ok := attrs[key]; ok && *field == "" {
+			*field = value
+		}
+	}
+	setInt := func(field *int64, key string) {
+		if n, err := strconv.ParseInt(attrs[key], 10, 64); err == nil {
+			*field = n
+		}
+	}
+
+	setString(&peer.Address, "addr")
+	setString(&peer.Status, "last_status")
+	setString(&peer.Status, "status")
+	setString(&peer.LastHandshake, "last_hdshk")
+	setString(&peer.Reconnect, "reconnect")
+	setString(&peer.Heartbeat, "heartbeat")
+	setString(&peer.State, "state")
+	setInt(&peer.Confirm, "confirm")
+	setInt(&peer.NewConnections, "new_conn")
+	setInt(&peer.ProtocolErrors, "proto_err")
+	setInt(&peer.Collisions, "coll")
+}
+
+// setPeerTableAttributes sets the fields of table from a line of its block.
+// The "table:<ptr> id=<name> ..." line describes the stick table itself.
+func setPeerTableAttributes(table *PeerTable, line string) {
+	attrs := peerAttributes(line)
+	setInt := func(field *int64, key string) {
+		if n, err := strconv.ParseInt(attrs[key], 10, 64); err == nil {
+			*field = n
+		}
+	}
+
+	if strings.HasPrefix(line, "table:") {
+		table.Table = attrs["id"]
+		setInt(&table.Update, "update")
+		setInt(&table.LocalUpdate, "localupdate")
+		setInt(&table.CommitUpdate, "commitupdate")
+		return
+	}
+	setInt(&table.LocalID, "local_id")
+	setInt(&table.RemoteID, "remote_id")
+	setInt(&table.LastAcked, "last_acked")
+	setInt(&table.LastPushed, "last_pushed")
+	setInt(&table.LastGet, "last_get")
+	setInt(&table.TeachingOrigin, "teaching_origin")
+}
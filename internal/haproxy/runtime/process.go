@@ -0,0 +1,343 @@
+package haproxy
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// threadPattern matches the line opening a thread of 'show threads'. The
+	// thread running the command is marked with '*' and stuck threads with '>':
+	// "*>Thread 2 : id=0x7f5e9d7fe700 act=1 glob=0 wq=1 rq=0 tl=1 tlsz=0 rqsz=0"
+	threadPattern = regexp.MustCompile(`^([ *])?([ >])?Thread\s+(\d+)\s*:(.*)$`)
+
+	// threadFunctionPattern matches the function of the task a thread runs: "fct=0x55d8f6bf7e90(process_stream)"
+	threadFunctionPattern = regexp.MustCompile(`fct=0x[0-9a-f]+\(([^)]+)\)`)
+
+	// poolPattern matches a pool of 'show pools', with the thread caches and
+	// needed_avg reported by recent versions only:
+	// "  - Pool buffer (16384 bytes) : 6 allocated (98304 bytes), 2 used (~0 by thread caches), needed_avg 3, 0 failures, 2 users, @0x55 [SHARED]"
+	poolPattern = regexp.MustCompile(`^\s*- Pool (\S+) \((\d+) bytes\) : (\d+) allocated \((\d+) bytes\), (\d+) used(?: \(~(\d+) by thread caches\))?(?:, needed_avg (\d+))?, (\d+) failures, (\d+) users`)
+
+	// poolsTotalPattern matches the summary line of 'show pools': "Total: 15 pools, 1312000 bytes allocated, 52000 used"
+	poolsTotalPattern = regexp.MustCompile(`^Total: (\d+) pools, (\d+) bytes allocated, (\d+) used`)
+
+	// runningTasksPattern matches the header of 'show tasks': "Running tasks: 2 (4 threads)"
+	runningTasksPattern = regexp.MustCompile(`^Running tasks: (\d+) \((\d+) threads?\)`)
+
+	// fdPattern matches a file descriptor of 'show fd': "     11 : st=0x22(R:pRa W:pRa) ev=0x01(heopI) ..."
+	fdPattern = regexp.MustCompile(`^\s*(\d+)\s*: st=`)
+
+	// fdHandlerPattern matches the I/O callback of a file descriptor: "iocb=0x55d8f6b4b1a0(sock_conn_iocb)"
+	fdHandlerPattern = regexp.MustCompile(`iocb=0x[0-9a-f]+\(([^)]+)\)`)
+)
+
+// fdHandlerTypes maps the I/O callbacks of 'show fd' to file descriptor types.
+// Connections are further split by side.
+var fdHandlerTypes = map[string]string{
+	"listener_accept":         "listener",
+	"sock_accept_iocb":        "listener",
+	"conn_fd_handler":         "connection",
+	"sock_conn_iocb":          "connection",
+	"dgram_fd_handler":        "dgram",
+	"quic_lstnr_sock_fd_iocb": "quic",
+	"quic_conn_sock_fd_iocb":  "quic",
+	"poller_pipe_io_handler":  "pipe",
+	"thread_sync_io_handler":  "pipe",
+	"mworker_accept_wrapper":  "master",
+}
+
+// ShowActivity returns the scheduler and poller counters of 'show activity',
+// totaled and per thread.
+func (c *HAProxyClient) ShowActivity() (*ProcessActivity, error) {
+	return c.ShowActivityWithContext(context.Background())
+}
+
+// ShowActivityWithContext returns the scheduler and poller counters with context support.
+func (c *HAProxyClient) ShowActivityWithContext(ctx context.Context) (*ProcessActivity, error) {
+	slog.Debug("HAProxyClient.ShowActivity called")
+
+	result, err := c.executeWithErrorHandling(ctx, "show activity", "show activity")
+	if err != nil {
+		return nil, err
+	}
+	return parseActivity(result), nil
+}
+
+// parseActivity parses 'show activity'. Counters are "<name>: <total> [ <per thread>... ]"
+// from HAProxy 2.4 and "<name>: <per thread>..." before.
+func parseActivity(output string) *ProcessActivity {
+	activity := &ProcessActivity{Counters: make(map[string]ActivityCounter)}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "thread_id":
+			// "1 (1..4)": the thread running the command and the thread range
+			if _, last, ok := strings.Cut(value, ".."); ok {
+				activity.Threads, _ = strconv.Atoi(strings.TrimSuffix(last, ")"))
+			}
+			continue
+		case "thr_id":
+			activity.Threads = len(strings.Fields(value))
+			continue
+		}
+
+		var counter ActivityCounter
+		total, threads, bracketed := strings.Cut(value, "[")
+		if bracketed {
+			n, err := strconv.ParseInt(strings.TrimSpace(total), 10, 64)
+			if err != nil {
+				continue
+			}
+			counter.Total = n
+			counter.PerThread = parseInts(strings.Fields(strings.TrimSuffix(strings.TrimSpace(threads), "]")))
+		} else {
+			perThread := parseInts(strings.Fields(value))
+			if perThread == nil {
+				continue // Not a counter, e.g. date_now
+			}
+			for _, n := range perThread {
+				counter.Total += n
+			}
+			if len(perThread) > 1 {
+				counter.PerThread = perThread
+			}
+		}
+		activity.Counters[key] = counter
+	}
+	if loops, ok := activity.Counters["loops"]; ok && activity.Threads == 0 {
+		activity.Threads = max(len(loops.PerThread), 1)
+	}
+	return activity
+}
+
+// parseInts parses fields as integers, or returns nil if any is not one
+func parseInts(fields []string) []int64 {
+	if len(fields) == 0 {
+		return nil
+	}
+	values := make([]int64, 0, len(fields))
+	for _, field := range fields {
+		n, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil
+		}
+		values = append(values, n)
+	}
+	return values
+}
+
+// ShowThreads returns the state of each thread: its queues, whether it is
+// stuck and the task it is running.
+func (c *HAProxyClient) ShowThreads() ([]ThreadInfo, error) {
+	return c.ShowThreadsWithContext(context.Background())
+}
+
+// ShowThreadsWithContext returns the state of each thread with context support.
+func (c *HAProxyClient) ShowThreadsWithContext(ctx context.Context) ([]ThreadInfo, error) {
+	slog.Debug("HAProxyClient.ShowThreads called")
+
+	result, err := c.executeWithErrorHandling(ctx, "show threads", "show threads")
+	if err != nil {
+		return nil, err
+	}
+	return parseThreads(result), nil
+}
+
+// parseThreads parses 'show threads': a "Thread <n> :" line per thread,
+// followed by indented lines of key=value attributes and the current task.
+func parseThreads(output string) []ThreadInfo {
+	threads := make([]ThreadInfo, 0)
+	for _, line := range strings.Split(output, "\n") {
+		if m := threadPattern.FindStringSubmatch(line); m != nil {
+			id, _ := strconv.Atoi(m[3])
+			threads = append(threads, ThreadInfo{ID: id, Calling: m[1] == "*", Stuck: m[2] == ">"})
+			line = m[4]
+		} else if len(threads) == 0 {
+			continue
+		}
+
+		thread := &threads[len(threads)-1]
+		if m := threadFunctionPattern.FindStringSubmatch(line); m != nil && thread.CurrentTask == "" {
+			thread.CurrentTask = m[1]
+		}
+		attrs := peerAttributes(line)
+		setInt := func(field *int64, key string) {
+			if n, err := strconv.ParseInt(attrs[key], 10, 64); err == nil {
+				*field = n
+			}
+		}
+		setInt(&thread.WaitQueue, "wq")
+		setInt(&thread.RunQueue, "rq")
+		setInt(&thread.Tasklets, "tl")
+		setInt(&thread.RunQueueSize, "rqsz")
+		if _, ok := attrs["curr_task"]; ok {
+			setInt(&thread.CurrentTaskCalls, "calls")
+		}
+		if attrs["act"] != "" {
+			thread.Active = attrs["act"] != "0"
+		}
+		if attrs["stuck"] == "1" {
+			thread.Stuck = true
+		}
+		if attrs["harmless"] != "" {
+			thread.Harmless = attrs["harmless"] != "0"
+		}
+	}
+	return threads
+}
+
+// ShowPools returns the memory pools and their usage.
+func (c *HAProxyClient) ShowPools() (*PoolsInfo, error) {
+	return c.ShowPoolsWithContext(context.Background())
+}
+
+// ShowPoolsWithContext returns the memory pools and their usage with context support.
+func (c *HAProxyClient) ShowPoolsWithContext(ctx context.Context) (*PoolsInfo, error) {
+	slog.Debug("HAProxyClient.ShowPools called")
+
+	result, err := c.executeWithErrorHandling(ctx, "show pools", "show pools")
+	if err != nil {
+		return nil, err
+	}
+	return parsePools(result), nil
+}
+
+// parsePools parses the pool lines and the total of 'show pools'. Pools are
+// sorted by allocated bytes, largest first.
+func parsePools(output string) *PoolsInfo {
+	pools := &PoolsInfo{Pools: make([]PoolUsage, 0)}
+	for _, line := range strings.Split(output, "\n") {
+		if m := poolPattern.FindStringSubmatch(line); m != nil {
+			n := func(i int) int64 {
+				v, _ := strconv.ParseInt(m[i], 10, 64)
+				return v
+			}
+			pools.Pools = append(pools.Pools, PoolUsage{
+				Name:           m[1],
+				Size:           n(2),
+				Allocated:      n(3),
+				AllocatedBytes: n(4),
+				Used:           n(5),
+				Cached:         n(6),
+				NeededAvg:      n(7),
+				Failures:       n(8),
+				Users:          n(9),
+			})
+			continue
+		}
+		if m := poolsTotalPattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			pools.TotalPools, _ = strconv.Atoi(m[1])
+			pools.AllocatedBytes, _ = strconv.ParseInt(m[2], 10, 64)
+			pools.UsedBytes, _ = strconv.ParseInt(m[3], 10, 64)
+		}
+	}
+	sort.SliceStable(pools.Pools, func(i, j int) bool {
+		return pools.Pools[i].AllocatedBytes > pools.Pools[j].AllocatedBytes
+	})
+	return pools
+}
+
+// ShowTasks returns the tasks waiting in the run queues, grouped by function.
+func (c *HAProxyClient) ShowTasks() (*TasksInfo, error) {
+	return c.ShowTasksWithContext(context.Background())
+}
+
+// ShowTasksWithContext returns the tasks waiting in the run queues with context support.
+func (c *HAProxyClient) ShowTasksWithContext(ctx context.Context) (*TasksInfo, error) {
+	slog.Debug("HAProxyClient.ShowTasks called")
+
+	result, err := c.executeWithErrorHandling(ctx, "show tasks", "show tasks")
+	if err != nil {
+		return nil, err
+	}
+	return parseTasks(result), nil
+}
+
+// parseTasks parses 'show tasks': a "Running tasks" header, then a
+// "function places % lat_tot lat_avg" row per function. Functions are sorted
+// by places, most first.
+func parseTasks(output string) *TasksInfo {
+	tasks := &TasksInfo{Tasks: make([]TaskUsage, 0)}
+	for _, line := range strings.Split(output, "\n") {
+		if m := runningTasksPattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			tasks.Running, _ = strconv.Atoi(m[1])
+			tasks.Threads, _ = strconv.Atoi(m[2])
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] == "function" {
+			continue
+		}
+		places, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		task := TaskUsage{Function: fields[0], Places: places}
+		task.Percent, _ = strconv.ParseFloat(fields[2], 64)
+		if len(fields) > 3 && fields[3] != "-" {
+			task.LatencyTotal = fields[3]
+		}
+		if len(fields) > 4 && fields[4] != "-" {
+			task.LatencyAvg = fields[4]
+		}
+		tasks.Tasks = append(tasks.Tasks, task)
+	}
+	sort.SliceStable(tasks.Tasks, func(i, j int) bool {
+		return tasks.Tasks[i].Places > tasks.Tasks[j].Places
+	})
+	return tasks
+}
+
+// ShowFDs counts the open file descriptors by type.
+func (c *HAProxyClient) ShowFDs() (*FDInfo, error) {
+	return c.ShowFDsWithContext(context.Background())
+}
+
+// ShowFDsWithContext counts the open file descriptors by type with context support.
+func (c *HAProxyClient) ShowFDsWithContext(ctx context.Context) (*FDInfo, error) {
+	slog.Debug("HAProxyClient.ShowFDs called")
+
+	result, err := c.executeWithErrorHandling(ctx, "show fd", "show fd")
+	if err != nil {
+		return nil, err
+	}
+	return parseFDs(result), nil
+}
+
+// parseFDs counts the file descriptors of 'show fd' by type, derived from
+// their I/O callback: listeners, frontend and backend connections, pipes and
+// so on. Unknown callbacks count under their own name.
+func parseFDs(output string) *FDInfo {
+	fds := &FDInfo{ByType: make(map[string]int)}
+	for _, line := range strings.Split(output, "\n") {
+		if !fdPattern.MatchString(line) {
+			continue
+		}
+		fds.Total++
+
+		kind := "unknown"
+		if m := fdHandlerPattern.FindStringSubmatch(line); m != nil {
+			kind = m[1]
+			if known, ok := fdHandlerTypes[m[1]]; ok {
+				kind = known
+			}
+		}
+		if kind == "connection" {
+			kind = "frontend_connection"
+			if peerAttributes(line)["back"] == "1" {
+				kind = "backend_connection"
+			}
+		}
+		fds.ByType[kind]++
+	}
+	return fds
+}
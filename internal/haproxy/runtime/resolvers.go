@@ -0,0 +1,104 @@
+package haproxy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// nameserverCounters maps the 'show resolvers' counters to NameserverStats fields
+var nameserverCounters = map[string]func(stats *NameserverStats) *int64{
+	"sent":        func(stats *NameserverStats) *int64 { return &stats.Sent },
+	"snd_error":   func(stats *NameserverStats) *int64 { return &stats.SendErrors },
+	"valid":       func(stats *NameserverStats) *int64 { return &stats.Valid },
+	"update":      func(stats *NameserverStats) *int64 { return &stats.Update },
+	"cname":       func(stats *NameserverStats) *int64 { return &stats.CNAME },
+	"cname_error": func(stats *NameserverStats) *int64 { return &stats.CNAMEErrors },
+	"any_err":     func(stats *NameserverStats) *int64 { return &stats.AnyErrors },
+	"nx":          func(stats *NameserverStats) *int64 { return &stats.NX },
+	"timeout":     func(stats *NameserverStats) *int64 { return &stats.Timeouts },
+	"refused":     func(stats *NameserverStats) *int64 { return &stats.Refused },
+	"other":       func(stats *NameserverStats) *int64 { return &stats.Other },
+	"invalid":     func(stats *NameserverStats) *int64 { return &stats.Invalid },
+	"too_big":     func(stats *NameserverStats) *int64 { return &stats.TooBig },
+	"truncated":   func(stats *NameserverStats) *int64 { return &stats.Truncated },
+	"outdated":    func(stats *NameserverStats) *int64 { return &stats.Outdated },
+}
+
+// ShowResolvers returns the counters of each nameserver of the resolvers
+// sections, or of the section named id only.
+func (c *HAProxyClient) ShowResolvers(id string) ([]ResolversSection, error) {
+	return c.ShowResolversWithContext(context.Background(), id)
+}
+
+// ShowResolversWithContext returns the counters of each nameserver of the resolvers sections with context support.
+func (c *HAProxyClient) ShowResolversWithContext(ctx context.Context, id string) ([]ResolversSection, error) {
+	slog.Debug("HAProxyClient.ShowResolvers called", "resolvers", id)
+
+	if err := ValidateName("resolvers section", id); err != nil {
+		return nil, err
+	}
+
+	command := "show resolvers"
+	if id != "" {
+		command += " " + id
+	}
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, command)
+	if err != nil {
+		slog.Error("Failed to show resolvers", "resolvers", id, "error", err)
+		return nil, fmt.Errorf("failed to show resolvers: %w", err)
+	}
+
+	sections := parseResolvers(result)
+	if len(sections) == 0 && strings.TrimSpace(result) != "" {
+		// Unknown sections are reported with a plain message
+		return nil, fmt.Errorf("failed to show resolvers %s: %s", id, strings.TrimSpace(result))
+	}
+
+	slog.Debug("Successfully retrieved resolvers", "sections", len(sections))
+	return sections, nil
+}
+
+// parseResolvers parses 'show resolvers': a "Resolvers section <name>" line
+// per section, then " nameserver <name>:" and its "<counter>: <value>" lines.
+func parseResolvers(output string) []ResolversSection {
+	sections := make([]ResolversSection, 0)
+	var nameserver *NameserverStats
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if name, ok := strings.CutPrefix(line, "Resolvers section "); ok {
+			sections = append(sections, ResolversSection{Name: strings.TrimSpace(name), Nameservers: make([]NameserverStats, 0)})
+			nameserver = nil
+			continue
+		}
+		if len(sections) == 0 {
+			continue
+		}
+		section := &sections[len(sections)-1]
+		if name, ok := strings.CutPrefix(line, "nameserver "); ok {
+			section.Nameservers = append(section.Nameservers, NameserverStats{Name: strings.TrimSuffix(strings.TrimSpace(name), ":")})
+			nameserver = &section.Nameservers[len(section.Nameservers)-1]
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || nameserver == nil {
+			continue
+		}
+		if counter, known := nameserverCounters[strings.TrimSpace(key)]; known {
+			if n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+				*counter(nameserver) = n
+			}
+		}
+	}
+
+	for i := range sections {
+		for j := range sections[i].Nameservers {
+			stats := &sections[i].Nameservers[j]
+			stats.Errors = stats.SendErrors + stats.CNAMEErrors + stats.AnyErrors + stats.Other + stats.Invalid + stats.TooBig + stats.Truncated
+		}
+	}
+	return sections
+}
@@ -0,0 +1,90 @@
+package haproxy
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RetryPolicy controls how connection attempts to the Runtime API are retried.
+// Only establishing the connection is retried; a command that has been sent is
+// never replayed, because many Runtime API commands are not idempotent.
+type RetryPolicy struct {
+	MaxAttempts    int           // Total attempts including the first; 1 disables retries
+	InitialBackoff time.Duration // Wait before the second attempt
+	MaxBackoff     time.Duration // Upper bound for the wait between attempts
+	Multiplier     float64       // Growth factor applied to the wait after each attempt
+}
+
+// DefaultRetryPolicy is used when ClientOptions.Retry is left empty
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+}
+
+// withDefaults fills unset fields from DefaultRetryPolicy
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultRetryPolicy.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultRetryPolicy.MaxBackoff
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultRetryPolicy.Multiplier
+	}
+	return p
+}
+
+// backoff returns the wait before the given attempt (attempt 1 is the first retry)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		wait = time.Duration(float64(wait) * p.Multiplier)
+		if wait >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return wait
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// permanentError marks a dial failure that retrying cannot fix, such as a
+// rejected certificate or failed SSH authentication
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// permanent wraps err so that the dial loop stops retrying
+func permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// isPermanent reports whether err was marked as not retryable
+func isPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
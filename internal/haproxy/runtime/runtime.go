@@ -1,6 +1,7 @@
 package haproxy
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -12,10 +13,15 @@ import (
 
 // GetRuntimeInfo retrieves runtime information (like 'show info').
 func (c *HAProxyClient) GetRuntimeInfo() (map[string]string, error) {
+	return c.GetRuntimeInfoWithContext(context.Background())
+}
+
+// GetRuntimeInfoWithContext retrieves runtime information (like 'show info') with context support.
+func (c *HAProxyClient) GetRuntimeInfoWithContext(ctx context.Context) (map[string]string, error) {
 	slog.Debug("HAProxyClient.GetRuntimeInfo called")
 
 	// Execute the 'show info' command directly
-	result, err := c.ExecuteRuntimeCommand("show info")
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show info")
 	if err != nil {
 		slog.Error("Failed to get runtime info", "error", err)
 		return nil, fmt.Errorf("failed to get runtime info: %w", err)
@@ -55,8 +61,17 @@ func (c *HAProxyClient) GetRuntimeInfo() (map[string]string, error) {
 // ShowStat executes the 'show stat' Runtime API command to get HAProxy statistics.
 // The optional filter parameter can be used to filter by proxy or server names.
 func (c *HAProxyClient) ShowStat(filter string) ([]map[string]string, error) {
+	return c.ShowStatWithContext(context.Background(), filter)
+}
+
+// ShowStatWithContext executes the 'show stat' Runtime API command to get HAProxy statistics with context support.
+func (c *HAProxyClient) ShowStatWithContext(ctx context.Context, filter string) ([]map[string]string, error) {
 	slog.Debug("HAProxyClient.ShowStat called", "filter", filter)
 
+	if err := validateWords("show stat filter", filter); err != nil {
+		return nil, err
+	}
+
 	// Construct command - add filter if provided
 	cmd := "show stat"
 	if filter != "" {
@@ -64,7 +79,7 @@ func (c *HAProxyClient) ShowStat(filter string) ([]map[string]string, error) {
 	}
 
 	// Execute command
-	result, err := c.ExecuteRuntimeCommand(cmd)
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to execute 'show stat' command", "error", err)
 		return nil, fmt.Errorf("failed to execute 'show stat': %w", err)
@@ -82,10 +97,15 @@ func (c *HAProxyClient) ShowStat(filter string) ([]map[string]string, error) {
 
 // GetStats retrieves runtime statistics.
 func (c *HAProxyClient) GetStats() (map[string]interface{}, error) {
+	return c.GetStatsWithContext(context.Background())
+}
+
+// GetStatsWithContext retrieves runtime statistics with context support.
+func (c *HAProxyClient) GetStatsWithContext(ctx context.Context) (map[string]interface{}, error) {
 	slog.Debug("HAProxyClient.GetStats called")
 
 	// Use ShowStat which is already implemented with direct command
-	rawStats, err := c.ShowStat("")
+	rawStats, err := c.ShowStatWithContext(ctx, "")
 	if err != nil {
 		slog.Error("Failed to get stats", "error", err)
 		return nil, fmt.Errorf("failed to get stats: %w", err)
@@ -187,10 +207,15 @@ func (c *HAProxyClient) GetStats() (map[string]interface{}, error) {
 
 // DebugCounters retrieves HAProxy internal counters.
 func (c *HAProxyClient) DebugCounters() (map[string]string, error) {
+	return c.DebugCountersWithContext(context.Background())
+}
+
+// DebugCountersWithContext retrieves HAProxy internal counters with context support.
+func (c *HAProxyClient) DebugCountersWithContext(ctx context.Context) (map[string]string, error) {
 	slog.Debug("HAProxyClient.DebugCounters called")
 
 	// Execute the command directly
-	result, err := c.ExecuteRuntimeCommand("debug dev counters")
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "debug dev counters")
 	if err != nil {
 		slog.Error("Failed to get debug counters", "error", err)
 		return nil, fmt.Errorf("failed to get debug counters: %w", err)
@@ -229,10 +254,15 @@ func (c *HAProxyClient) DebugCounters() (map[string]string, error) {
 
 // ClearCountersAll executes the 'clear counters all' runtime command to reset all HAProxy statistics.
 func (c *HAProxyClient) ClearCountersAll() error {
+	return c.ClearCountersAllWithContext(context.Background())
+}
+
+// ClearCountersAllWithContext executes the 'clear counters all' runtime command to reset all HAProxy statistics with context support.
+func (c *HAProxyClient) ClearCountersAllWithContext(ctx context.Context) error {
 	slog.Debug("HAProxyClient.ClearCountersAll called")
 
 	// Execute the clear counters all command
-	_, err := c.ExecuteRuntimeCommand("clear counters all")
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, "clear counters all")
 	if err != nil {
 		slog.Error("Failed to execute 'clear counters all' command", "error", err)
 		return fmt.Errorf("failed to clear counters: %w", err)
@@ -244,13 +274,22 @@ func (c *HAProxyClient) ClearCountersAll() error {
 
 // DumpStatsFile executes the 'dump stats-file' runtime command to write stats to a file.
 func (c *HAProxyClient) DumpStatsFile(filepath string) (string, error) {
+	return c.DumpStatsFileWithContext(context.Background(), filepath)
+}
+
+// DumpStatsFileWithContext executes the 'dump stats-file' runtime command to write stats to a file with context support.
+func (c *HAProxyClient) DumpStatsFileWithContext(ctx context.Context, filepath string) (string, error) {
 	slog.Debug("HAProxyClient.DumpStatsFile called", "filepath", filepath)
 
+	if err := validatePath("file path", filepath); err != nil {
+		return "", err
+	}
+
 	// Construct command with the filepath
 	cmd := fmt.Sprintf("dump stats-file %s", filepath)
 
 	// Execute the command
-	result, err := c.ExecuteRuntimeCommand(cmd)
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to execute 'dump stats-file' command", "error", err, "filepath", filepath)
 		return "", fmt.Errorf("failed to dump stats to file: %w", err)
@@ -260,6 +299,34 @@ func (c *HAProxyClient) DumpStatsFile(filepath string) (string, error) {
 	return filepath, nil
 }
 
+// ShowErrors executes the 'show errors' runtime command to get the last captured protocol errors.
+// If proxy is empty, errors for all proxies are returned.
+func (c *HAProxyClient) ShowErrors(proxy string) (string, error) {
+	return c.ShowErrorsWithContext(context.Background(), proxy)
+}
+
+// ShowErrorsWithContext executes the 'show errors' runtime command with context support.
+func (c *HAProxyClient) ShowErrorsWithContext(ctx context.Context, proxy string) (string, error) {
+	slog.Debug("HAProxyClient.ShowErrors called", "proxy", proxy)
+
+	if err := ValidateName("proxy", proxy); err != nil {
+		return "", err
+	}
+
+	cmd := "show errors"
+	if proxy != "" {
+		cmd = fmt.Sprintf("%s %s", cmd, proxy)
+	}
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
+	if err != nil {
+		slog.Error("Failed to execute 'show errors' command", "error", err, "proxy", proxy)
+		return "", fmt.Errorf("failed to show errors: %w", err)
+	}
+
+	return result, nil
+}
+
 // ============================================
 // Section: Topology Discovery
 // ============================================
@@ -267,8 +334,17 @@ func (c *HAProxyClient) DumpStatsFile(filepath string) (string, error) {
 // ShowServersState executes the 'show servers state' runtime command to get server states.
 // If backend is empty, it will show all servers across all backends.
 func (c *HAProxyClient) ShowServersState(backend string) ([]map[string]string, error) {
+	return c.ShowServersStateWithContext(context.Background(), backend)
+}
+
+// ShowServersStateWithContext executes the 'show servers state' runtime command to get server states with context support.
+func (c *HAProxyClient) ShowServersStateWithContext(ctx context.Context, backend string) ([]map[string]string, error) {
 	slog.Debug("HAProxyClient.ShowServersState called", "backend", backend)
 
+	if err := ValidateName("backend", backend); err != nil {
+		return nil, err
+	}
+
 	// Construct command with optional backend filter
 	cmd := "show servers state"
 	if backend != "" {
@@ -276,52 +352,13 @@ func (c *HAProxyClient) ShowServersState(backend string) ([]map[string]string, e
 	}
 
 	// Execute the command
-	result, err := c.ExecuteRuntimeCommand(cmd)
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to execute 'show servers state' command", "error", err)
 		return nil, fmt.Errorf("failed to show servers state: %w", err)
 	}
 
-	// Parse the output into structured data
-	// The format is typically: # be_id be_name srv_id srv_name srv_addr srv_op_state...
-	lines := strings.Split(strings.TrimSpace(result), "\n")
-	if len(lines) < 2 {
-		// Need at least a header row and one data row
-		return []map[string]string{}, nil
-	}
-
-	// Extract header columns (skipping first line if it's a comment)
-	headerLineIndex := 0
-	if strings.HasPrefix(lines[0], "#") {
-		headerLineIndex = 1
-	}
-
-	// If we have no data rows, return empty result
-	if headerLineIndex >= len(lines) {
-		return []map[string]string{}, nil
-	}
-
-	// Split header into fields
-	headerFields := strings.Fields(lines[headerLineIndex])
-
-	// Parse data rows
-	serverStates := make([]map[string]string, 0, len(lines)-headerLineIndex-1)
-	for i := headerLineIndex + 1; i < len(lines); i++ {
-		// Skip empty lines or comments
-		if lines[i] == "" || strings.HasPrefix(lines[i], "#") {
-			continue
-		}
-
-		row := make(map[string]string)
-		fields := strings.Fields(lines[i])
-
-		// Add each column to the row map
-		for j := 0; j < len(headerFields) && j < len(fields); j++ {
-			row[headerFields[j]] = fields[j]
-		}
-
-		serverStates = append(serverStates, row)
-	}
+	serverStates := parseServersState(result)
 
 	slog.Debug("Successfully retrieved server states", "count", len(serverStates))
 	return serverStates, nil
@@ -333,13 +370,22 @@ func (c *HAProxyClient) ShowServersState(backend string) ([]map[string]string, e
 
 // SetWeight sets a server's weight within a backend.
 func (c *HAProxyClient) SetWeight(backend, server string, weight int) (string, error) {
+	return c.SetWeightWithContext(context.Background(), backend, server, weight)
+}
+
+// SetWeightWithContext sets a server's weight within a backend with context support.
+func (c *HAProxyClient) SetWeightWithContext(ctx context.Context, backend, server string, weight int) (string, error) {
 	slog.Debug("HAProxyClient.SetWeight called", "backend", backend, "server", server, "weight", weight)
 
+	if err := validateServerName(backend, server); err != nil {
+		return "", err
+	}
+
 	// Construct command
 	cmd := fmt.Sprintf("set weight %s/%s %d", backend, server, weight)
 
 	// Execute the command
-	result, err := c.ExecuteRuntimeCommand(cmd)
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to set server weight", "error", err, "backend", backend, "server", server)
 		return "", fmt.Errorf("failed to set weight for server %s/%s: %w", backend, server, err)
@@ -358,13 +404,22 @@ func (c *HAProxyClient) SetWeight(backend, server string, weight int) (string, e
 
 // EnableHealth enables health checks for a server in a backend.
 func (c *HAProxyClient) EnableHealth(backend, server string) error {
+	return c.EnableHealthWithContext(context.Background(), backend, server)
+}
+
+// EnableHealthWithContext enables health checks for a server in a backend with context support.
+func (c *HAProxyClient) EnableHealthWithContext(ctx context.Context, backend, server string) error {
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	slog.Info("Enabling health checks", "backend", backend, "server", server)
 
 	// Construct the command
 	cmd := fmt.Sprintf("enable health %s/%s", backend, server)
 
 	// Execute the command
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to enable health checks", "error", err, "backend", backend, "server", server)
 		return fmt.Errorf("failed to enable health checks for %s/%s: %w", backend, server, err)
@@ -376,13 +431,22 @@ func (c *HAProxyClient) EnableHealth(backend, server string) error {
 
 // DisableHealth disables health checks for a server in a backend.
 func (c *HAProxyClient) DisableHealth(backend, server string) error {
+	return c.DisableHealthWithContext(context.Background(), backend, server)
+}
+
+// DisableHealthWithContext disables health checks for a server in a backend with context support.
+func (c *HAProxyClient) DisableHealthWithContext(ctx context.Context, backend, server string) error {
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	slog.Info("Disabling health checks", "backend", backend, "server", server)
 
 	// Construct the command
 	cmd := fmt.Sprintf("disable health %s/%s", backend, server)
 
 	// Execute the command
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to disable health checks", "error", err, "backend", backend, "server", server)
 		return fmt.Errorf("failed to disable health checks for %s/%s: %w", backend, server, err)
@@ -394,13 +458,22 @@ func (c *HAProxyClient) DisableHealth(backend, server string) error {
 
 // EnableAgent enables agent checks for a server in a backend.
 func (c *HAProxyClient) EnableAgent(backend, server string) error {
+	return c.EnableAgentWithContext(context.Background(), backend, server)
+}
+
+// EnableAgentWithContext enables agent checks for a server in a backend with context support.
+func (c *HAProxyClient) EnableAgentWithContext(ctx context.Context, backend, server string) error {
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	slog.Info("Enabling agent checks", "backend", backend, "server", server)
 
 	// Construct the command
 	cmd := fmt.Sprintf("enable agent %s/%s", backend, server)
 
 	// Execute the command
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to enable agent checks", "error", err, "backend", backend, "server", server)
 		return fmt.Errorf("failed to enable agent checks for %s/%s: %w", backend, server, err)
@@ -412,13 +485,22 @@ func (c *HAProxyClient) EnableAgent(backend, server string) error {
 
 // DisableAgent disables agent checks for a server in a backend.
 func (c *HAProxyClient) DisableAgent(backend, server string) error {
+	return c.DisableAgentWithContext(context.Background(), backend, server)
+}
+
+// DisableAgentWithContext disables agent checks for a server in a backend with context support.
+func (c *HAProxyClient) DisableAgentWithContext(ctx context.Context, backend, server string) error {
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	slog.Info("Disabling agent checks", "backend", backend, "server", server)
 
 	// Construct the command
 	cmd := fmt.Sprintf("disable agent %s/%s", backend, server)
 
 	// Execute the command
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to disable agent checks", "error", err, "backend", backend, "server", server)
 		return fmt.Errorf("failed to disable agent checks for %s/%s: %w", backend, server, err)
@@ -434,10 +516,15 @@ func (c *HAProxyClient) DisableAgent(backend, server string) error {
 
 // ReloadHAProxy triggers a configuration reload.
 func (c *HAProxyClient) ReloadHAProxy() error {
+	return c.ReloadHAProxyWithContext(context.Background())
+}
+
+// ReloadHAProxyWithContext triggers a configuration reload with context support.
+func (c *HAProxyClient) ReloadHAProxyWithContext(ctx context.Context) error {
 	slog.Info("Reloading HAProxy configuration")
 
 	// Execute the reload command directly
-	result, err := c.ExecuteRuntimeCommand("reload")
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "reload")
 	if err != nil {
 		slog.Error("Failed to reload HAProxy", "error", err)
 		return fmt.Errorf("failed to reload HAProxy: %w", err)
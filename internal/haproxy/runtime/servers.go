@@ -1,64 +1,37 @@
 package haproxy
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
-	"strings"
 )
 
 // ListServers retrieves a list of servers for a specific backend.
 func (c *HAProxyClient) ListServers(backend string) ([]string, error) {
+	return c.ListServersWithContext(context.Background(), backend)
+}
+
+// ListServersWithContext retrieves a list of servers for a specific backend with context support.
+func (c *HAProxyClient) ListServersWithContext(ctx context.Context, backend string) ([]string, error) {
 	slog.Debug("HAProxyClient.ListServers called", "backend", backend)
 
+	if err := ValidateName("backend", backend); err != nil {
+		return nil, err
+	}
+
 	// Use direct command to get server state
 	cmd := fmt.Sprintf("show servers state %s", backend)
-	result, err := c.ExecuteRuntimeCommand(cmd)
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to list servers", "backend", backend, "error", err)
 		return nil, fmt.Errorf("failed to list servers for backend %s: %w", backend, err)
 	}
 
-	// Parse the output to extract server names
-	lines := strings.Split(strings.TrimSpace(result), "\n")
-	if len(lines) < 2 {
-		// Return empty list if not enough lines (need header + data)
-		return []string{}, nil
-	}
-
-	// Find the server name column index (assuming second line contains column headers)
-	headerLine := 1
-	if strings.HasPrefix(lines[0], "#") {
-		headerLine = 1
-	}
-
-	if headerLine >= len(lines) {
-		return []string{}, nil
-	}
-
-	headers := strings.Fields(lines[headerLine])
-	nameIndex := -1
-	for i, header := range headers {
-		if header == "srv_name" {
-			nameIndex = i
-			break
-		}
-	}
-
-	if nameIndex == -1 {
-		slog.Error("Failed to find server name column", "backend", backend)
-		return nil, fmt.Errorf("failed to find server name column for backend %s", backend)
-	}
-
 	// Extract server names
 	serverNames := make([]string, 0)
-	for i := headerLine + 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == "" || strings.HasPrefix(lines[i], "#") {
-			continue
-		}
-
-		fields := strings.Fields(lines[i])
-		if nameIndex < len(fields) {
-			serverNames = append(serverNames, fields[nameIndex])
+	for _, row := range parseServersState(result) {
+		if name := row["srv_name"]; name != "" {
+			serverNames = append(serverNames, name)
 		}
 	}
 
@@ -68,11 +41,20 @@ func (c *HAProxyClient) ListServers(backend string) ([]string, error) {
 
 // GetServerDetails retrieves detailed information about a specific server.
 func (c *HAProxyClient) GetServerDetails(backend, server string) (map[string]interface{}, error) {
+	return c.GetServerDetailsWithContext(context.Background(), backend, server)
+}
+
+// GetServerDetailsWithContext retrieves detailed information about a specific server with context support.
+func (c *HAProxyClient) GetServerDetailsWithContext(ctx context.Context, backend, server string) (map[string]interface{}, error) {
 	slog.Debug("HAProxyClient.GetServerDetails called", "backend", backend, "server", server)
 
+	if err := validateServerName(backend, server); err != nil {
+		return nil, err
+	}
+
 	// Get server state from direct command
-	stateCmd := fmt.Sprintf("show servers state %s %s", backend, server)
-	stateOutput, err := c.ExecuteRuntimeCommand(stateCmd)
+	stateCmd := fmt.Sprintf("show servers state %s", backend)
+	stateOutput, err := c.ExecuteRuntimeCommandWithContext(ctx, stateCmd)
 	if err != nil {
 		slog.Error("Failed to get server state", "backend", backend, "server", server, "error", err)
 		return nil, fmt.Errorf("failed to get server state for %s/%s: %w", backend, server, err)
@@ -84,40 +66,18 @@ func (c *HAProxyClient) GetServerDetails(backend, server string) (map[string]int
 		"backend": backend,
 	}
 
-	// Parse server state output
-	lines := strings.Split(strings.TrimSpace(stateOutput), "\n")
-	if len(lines) >= 3 { // Need at least comment, header, and data line
-		// Find the header line
-		headerLine := 1
-		if strings.HasPrefix(lines[0], "#") {
-			headerLine = 1
-		}
-
-		// Get headers and data
-		headers := strings.Fields(lines[headerLine])
-		dataLine := headerLine + 1
-
-		if dataLine < len(lines) {
-			data := strings.Fields(lines[dataLine])
-
-			// Map headers to data
-			for i := 0; i < len(headers) && i < len(data); i++ {
-				details[headers[i]] = data[i]
-
-				// Special handling for common fields
-				switch headers[i] {
-				case "srv_addr":
-					details["address"] = data[i]
-				case "srv_op_state":
-					details["status"] = data[i]
-				}
-			}
+	// Pick the requested server from the backend's server states
+	if state, ok := findServerState(parseServersState(stateOutput), server); ok {
+		for key, value := range state {
+			details[key] = value
 		}
+		details["address"] = state["srv_addr"]
+		details["status"] = state["srv_op_state"]
 	}
 
 	// Get additional stats from stats command if available
 	statsCmd := fmt.Sprintf("show stat %s %s", backend, server)
-	statsOutput, err := c.ExecuteRuntimeCommand(statsCmd)
+	statsOutput, err := c.ExecuteRuntimeCommandWithContext(ctx, statsCmd)
 	if err == nil && len(statsOutput) > 0 {
 		// Parse stats output for additional details
 		_, statsData, parseErr := parseCSVStats(statsOutput)
@@ -137,11 +97,20 @@ func (c *HAProxyClient) GetServerDetails(backend, server string) (map[string]int
 
 // EnableServer enables a server in a backend.
 func (c *HAProxyClient) EnableServer(backend, server string) error {
+	return c.EnableServerWithContext(context.Background(), backend, server)
+}
+
+// EnableServerWithContext enables a server in a backend with context support.
+func (c *HAProxyClient) EnableServerWithContext(ctx context.Context, backend, server string) error {
 	slog.Debug("Enabling server", "backend", backend, "server", server)
 
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	// Use direct command
 	cmd := fmt.Sprintf("set server %s/%s state ready", backend, server)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to enable server", "backend", backend, "server", server, "error", err)
 		return fmt.Errorf("failed to enable server %s/%s: %w", backend, server, err)
@@ -153,11 +122,20 @@ func (c *HAProxyClient) EnableServer(backend, server string) error {
 
 // DisableServer disables a server in a backend.
 func (c *HAProxyClient) DisableServer(backend, server string) error {
+	return c.DisableServerWithContext(context.Background(), backend, server)
+}
+
+// DisableServerWithContext disables a server in a backend with context support.
+func (c *HAProxyClient) DisableServerWithContext(ctx context.Context, backend, server string) error {
 	slog.Debug("Disabling server", "backend", backend, "server", server)
 
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	// Use direct command
 	cmd := fmt.Sprintf("set server %s/%s state maint", backend, server)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to disable server", "backend", backend, "server", server, "error", err)
 		return fmt.Errorf("failed to disable server %s/%s: %w", backend, server, err)
@@ -169,8 +147,17 @@ func (c *HAProxyClient) DisableServer(backend, server string) error {
 
 // SetServerWeight sets the weight of a server in a backend.
 func (c *HAProxyClient) SetServerWeight(backend, server string, weight int) error {
+	return c.SetServerWeightWithContext(context.Background(), backend, server, weight)
+}
+
+// SetServerWeightWithContext sets the weight of a server in a backend with context support.
+func (c *HAProxyClient) SetServerWeightWithContext(ctx context.Context, backend, server string, weight int) error {
 	slog.Debug("Setting server weight", "backend", backend, "server", server, "weight", weight)
 
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	// Validate weight
 	if weight < 0 || weight > 256 {
 		return fmt.Errorf("invalid weight %d (must be between 0 and 256)", weight)
@@ -178,7 +165,7 @@ func (c *HAProxyClient) SetServerWeight(backend, server string, weight int) erro
 
 	// Use direct command
 	cmd := fmt.Sprintf("set server %s/%s weight %d", backend, server, weight)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to set server weight", "backend", backend, "server", server, "weight", weight, "error", err)
 		return fmt.Errorf("failed to set weight for server %s/%s: %w", backend, server, err)
@@ -190,8 +177,17 @@ func (c *HAProxyClient) SetServerWeight(backend, server string, weight int) erro
 
 // SetServerMaxconn sets the maximum connections for a server.
 func (c *HAProxyClient) SetServerMaxconn(backend, server string, maxconn int) error {
+	return c.SetServerMaxconnWithContext(context.Background(), backend, server, maxconn)
+}
+
+// SetServerMaxconnWithContext sets the maximum connections for a server with context support.
+func (c *HAProxyClient) SetServerMaxconnWithContext(ctx context.Context, backend, server string, maxconn int) error {
 	slog.Debug("Setting server maxconn", "backend", backend, "server", server, "maxconn", maxconn)
 
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	// Validate maxconn
 	if maxconn < 0 {
 		return fmt.Errorf("invalid maxconn %d (must be >= 0)", maxconn)
@@ -199,7 +195,7 @@ func (c *HAProxyClient) SetServerMaxconn(backend, server string, maxconn int) er
 
 	// Execute the set maxconn command
 	cmd := fmt.Sprintf("set maxconn server %s/%s %d", backend, server, maxconn)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to set server maxconn", "backend", backend, "server", server, "maxconn", maxconn, "error", err)
 		return fmt.Errorf("failed to set maxconn for server %s/%s: %w", backend, server, err)
@@ -209,13 +205,73 @@ func (c *HAProxyClient) SetServerMaxconn(backend, server string, maxconn int) er
 	return nil
 }
 
+// ShutdownSession immediately terminates a session by its ID, as listed by 'show sess'.
+func (c *HAProxyClient) ShutdownSession(id string) error {
+	return c.ShutdownSessionWithContext(context.Background(), id)
+}
+
+// ShutdownSessionWithContext terminates a session with context support.
+func (c *HAProxyClient) ShutdownSessionWithContext(ctx context.Context, id string) error {
+	slog.Debug("Shutting down session", "id", id)
+
+	if id == "" {
+		return fmt.Errorf("session ID cannot be empty")
+	}
+	if err := ValidateName("session ID", id); err != nil {
+		return err
+	}
+
+	cmd := fmt.Sprintf("shutdown session %s", id)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
+	if err != nil {
+		slog.Error("Failed to shut down session", "id", id, "error", err)
+		return fmt.Errorf("failed to shut down session %s: %w", id, err)
+	}
+
+	slog.Debug("Successfully shut down session", "id", id)
+	return nil
+}
+
+// ShutdownServerSessions immediately terminates every session attached to a server.
+func (c *HAProxyClient) ShutdownServerSessions(backend, server string) error {
+	return c.ShutdownServerSessionsWithContext(context.Background(), backend, server)
+}
+
+// ShutdownServerSessionsWithContext terminates every session of a server with context support.
+func (c *HAProxyClient) ShutdownServerSessionsWithContext(ctx context.Context, backend, server string) error {
+	slog.Debug("Shutting down server sessions", "backend", backend, "server", server)
+
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
+	cmd := fmt.Sprintf("shutdown sessions server %s/%s", backend, server)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
+	if err != nil {
+		slog.Error("Failed to shut down server sessions", "backend", backend, "server", server, "error", err)
+		return fmt.Errorf("failed to shut down sessions of server %s/%s: %w", backend, server, err)
+	}
+
+	slog.Debug("Successfully shut down server sessions", "backend", backend, "server", server)
+	return nil
+}
+
 // GetServerState retrieves the state of a server in a backend.
 func (c *HAProxyClient) GetServerState(backend, server string) (string, error) {
+	return c.GetServerStateWithContext(context.Background(), backend, server)
+}
+
+// GetServerStateWithContext retrieves the state of a server in a backend with context support.
+func (c *HAProxyClient) GetServerStateWithContext(ctx context.Context, backend, server string) (string, error) {
 	slog.Debug("Getting server state", "backend", backend, "server", server)
 
+	if err := validateServerName(backend, server); err != nil {
+		return "", err
+	}
+
 	// Use direct command
-	cmd := fmt.Sprintf("show servers state %s %s", backend, server)
-	result, err := c.ExecuteRuntimeCommand(cmd)
+	cmd := fmt.Sprintf("show servers state %s", backend)
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		// Check if this is a structured HAProxy error
 		if haErr, ok := err.(HAProxyError); ok {
@@ -230,46 +286,16 @@ func (c *HAProxyClient) GetServerState(backend, server string) (string, error) {
 		return "", fmt.Errorf("failed to get server state for %s/%s: %w", backend, server, err)
 	}
 
-	// Parse the output to find operational state
-	lines := strings.Split(strings.TrimSpace(result), "\n")
-	if len(lines) < 3 { // Need at least comment, header, and data line
-		return "", fmt.Errorf("insufficient data in server state output for %s/%s", backend, server)
-	}
-
-	// Find the header line
-	headerLine := 1
-	if strings.HasPrefix(lines[0], "#") {
-		headerLine = 1
+	// Find the requested server and its operational state
+	row, ok := findServerState(parseServersState(result), server)
+	if !ok {
+		return "", fmt.Errorf("server %s not found in backend %s", server, backend)
 	}
 
-	// Get headers and data
-	headers := strings.Fields(lines[headerLine])
-	dataLine := headerLine + 1
-
-	if dataLine >= len(lines) {
-		return "", fmt.Errorf("missing data line in server state output for %s/%s", backend, server)
-	}
-
-	// Find the srv_op_state column
-	stateIdx := -1
-	for i, h := range headers {
-		if h == "srv_op_state" {
-			stateIdx = i
-			break
-		}
-	}
-
-	if stateIdx == -1 {
-		return "", fmt.Errorf("srv_op_state column not found in server state output for %s/%s", backend, server)
-	}
-
-	// Extract state value
-	data := strings.Fields(lines[dataLine])
-	if stateIdx >= len(data) {
+	state, ok := row["srv_op_state"]
+	if !ok {
 		return "", fmt.Errorf("srv_op_state value not found in server state output for %s/%s", backend, server)
 	}
-
-	state := data[stateIdx]
 	slog.Debug("Successfully got server state", "backend", backend, "server", server, "state", state)
 
 	return state, nil
@@ -277,81 +303,37 @@ func (c *HAProxyClient) GetServerState(backend, server string) (string, error) {
 
 // GetServersState retrieves the state of all servers in a backend.
 func (c *HAProxyClient) GetServersState(backend string) ([]map[string]string, error) {
+	return c.GetServersStateWithContext(context.Background(), backend)
+}
+
+// GetServersStateWithContext retrieves the state of all servers in a backend with context support.
+func (c *HAProxyClient) GetServersStateWithContext(ctx context.Context, backend string) ([]map[string]string, error) {
 	slog.Debug("Getting servers state", "backend", backend)
 
+	if err := ValidateName("backend", backend); err != nil {
+		return nil, err
+	}
+
 	// Use direct command
 	cmd := fmt.Sprintf("show servers state %s", backend)
-	output, err := c.ExecuteRuntimeCommand(cmd)
+	output, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to get servers state", "backend", backend, "error", err)
 		return nil, fmt.Errorf("failed to get servers state for backend %s: %w", backend, err)
 	}
 
-	// Parse the output
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	if len(lines) < 2 { // Need at least header and data line
-		return []map[string]string{}, nil
-	}
-
-	// Find the header line
-	headerLine := 1
-	if strings.HasPrefix(lines[0], "#") {
-		headerLine = 1
-	}
-
-	if headerLine >= len(lines) {
-		return []map[string]string{}, nil
-	}
-
-	// Get headers
-	headers := strings.Fields(lines[headerLine])
-
-	// Convert to a more generic format
+	// Add standard field names alongside the raw columns
 	servers := make([]map[string]string, 0)
-	for i := headerLine + 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == "" || strings.HasPrefix(lines[i], "#") {
+	for _, serverMap := range parseServersState(output) {
+		name, hasName := serverMap["srv_name"]
+		if !hasName {
 			continue
 		}
-
-		fields := strings.Fields(lines[i])
-		serverMap := make(map[string]string)
-
-		// Map fields to headers
-		for j := 0; j < len(headers) && j < len(fields); j++ {
-			serverMap[headers[j]] = fields[j]
-		}
-
-		// Add standard fields if present
-		for _, key := range headers {
-			if key == "be_name" || key == "srv_name" || key == "srv_addr" || key == "srv_op_state" {
-				idx := -1
-				for i, h := range headers {
-					if h == key {
-						idx = i
-						break
-					}
-				}
-
-				if idx >= 0 && idx < len(fields) {
-					// Map to standard names
-					switch key {
-					case "be_name":
-						serverMap["backend"] = fields[idx]
-					case "srv_name":
-						serverMap["name"] = fields[idx]
-					case "srv_addr":
-						serverMap["address"] = fields[idx]
-					case "srv_op_state":
-						serverMap["state"] = fields[idx]
-					}
-				}
-			}
-		}
-
-		// Add to result if it has name and backend
-		if _, hasName := serverMap["name"]; hasName {
-			servers = append(servers, serverMap)
-		}
+		serverMap["name"] = name
+		serverMap["backend"] = serverMap["be_name"]
+		serverMap["address"] = serverMap["srv_addr"]
+		serverMap["state"] = serverMap["srv_op_state"]
+		servers = append(servers, serverMap)
 	}
 
 	slog.Debug("Successfully got servers state", "backend", backend, "count", len(servers))
@@ -360,11 +342,20 @@ func (c *HAProxyClient) GetServersState(backend string) ([]map[string]string, er
 
 // EnableAgentCheck enables the agent check for a server.
 func (c *HAProxyClient) EnableAgentCheck(backend, server string) error {
+	return c.EnableAgentCheckWithContext(context.Background(), backend, server)
+}
+
+// EnableAgentCheckWithContext enables the agent check for a server with context support.
+func (c *HAProxyClient) EnableAgentCheckWithContext(ctx context.Context, backend, server string) error {
 	slog.Debug("Enabling agent check", "backend", backend, "server", server)
 
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	// Use direct command
 	cmd := fmt.Sprintf("enable agent %s/%s", backend, server)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to enable agent check", "backend", backend, "server", server, "error", err)
 		return fmt.Errorf("failed to enable agent check for %s/%s: %w", backend, server, err)
@@ -376,11 +367,20 @@ func (c *HAProxyClient) EnableAgentCheck(backend, server string) error {
 
 // DisableAgentCheck disables the agent check for a server.
 func (c *HAProxyClient) DisableAgentCheck(backend, server string) error {
+	return c.DisableAgentCheckWithContext(context.Background(), backend, server)
+}
+
+// DisableAgentCheckWithContext disables the agent check for a server with context support.
+func (c *HAProxyClient) DisableAgentCheckWithContext(ctx context.Context, backend, server string) error {
 	slog.Debug("Disabling agent check", "backend", backend, "server", server)
 
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	// Use direct command
 	cmd := fmt.Sprintf("disable agent %s/%s", backend, server)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to disable agent check", "backend", backend, "server", server, "error", err)
 		return fmt.Errorf("failed to disable agent check for %s/%s: %w", backend, server, err)
@@ -392,11 +392,20 @@ func (c *HAProxyClient) DisableAgentCheck(backend, server string) error {
 
 // EnableHealthCheck enables the health check for a server.
 func (c *HAProxyClient) EnableHealthCheck(backend, server string) error {
+	return c.EnableHealthCheckWithContext(context.Background(), backend, server)
+}
+
+// EnableHealthCheckWithContext enables the health check for a server with context support.
+func (c *HAProxyClient) EnableHealthCheckWithContext(ctx context.Context, backend, server string) error {
 	slog.Debug("Enabling health check", "backend", backend, "server", server)
 
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	// Use direct command
 	cmd := fmt.Sprintf("enable health %s/%s", backend, server)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to enable health check", "backend", backend, "server", server, "error", err)
 		return fmt.Errorf("failed to enable health check for %s/%s: %w", backend, server, err)
@@ -408,11 +417,20 @@ func (c *HAProxyClient) EnableHealthCheck(backend, server string) error {
 
 // DisableHealthCheck disables the health check for a server.
 func (c *HAProxyClient) DisableHealthCheck(backend, server string) error {
+	return c.DisableHealthCheckWithContext(context.Background(), backend, server)
+}
+
+// DisableHealthCheckWithContext disables the health check for a server with context support.
+func (c *HAProxyClient) DisableHealthCheckWithContext(ctx context.Context, backend, server string) error {
 	slog.Debug("Disabling health check", "backend", backend, "server", server)
 
+	if err := validateServerName(backend, server); err != nil {
+		return err
+	}
+
 	// Use direct command
 	cmd := fmt.Sprintf("disable health %s/%s", backend, server)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to disable health check", "backend", backend, "server", server, "error", err)
 		return fmt.Errorf("failed to disable health check for %s/%s: %w", backend, server, err)
@@ -424,11 +442,29 @@ func (c *HAProxyClient) DisableHealthCheck(backend, server string) error {
 
 // AddServer adds a new server to a backend.
 func (c *HAProxyClient) AddServer(backend, name, addr string, port, weight int) error {
+	return c.AddServerWithContext(context.Background(), backend, name, addr, port, weight)
+}
+
+// AddServerWithContext adds a new server to a backend with context support.
+func (c *HAProxyClient) AddServerWithContext(ctx context.Context, backend, name, addr string, port, weight int) error {
 	slog.Debug("Adding server", "backend", backend, "server", name, "address", addr, "port", port, "weight", weight)
 
+	if err := validateServerName(backend, name); err != nil {
+		return err
+	}
+	if err := validatePath("address", addr); err != nil {
+		return err
+	}
+
 	// Form the add server command
-	cmd := fmt.Sprintf("add server %s/%s %s:%d weight %d", backend, name, addr, port, weight)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	cmd := fmt.Sprintf("add server %s/%s %s", backend, name, addr)
+	if port > 0 {
+		cmd = fmt.Sprintf("%s:%d", cmd, port)
+	}
+	if weight > 0 {
+		cmd = fmt.Sprintf("%s weight %d", cmd, weight)
+	}
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to add server", "backend", backend, "server", name, "error", err)
 		return fmt.Errorf("failed to add server %s/%s: %w", backend, name, err)
@@ -440,11 +476,20 @@ func (c *HAProxyClient) AddServer(backend, name, addr string, port, weight int)
 
 // DelServer removes a server from a backend.
 func (c *HAProxyClient) DelServer(backend, name string) error {
+	return c.DelServerWithContext(context.Background(), backend, name)
+}
+
+// DelServerWithContext removes a server from a backend with context support.
+func (c *HAProxyClient) DelServerWithContext(ctx context.Context, backend, name string) error {
 	slog.Debug("Deleting server", "backend", backend, "server", name)
 
+	if err := validateServerName(backend, name); err != nil {
+		return err
+	}
+
 	// Form the delete server command
 	cmd := fmt.Sprintf("del server %s/%s", backend, name)
-	_, err := c.ExecuteRuntimeCommand(cmd)
+	_, err := c.ExecuteRuntimeCommandWithContext(ctx, cmd)
 	if err != nil {
 		slog.Error("Failed to delete server", "backend", backend, "server", name, "error", err)
 		return fmt.Errorf("failed to delete server %s/%s: %w", backend, name, err)
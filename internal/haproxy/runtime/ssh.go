@@ -0,0 +1,322 @@
+package haproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// DefaultSSHPort is used when an ssh:// runtime URL does not specify a port
+const DefaultSSHPort = "22"
+
+// SSHOptions configures the SSH tunnel used to reach a remote Runtime API socket
+type SSHOptions struct {
+	User                  string // Remote user; overrides the user in the URL
+	Password              string // Password authentication
+	KeyFile               string // Private key used for public key authentication
+	KeyPassphrase         string // Passphrase protecting KeyFile
+	UseAgent              bool   // Use the agent listening on SSH_AUTH_SOCK
+	KnownHostsFile        string // known_hosts file used to verify the remote host key
+	InsecureIgnoreHostKey bool   // Skip host key verification (testing only)
+}
+
+// sshAgent lists the keys of the agent listening on SSH_AUTH_SOCK. Agent
+// signers sign over the agent connection, so one connection is kept for every
+// handshake and reopened when it fails.
+type sshAgent struct {
+	socket string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	client agent.ExtendedAgent
+}
+
+// connect opens the agent connection unless it is already open. Callers hold a.mu.
+func (a *sshAgent) connect() error {
+	if a.conn != nil {
+		return nil
+	}
+	conn, err := net.Dial("unix", a.socket)
+	if err != nil {
+		return fmt.Errorf("failed to connect to ssh agent: %w", err)
+	}
+	a.conn = conn
+	a.client = agent.NewClient(conn)
+	return nil
+}
+
+// signers implements the ssh.PublicKeysCallback of agent authentication
+func (a *sshAgent) signers() ([]ssh.Signer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.connect(); err != nil {
+		return nil, err
+	}
+	signers, err := a.client.Signers()
+	if err != nil {
+		_ = a.conn.Close()
+		a.conn, a.client = nil, nil
+		return nil, fmt.Errorf("failed to list ssh agent keys: %w", err)
+	}
+	return signers, nil
+}
+
+// Close closes the agent connection
+func (a *sshAgent) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.conn == nil {
+		return nil
+	}
+	err := a.conn.Close()
+	a.conn, a.client = nil, nil
+	return err
+}
+
+// newSSHClientConfig builds an ssh.ClientConfig from the options and the
+// runtime URL. The returned agent, set when opts.UseAgent is, must be closed
+// once the config is no longer used.
+func newSSHClientConfig(opts SSHOptions, user string) (*ssh.ClientConfig, *sshAgent, error) {
+	if opts.User != "" {
+		user = opts.User
+	}
+	if user == "" {
+		return nil, nil, fmt.Errorf("ssh user is required")
+	}
+
+	var authMethods []ssh.AuthMethod
+	var keyAgent *sshAgent
+
+	if opts.KeyFile != "" {
+		key, err := os.ReadFile(opts.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read ssh key file %s: %w", opts.KeyFile, err)
+		}
+
+		var signer ssh.Signer
+		if opts.KeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(opts.KeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(key)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse ssh key file %s: %w", opts.KeyFile, err)
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+
+	if opts.UseAgent {
+		socket := os.Getenv("SSH_AUTH_SOCK")
+		if socket == "" {
+			return nil, nil, fmt.Errorf("ssh agent requested but SSH_AUTH_SOCK is not set")
+		}
+		keyAgent = &sshAgent{socket: socket}
+		authMethods = append(authMethods, ssh.PublicKeysCallback(keyAgent.signers))
+	}
+
+	if opts.Password != "" {
+		authMethods = append(authMethods, ssh.Password(opts.Password))
+	}
+
+	if len(authMethods) == 0 {
+		return nil, nil, fmt.Errorf("no ssh authentication method configured (key file, agent or password)")
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	switch {
+	case opts.KnownHostsFile != "":
+		callback, err := knownhosts.New(opts.KnownHostsFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load known hosts file %s: %w", opts.KnownHostsFile, err)
+		}
+		hostKeyCallback = callback
+	case opts.InsecureIgnoreHostKey:
+		slog.Warn("SSH host key verification is disabled for the Runtime API tunnel")
+		hostKeyCallback = ssh.InsecureIgnoreHostKey() // #nosec G106 -- explicitly requested by configuration
+	default:
+		return nil, nil, fmt.Errorf("ssh host key verification requires a known hosts file")
+	}
+
+	// Connect to the agent last so that no other error leaves the connection open
+	if keyAgent != nil {
+		keyAgent.mu.Lock()
+		err := keyAgent.connect()
+		keyAgent.mu.Unlock()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &ssh.ClientConfig{
+		User:            user,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         10 * time.Second,
+	}, keyAgent, nil
+}
+
+// sshTransport reaches a remote Runtime API Unix socket through an SSH tunnel
+type sshTransport struct {
+	address    string // SSH server host:port
+	socketPath string // Remote admin socket path
+	config     *ssh.ClientConfig
+	agent      *sshAgent // Agent used to authenticate, if any
+
+	mu     sync.Mutex
+	client *ssh.Client // Cached SSH connection, reused across commands
+}
+
+// newSSHTransport builds an SSH transport from an ssh://user@host[:port]/path URL
+func newSSHTransport(u *url.URL, opts SSHOptions) (*sshTransport, error) {
+	if u.Host == "" || u.Path == "" {
+		return nil, fmt.Errorf("ssh runtime API URL must look like ssh://user@host/path/to/admin.sock")
+	}
+
+	config, keyAgent, err := newSSHClientConfig(opts, u.User.Username())
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure SSH for runtime API: %w", err)
+	}
+
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), DefaultSSHPort)
+	}
+
+	return &sshTransport{address: address, socketPath: u.Path, config: config, agent: keyAgent}, nil
+}
+
+// Name implements Transport
+func (t *sshTransport) Name() string { return "ssh" }
+
+// Address implements Transport
+func (t *sshTransport) Address() string { return t.address + t.socketPath }
+
+// sshClient returns the cached SSH connection, establishing it if needed
+func (t *sshTransport) sshClient(ctx context.Context) (*ssh.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client != nil {
+		return t.client, nil
+	}
+
+	slog.Debug("Establishing SSH tunnel to HAProxy host", "address", t.address, "user", t.config.User)
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", t.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ssh host %s: %w", t.address, err)
+	}
+
+	// Bound the handshake by the context deadline or the SSH timeout, whichever is sooner
+	deadline := time.Now().Add(t.config.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to set ssh handshake deadline: %w", err)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, t.address, t.config)
+	if err != nil {
+		_ = conn.Close()
+		err = fmt.Errorf("ssh handshake with %s failed: %w", t.address, err)
+		if isSSHRejection(err) {
+			return nil, permanent(err)
+		}
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		_ = sshConn.Close()
+		return nil, fmt.Errorf("failed to clear ssh handshake deadline: %w", err)
+	}
+
+	client := ssh.NewClient(sshConn, chans, reqs)
+	t.client = client
+
+	// Drop the cached client once the connection goes away so the next command reconnects
+	go func() {
+		_ = client.Wait()
+		t.mu.Lock()
+		if t.client == client {
+			t.client = nil
+		}
+		t.mu.Unlock()
+		slog.Debug("SSH tunnel to HAProxy host closed", "address", t.address)
+	}()
+
+	return client, nil
+}
+
+// Dial implements Transport by opening a stream to the remote Unix socket
+func (t *sshTransport) Dial(ctx context.Context) (net.Conn, error) {
+	client, err := t.sshClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.Dial("unix", t.socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open remote socket %s over ssh: %w", t.socketPath, err)
+	}
+
+	return &sshConn{Conn: conn}, nil
+}
+
+// Close implements Transport by tearing down the cached SSH connection and
+// the agent connection
+func (t *sshTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var err error
+	if t.client != nil {
+		err = t.client.Close()
+		t.client = nil
+	}
+	if t.agent != nil {
+		err = errors.Join(err, t.agent.Close())
+	}
+	return err
+}
+
+// isSSHRejection reports whether a handshake failed because the server
+// rejected every authentication method or its host key did not verify, which
+// retrying cannot fix
+func isSSHRejection(err error) bool {
+	var keyErr *knownhosts.KeyError
+	var revokedErr *knownhosts.RevokedError
+	if errors.As(err, &keyErr) || errors.As(err, &revokedErr) {
+		return true
+	}
+	// x/crypto/ssh has no error type for failed authentication
+	return strings.Contains(err.Error(), "ssh: unable to authenticate")
+}
+
+// sshConn adapts an SSH channel, which has no deadline support, to net.Conn.
+// Cancellation is handled by closing the connection when the context ends.
+type sshConn struct {
+	net.Conn
+}
+
+// SetDeadline is a no-op; cancellation is handled by closing the channel
+func (s *sshConn) SetDeadline(time.Time) error { return nil }
+
+// SetReadDeadline is a no-op; cancellation is handled by closing the channel
+func (s *sshConn) SetReadDeadline(time.Time) error { return nil }
+
+// SetWriteDeadline is a no-op; cancellation is handled by closing the channel
+func (s *sshConn) SetWriteDeadline(time.Time) error { return nil }
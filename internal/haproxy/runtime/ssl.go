@@ -0,0 +1,261 @@
+package haproxy
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// sslCertFields maps the 'show ssl cert <name>' keys to SSLCertInfo fields
+var sslCertFields = map[string]func(info *SSLCertInfo, value string){
+	"Filename":         func(info *SSLCertInfo, value string) { info.Filename = value },
+	"Status":           func(info *SSLCertInfo, value string) { info.Status = value },
+	"Serial":           func(info *SSLCertInfo, value string) { info.Serial = value },
+	"notBefore":        func(info *SSLCertInfo, value string) { info.NotBefore = value },
+	"notAfter":         func(info *SSLCertInfo, value string) { info.NotAfter = value },
+	"Algorithm":        func(info *SSLCertInfo, value string) { info.Algorithm = value },
+	"SHA1 FingerPrint": func(info *SSLCertInfo, value string) { info.SHA1Fingerprint = value },
+	"Subject":          func(info *SSLCertInfo, value string) { info.Subject = value },
+	"Issuer":           func(info *SSLCertInfo, value string) { info.Issuer = value },
+	"Chain Subject":    func(info *SSLCertInfo, value string) { info.ChainSubjects = append(info.ChainSubjects, value) },
+	"Chain Issuer":     func(info *SSLCertInfo, value string) { info.ChainIssuers = append(info.ChainIssuers, value) },
+	"Subject Alternative Name": func(info *SSLCertInfo, value string) {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				info.SubjectAltNames = append(info.SubjectAltNames, name)
+			}
+		}
+	},
+}
+
+// ListSSLCerts lists the certificates loaded by HAProxy and the one with an
+// uncommitted transaction, if any.
+func (c *HAProxyClient) ListSSLCerts() (*SSLCertList, error) {
+	return c.ListSSLCertsWithContext(context.Background())
+}
+
+// ListSSLCertsWithContext lists the certificates loaded by HAProxy with context support.
+func (c *HAProxyClient) ListSSLCertsWithContext(ctx context.Context) (*SSLCertList, error) {
+	slog.Debug("HAProxyClient.ListSSLCerts called")
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show ssl cert")
+	if err != nil {
+		slog.Error("Failed to list SSL certificates", "error", err)
+		return nil, fmt.Errorf("failed to list SSL certificates: %w", err)
+	}
+
+	certificates, transaction := parseSSLList(result)
+	list := &SSLCertList{Certificates: certificates, Transaction: transaction}
+
+	slog.Debug("Successfully listed SSL certificates", "count", len(list.Certificates), "transaction", list.Transaction)
+	return list, nil
+}
+
+// ShowSSLCert returns the details of a certificate. A name prefixed with '*'
+// shows the certificate of the uncommitted transaction instead.
+func (c *HAProxyClient) ShowSSLCert(name string) (*SSLCertInfo, error) {
+	return c.ShowSSLCertWithContext(context.Background(), name)
+}
+
+// ShowSSLCertWithContext returns the details of a certificate with context support.
+func (c *HAProxyClient) ShowSSLCertWithContext(ctx context.Context, name string) (*SSLCertInfo, error) {
+	slog.Debug("HAProxyClient.ShowSSLCert called", "certificate", name)
+
+	if err := validatePath("certificate", name); err != nil {
+		return nil, err
+	}
+
+	if name == "" || name == "*" {
+		return nil, fmt.Errorf("certificate name is required")
+	}
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, "show ssl cert "+name)
+	if err != nil {
+		slog.Error("Failed to show SSL certificate", "certificate", name, "error", err)
+		return nil, fmt.Errorf("failed to show SSL certificate %s: %w", name, err)
+	}
+
+	info, err := parseSSLCert(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to show SSL certificate %s: %w", name, err)
+	}
+	return info, nil
+}
+
+// parseSSLList parses the listing of 'show ssl cert', 'show ssl ca-file' or
+// 'show ssl crl-file': a "# transaction" section with the '*'-prefixed name of
+// the file with an uncommitted transaction, then a "# filename" section.
+// CA and CRL file lines end with " - <n> certificate(s)", which is dropped.
+func parseSSLList(output string) ([]string, string) {
+	names := make([]string, 0)
+	transaction := ""
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, _, _ := strings.Cut(line, " - ")
+		if pending, ok := strings.CutPrefix(name, "*"); ok {
+			transaction = pending
+		} else {
+			names = append(names, name)
+		}
+	}
+	return names, transaction
+}
+
+// parseSSLCert parses the "Key: value" lines of 'show ssl cert <name>'
+func parseSSLCert(output string) (*SSLCertInfo, error) {
+	info := &SSLCertInfo{}
+	parseSSLCertFields(strings.Split(output, "\n"), info)
+	if info.Filename == "" {
+		// Unknown certificates are reported with a plain message
+		return nil, fmt.Errorf("%s", strings.TrimSpace(output))
+	}
+	return info, nil
+}
+
+// parseSSLCertFields sets the fields of info from "Key: value" lines
+func parseSSLCertFields(lines []string, info *SSLCertInfo) {
+	for _, line := range lines {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		if set, known := sslCertFields[key]; known {
+			set(info, strings.TrimSpace(value))
+		}
+	}
+}
+
+// NewSSLCert creates an empty certificate store, to be filled with SetSSLCert
+// and CommitSSLCert before it is referenced by a crt-list.
+func (c *HAProxyClient) NewSSLCert(name string) error {
+	return c.NewSSLCertWithContext(context.Background(), name)
+}
+
+// NewSSLCertWithContext creates an empty certificate store with context support.
+func (c *HAProxyClient) NewSSLCertWithContext(ctx context.Context, name string) error {
+	return c.sslCommand(ctx, "new ssl cert "+name, name, "create SSL certificate", "New empty certificate store")
+}
+
+// SetSSLCert stages a PEM payload (certificate, chain and optionally the key)
+// for a certificate in a transaction. The change takes effect on CommitSSLCert.
+func (c *HAProxyClient) SetSSLCert(name, payload string) error {
+	return c.SetSSLCertWithContext(context.Background(), name, payload)
+}
+
+// SetSSLCertWithContext stages a PEM payload for a certificate with context support.
+func (c *HAProxyClient) SetSSLCertWithContext(ctx context.Context, name, payload string) error {
+	payload, err := pemPayload(payload, "CERTIFICATE", func(der []byte) error {
+		_, err := x509.ParseCertificate(der)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set SSL certificate %s: %w", name, err)
+	}
+	return c.sslCommand(ctx, fmt.Sprintf("set ssl cert %s <<\n%s\n", name, payload), name, "set SSL certificate", "Transaction ")
+}
+
+// CommitSSLCert applies the transaction of a certificate to the running process.
+func (c *HAProxyClient) CommitSSLCert(name string) error {
+	return c.CommitSSLCertWithContext(context.Background(), name)
+}
+
+// CommitSSLCertWithContext applies the transaction of a certificate with context support.
+func (c *HAProxyClient) CommitSSLCertWithContext(ctx context.Context, name string) error {
+	return c.sslCommand(ctx, "commit ssl cert "+name, name, "commit SSL certificate", "Success!")
+}
+
+// AbortSSLCert discards the transaction of a certificate.
+func (c *HAProxyClient) AbortSSLCert(name string) error {
+	return c.AbortSSLCertWithContext(context.Background(), name)
+}
+
+// AbortSSLCertWithContext discards the transaction of a certificate with context support.
+func (c *HAProxyClient) AbortSSLCertWithContext(ctx context.Context, name string) error {
+	return c.sslCommand(ctx, "abort ssl cert "+name, name, "abort SSL certificate", "Transaction aborted")
+}
+
+// DelSSLCert deletes a certificate that is no longer used by any crt-list.
+func (c *HAProxyClient) DelSSLCert(name string) error {
+	return c.DelSSLCertWithContext(context.Background(), name)
+}
+
+// DelSSLCertWithContext deletes a certificate with context support.
+func (c *HAProxyClient) DelSSLCertWithContext(ctx context.Context, name string) error {
+	return c.sslCommand(ctx, "del ssl cert "+name, name, "delete SSL certificate", "Certificate '"+name+"' deleted")
+}
+
+// sslCommand runs an SSL certificate or crt-list command on target. HAProxy
+// reports these failures as plain text, so a line of the response must start
+// with success for the command to count as successful.
+func (c *HAProxyClient) sslCommand(ctx context.Context, command, target, action, success string) error {
+	slog.Debug("Running SSL command", "action", action, "target", target)
+
+	if target == "" {
+		return fmt.Errorf("%s: name is required", action)
+	}
+	if err := validatePath("name", target); err != nil {
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+
+	result, err := c.ExecuteRuntimeCommandWithContext(ctx, command)
+	if err == nil && !hasLinePrefix(result, success) {
+		err = fmt.Errorf("%s", strings.TrimSpace(result))
+	}
+	if err != nil {
+		slog.Error("Failed to "+action, "target", target, "error", err)
+		return fmt.Errorf("failed to %s %s: %w", action, target, err)
+	}
+
+	slog.Debug("Successfully ran SSL command", "action", action, "target", target)
+	return nil
+}
+
+// hasLinePrefix reports whether any line of output starts with prefix, ignoring case
+func hasLinePrefix(output, prefix string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); len(line) >= len(prefix) && strings.EqualFold(line[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// pemPayload checks that payload holds at least one valid PEM block of
+// blockType, as checked by parse, and normalizes it for the Runtime API, where
+// an empty line ends the payload
+func pemPayload(payload, blockType string, parse func(der []byte) error) (string, error) {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(payload, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimRight(line, " \t"))
+		}
+	}
+	payload = strings.Join(lines, "\n")
+
+	blocks := 0
+	rest := []byte(payload)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != blockType {
+			continue
+		}
+		if err := parse(block.Bytes); err != nil {
+			return "", fmt.Errorf("invalid %s in payload: %w", strings.ToLower(blockType), err)
+		}
+		blocks++
+	}
+	if blocks == 0 {
+		return "", fmt.Errorf("payload contains no PEM %s", strings.ToLower(blockType))
+	}
+	return payload, nil
+}
@@ -0,0 +1,114 @@
+package haproxy
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy/common"
+)
+
+// Transport opens connections to the HAProxy Runtime API.
+// Each command uses its own connection, matching HAProxy's non-interactive mode.
+type Transport interface {
+	// Dial opens a new connection, honouring ctx for cancellation and deadlines.
+	Dial(ctx context.Context) (net.Conn, error)
+	// Name returns the transport kind ("unix", "tcp", "tls" or "ssh").
+	Name() string
+	// Address returns the endpoint used in logs and errors.
+	Address() string
+	// Close releases resources held across commands, such as SSH sessions.
+	Close() error
+}
+
+// newTransport builds the transport matching the runtime API URL scheme
+func newTransport(u *url.URL, opts ClientOptions) (Transport, error) {
+	switch u.Scheme {
+	case "unix":
+		if u.Path == "" {
+			return nil, fmt.Errorf("unix runtime API URL requires a socket path")
+		}
+		return &netTransport{network: "unix", address: u.Path}, nil
+	case "tcp":
+		if u.Host == "" {
+			return nil, fmt.Errorf("tcp runtime API URL requires a host and port")
+		}
+		return &netTransport{network: "tcp", address: u.Host}, nil
+	case "tls":
+		return newTLSTransport(u, opts)
+	case "ssh":
+		return newSSHTransport(u, opts.SSH)
+	default:
+		return nil, fmt.Errorf("unsupported URL scheme: %s", u.Scheme)
+	}
+}
+
+// netTransport dials plain Unix or TCP sockets
+type netTransport struct {
+	network string
+	address string
+}
+
+// Dial implements Transport
+func (t *netTransport) Dial(ctx context.Context) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, t.network, t.address)
+}
+
+// Name implements Transport
+func (t *netTransport) Name() string { return t.network }
+
+// Address implements Transport
+func (t *netTransport) Address() string { return t.address }
+
+// Close implements Transport
+func (t *netTransport) Close() error { return nil }
+
+// tlsTransport dials the Runtime API over TLS
+type tlsTransport struct {
+	address string
+	config  *tls.Config
+}
+
+// newTLSTransport builds a TLS transport from the URL and TLS options
+func newTLSTransport(u *url.URL, opts ClientOptions) (*tlsTransport, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("tls runtime API URL requires a host and port")
+	}
+
+	config, err := common.NewTLSConfig(opts.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS for runtime API: %w", err)
+	}
+	if config.ServerName == "" {
+		config.ServerName = u.Hostname()
+	}
+
+	return &tlsTransport{address: u.Host, config: config}, nil
+}
+
+// Dial implements Transport
+func (t *tlsTransport) Dial(ctx context.Context) (net.Conn, error) {
+	d := tls.Dialer{Config: t.config}
+	conn, err := d.DialContext(ctx, "tcp", t.address)
+	if err != nil {
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			return nil, permanent(err)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Name implements Transport
+func (t *tlsTransport) Name() string { return "tls" }
+
+// Address implements Transport
+func (t *tlsTransport) Address() string { return t.address }
+
+// Close implements Transport
+func (t *tlsTransport) Close() error { return nil }
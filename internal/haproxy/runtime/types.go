@@ -3,6 +3,10 @@ package haproxy
 import (
 	"fmt"
 	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy/common"
 )
 
 // Server states
@@ -34,12 +38,26 @@ const (
 	ClientModeDirect
 )
 
+// ClientOptions configures transports, retries and timeouts for the Runtime API.
+type ClientOptions struct {
+	TLS       common.TLSOptions // Used by tls:// URLs
+	SSH       SSHOptions        // Used by ssh:// URLs
+	Retry     RetryPolicy       // Connection retry policy; DefaultRetryPolicy when empty
+	Timeout   time.Duration     // Per-command timeout when the context has no deadline
+	Transport Transport         // Overrides the transport derived from the URL (mainly for tests)
+}
+
 // HAProxyClient provides methods for interacting with HAProxy's Runtime API.
 type HAProxyClient struct {
 	RuntimeAPIURL    string
 	ConfigurationURL string
 	ParsedURL        *url.URL
 	Mode             HAProxyClientMode
+
+	transport    Transport                    // Connection factory selected from the URL scheme
+	retry        RetryPolicy                  // Policy for connection attempts
+	timeout      time.Duration                // Default per-command timeout
+	capabilities atomic.Pointer[Capabilities] // Commands of the connected HAProxy, once probed
 }
 
 // BackendInfo represents detailed information about a backend.
@@ -64,6 +82,271 @@ type ServerInfo struct {
 	ActiveConnections int    `json:"active_connections"` // Current active connections
 }
 
+// MapEntry represents a single entry of an HAProxy map.
+type MapEntry struct {
+	ID    string `json:"id"`    // Entry reference, usable with 'del map' and 'set map'
+	Key   string `json:"key"`   // Matched key
+	Value string `json:"value"` // Associated value
+}
+
+// MapInfo describes a map loaded by HAProxy, as listed by 'show map'.
+type MapInfo struct {
+	ID          string `json:"id"`          // Numeric map ID, usable as a map identifier
+	File        string `json:"file"`        // File the map was loaded from
+	Description string `json:"description"` // Where the map is used
+}
+
+// ErrorCapture is the last invalid request or response captured by a proxy,
+// as reported by 'show errors'.
+type ErrorCapture struct {
+	Timestamp     string `json:"timestamp"`
+	Proxy         string `json:"proxy"` // Proxy that captured the error
+	ProxyID       int    `json:"proxy_id"`
+	Direction     string `json:"direction"` // request or response
+	Frontend      string `json:"frontend,omitempty"`
+	Backend       string `json:"backend,omitempty"`
+	Server        string `json:"server,omitempty"`
+	Event         int    `json:"event"`            // Capture number, counting every error of the proxy
+	Source        string `json:"source,omitempty"` // Client address
+	Length        int    `json:"length"`
+	ErrorPosition int    `json:"error_position"` // Offset of the first invalid byte in the message
+	Excerpt       string `json:"excerpt"`        // Decoded message around the error
+	ExcerptOffset int    `json:"excerpt_offset"` // Offset of the excerpt in the message
+}
+
+// SSLCertList lists the certificates loaded by HAProxy, as reported by 'show ssl cert'.
+type SSLCertList struct {
+	Certificates []string `json:"certificates"`          // Certificate file names
+	Transaction  string   `json:"transaction,omitempty"` // Certificate with an uncommitted transaction
+}
+
+// SSLCertInfo describes a certificate, as reported by 'show ssl cert <name>'.
+type SSLCertInfo struct {
+	Filename        string   `json:"filename"`
+	Status          string   `json:"status,omitempty"` // e.g. Used, Unused or Uncommitted
+	Serial          string   `json:"serial,omitempty"`
+	NotBefore       string   `json:"not_before,omitempty"`
+	NotAfter        string   `json:"not_after,omitempty"`
+	SubjectAltNames []string `json:"subject_alt_names,omitempty"` // e.g. DNS:example.com
+	Algorithm       string   `json:"algorithm,omitempty"`
+	SHA1Fingerprint string   `json:"sha1_fingerprint,omitempty"`
+	Subject         string   `json:"subject,omitempty"`
+	Issuer          string   `json:"issuer,omitempty"`
+	ChainSubjects   []string `json:"chain_subjects,omitempty"` // Subjects of the intermediate certificates
+	ChainIssuers    []string `json:"chain_issuers,omitempty"`
+}
+
+// SSLFileList lists the CA or CRL files loaded by HAProxy, as reported by
+// 'show ssl ca-file' or 'show ssl crl-file'.
+type SSLFileList struct {
+	Files       []string `json:"files"`                 // File names
+	Transaction string   `json:"transaction,omitempty"` // File with an uncommitted transaction
+}
+
+// CAFileInfo describes a CA file, as reported by 'show ssl ca-file <name>'.
+type CAFileInfo struct {
+	Filename     string        `json:"filename"`
+	Status       string        `json:"status,omitempty"` // e.g. Used, Unused or Uncommitted
+	Certificates []SSLCertInfo `json:"certificates"`     // Named "<file>:<index>"
+}
+
+// CRLFileInfo describes a CRL file, as reported by 'show ssl crl-file <name>'.
+type CRLFileInfo struct {
+	Filename string    `json:"filename"`
+	Status   string    `json:"status,omitempty"`
+	CRLs     []CRLInfo `json:"crls"`
+}
+
+// CRLInfo is a certificate revocation list of a CRL file.
+type CRLInfo struct {
+	Version            string        `json:"version,omitempty"`
+	SignatureAlgorithm string        `json:"signature_algorithm,omitempty"`
+	Issuer             string        `json:"issuer,omitempty"`
+	LastUpdate         string        `json:"last_update,omitempty"`
+	NextUpdate         string        `json:"next_update,omitempty"` // NONE when unset
+	Revoked            []RevokedCert `json:"revoked"`
+}
+
+// RevokedCert is a certificate revoked by a CRL.
+type RevokedCert struct {
+	Serial         string `json:"serial"`
+	RevocationDate string `json:"revocation_date,omitempty"`
+}
+
+// OCSPResponseID identifies an OCSP response stapled by HAProxy, as listed by
+// 'show ssl ocsp-response'.
+type OCSPResponseID struct {
+	ID             string `json:"id"`                    // Certificate ID key
+	Certificate    string `json:"certificate,omitempty"` // Path of the certificate the response belongs to
+	IssuerNameHash string `json:"issuer_name_hash,omitempty"`
+	IssuerKeyHash  string `json:"issuer_key_hash,omitempty"`
+	SerialNumber   string `json:"serial_number,omitempty"`
+}
+
+// OCSPResponse describes an OCSP response, as reported by 'show ssl ocsp-response <id>'.
+type OCSPResponse struct {
+	ID             string `json:"id"`
+	ResponseStatus string `json:"response_status"` // e.g. successful (0x0)
+	ResponderID    string `json:"responder_id,omitempty"`
+	ProducedAt     string `json:"produced_at,omitempty"`
+	SerialNumber   string `json:"serial_number,omitempty"`
+	CertStatus     string `json:"cert_status,omitempty"` // good, revoked or unknown
+	RevocationTime string `json:"revocation_time,omitempty"`
+	ThisUpdate     string `json:"this_update,omitempty"`
+	NextUpdate     string `json:"next_update,omitempty"` // Stapling goes stale after this date
+}
+
+// ResolversSection is a resolvers section and the counters of its nameservers,
+// as reported by 'show resolvers'.
+type ResolversSection struct {
+	Name        string            `json:"name"`
+	Nameservers []NameserverStats `json:"nameservers"`
+}
+
+// NameserverStats holds the counters of a nameserver since HAProxy started
+type NameserverStats struct {
+	Name        string `json:"name"`
+	Sent        int64  `json:"sent"`        // Queries sent
+	SendErrors  int64  `json:"snd_error"`   // Queries that could not be sent
+	Valid       int64  `json:"valid"`       // Valid responses
+	Update      int64  `json:"update"`      // Responses that updated a server address
+	CNAME       int64  `json:"cname"`       // CNAME responses
+	CNAMEErrors int64  `json:"cname_error"` // CNAME responses that could not be resolved
+	AnyErrors   int64  `json:"any_err"`     // Empty responses to ANY queries
+	NX          int64  `json:"nx"`          // NXDOMAIN responses
+	Timeouts    int64  `json:"timeout"`     // Queries left unanswered
+	Refused     int64  `json:"refused"`     // REFUSED responses
+	Other       int64  `json:"other"`       // Responses with another error code
+	Invalid     int64  `json:"invalid"`     // Malformed responses
+	TooBig      int64  `json:"too_big"`     // Responses larger than accepted_payload_size
+	Truncated   int64  `json:"truncated"`   // Truncated responses
+	Outdated    int64  `json:"outdated"`    // Responses arriving after another nameserver answered
+	Errors      int64  `json:"errors"`      // Send, CNAME, ANY, other, invalid, too big and truncated errors
+}
+
+// PeersSection is a peers section, as reported by 'show peers'
+type PeersSection struct {
+	Name          string     `json:"name"`
+	Disabled      bool       `json:"disabled"`
+	Flags         string     `json:"flags,omitempty"`
+	ResyncTimeout string     `json:"resync_timeout,omitempty"` // e.g. <PAST> once the initial resync is over
+	Peers         []PeerInfo `json:"peers"`
+}
+
+// PeerInfo is a peer of a peers section and the state of its connection
+type PeerInfo struct {
+	Name           string      `json:"name"`
+	Local          bool        `json:"local"`              // The peer is this HAProxy
+	Activity       string      `json:"activity,omitempty"` // active or inactive, from HAProxy 2.4
+	Address        string      `json:"address"`
+	Status         string      `json:"status,omitempty"`         // Last handshake status, e.g. ESTA, CONN or NONE
+	State          string      `json:"state,omitempty"`          // State of the current connection, e.g. EST; empty when disconnected
+	LastHandshake  string      `json:"last_handshake,omitempty"` // Time since the last handshake, or <NEVER>
+	Reconnect      string      `json:"reconnect,omitempty"`      // Time until the next connection attempt, or <NEVER>
+	Heartbeat      string      `json:"heartbeat,omitempty"`
+	Confirm        int64       `json:"confirm"` // Update confirmations waiting to be sent
+	NewConnections int64       `json:"new_conn"`
+	ProtocolErrors int64       `json:"proto_err"`
+	Collisions     int64       `json:"coll"`
+	Tables         []PeerTable `json:"tables"`
+}
+
+// PeerTable is a stick table shared with a peer and how far its updates were
+// pushed to and acknowledged by the peer
+type PeerTable struct {
+	Table          string `json:"table"`
+	LocalID        int64  `json:"local_id"`
+	RemoteID       int64  `json:"remote_id"`
+	LastAcked      int64  `json:"last_acked"`  // Last local update acknowledged by the peer
+	LastPushed     int64  `json:"last_pushed"` // Last local update sent to the peer
+	LastGet        int64  `json:"last_get"`    // Last update received from the peer
+	TeachingOrigin int64  `json:"teaching_origin"`
+	Update         int64  `json:"update"`        // Last update of the table
+	LocalUpdate    int64  `json:"local_update"`  // Last update made locally
+	CommitUpdate   int64  `json:"commit_update"` // Last update committed
+	Synced         bool   `json:"synced"`        // Every local update was pushed and acknowledged
+}
+
+// ProcessActivity holds the scheduler and poller counters of 'show activity'
+type ProcessActivity struct {
+	Threads  int                        `json:"threads"`
+	Counters map[string]ActivityCounter `json:"counters"` // e.g. loops, ctxsw, poll_io, pool_fail
+}
+
+// ActivityCounter is a counter of 'show activity', totaled and per thread
+type ActivityCounter struct {
+	Total     int64   `json:"total"`
+	PerThread []int64 `json:"per_thread,omitempty"` // Omitted with a single thread
+}
+
+// ThreadInfo is the state of a thread, as reported by 'show threads'
+type ThreadInfo struct {
+	ID               int    `json:"id"`
+	Calling          bool   `json:"calling"` // The thread running the command
+	Stuck            bool   `json:"stuck"`   // The watchdog found the thread not making progress
+	Active           bool   `json:"active"`
+	Harmless         bool   `json:"harmless"`
+	WaitQueue        int64  `json:"wq"`
+	RunQueue         int64  `json:"rq"`
+	Tasklets         int64  `json:"tl"`
+	RunQueueSize     int64  `json:"rqsz"`
+	CurrentTask      string `json:"current_task,omitempty"` // Function of the task being run
+	CurrentTaskCalls int64  `json:"current_task_calls,omitempty"`
+}
+
+// PoolsInfo lists the memory pools of 'show pools', largest first
+type PoolsInfo struct {
+	TotalPools     int         `json:"total_pools"`
+	AllocatedBytes int64       `json:"allocated_bytes"`
+	UsedBytes      int64       `json:"used_bytes"`
+	Pools          []PoolUsage `json:"pools"`
+}
+
+// PoolUsage is the usage of a memory pool
+type PoolUsage struct {
+	Name           string `json:"name"`
+	Size           int64  `json:"size"` // Bytes per entry
+	Allocated      int64  `json:"allocated"`
+	AllocatedBytes int64  `json:"allocated_bytes"`
+	Used           int64  `json:"used"`
+	Cached         int64  `json:"cached,omitempty"`     // Entries held by thread caches
+	NeededAvg      int64  `json:"needed_avg,omitempty"` // Average entries needed
+	Failures       int64  `json:"failures"`             // Failed allocations
+	Users          int64  `json:"users"`                // Pools sharing this one
+}
+
+// TasksInfo lists the functions of the tasks waiting in the run queues, as
+// reported by 'show tasks', most queued first
+type TasksInfo struct {
+	Running int         `json:"running"`
+	Threads int         `json:"threads"`
+	Tasks   []TaskUsage `json:"tasks"`
+}
+
+// TaskUsage is a task function waiting in the run queues
+type TaskUsage struct {
+	Function     string  `json:"function"`
+	Places       int64   `json:"places"`  // Queued tasks running this function
+	Percent      float64 `json:"percent"` // Share of the queued tasks
+	LatencyTotal string  `json:"lat_tot,omitempty"`
+	LatencyAvg   string  `json:"lat_avg,omitempty"`
+}
+
+// FDInfo counts the open file descriptors of 'show fd' by type
+type FDInfo struct {
+	Total  int            `json:"total"`
+	ByType map[string]int `json:"by_type"` // e.g. listener, frontend_connection, backend_connection, pipe
+}
+
+// CrtListEntry is a line of a crt-list: a certificate, the SSL bind options
+// applied to it and the SNI names it is served for.
+type CrtListEntry struct {
+	Certificate string   `json:"certificate"`
+	Line        int      `json:"line,omitempty"`        // Line number within the crt-list, 0 when unknown
+	SSLOptions  string   `json:"ssl_options,omitempty"` // Options between brackets, e.g. "alpn h2 ssl-min-ver TLSv1.2"
+	SNIFilters  []string `json:"sni_filters,omitempty"` // e.g. example.com, *.example.com or !www.example.com
+}
+
 // CommandOptions provides options for executing HAProxy commands
 type CommandOptions struct {
 	Timeout int  // Timeout in seconds
@@ -90,6 +373,58 @@ func (e HAProxyError) Error() string {
 	return fmt.Sprintf("[%d]: %s (command: %s)", e.Code, e.Message, e.Command)
 }
 
+// ConnectionError reports that the Runtime API could not be reached.
+// It wraps the error from the last attempt.
+type ConnectionError struct {
+	Transport string // Transport kind: unix, tcp, tls or ssh
+	Address   string // Endpoint that was dialed
+	Attempts  int    // Number of connection attempts made
+	Err       error  // Error from the last attempt
+}
+
+// Error implements the error interface
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("cannot connect to HAProxy runtime API via %s %s after %d attempt(s): %v",
+		e.Transport, e.Address, e.Attempts, e.Err)
+}
+
+// Unwrap returns the underlying connection error
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// Capabilities describes the Runtime API commands the connected HAProxy supports
+type Capabilities struct {
+	Version  string   `json:"version"`            // Version reported by 'show info', e.g. 2.8.3-1ppa1
+	Major    int      `json:"major"`              // Major version, 0 if unknown
+	Minor    int      `json:"minor"`              // Minor version
+	Commands []string `json:"commands,omitempty"` // Commands listed by 'help'
+}
+
+// UnsupportedCommandError reports a command the connected HAProxy does not support
+type UnsupportedCommandError struct {
+	Command string // Command name, e.g. "add server"
+	Release string // Major and minor version of the connected HAProxy, e.g. "2.2"
+}
+
+// Error implements the error interface
+func (e *UnsupportedCommandError) Error() string {
+	return fmt.Sprintf("%s is not supported on HAProxy %s", e.Command, e.Release)
+}
+
+// InvalidArgumentError reports a command argument that would change the
+// command sent to the Runtime API, such as a backend name containing ';'
+type InvalidArgumentError struct {
+	Kind  string // Argument kind, e.g. "backend"
+	Value string // Rejected value
+	Char  rune   // First rejected character
+}
+
+// Error implements the error interface
+func (e *InvalidArgumentError) Error() string {
+	return fmt.Sprintf("invalid %s %q: must not contain %q", e.Kind, e.Value, e.Char)
+}
+
 // NewHAProxyError creates a new HAProxyError
 func NewHAProxyError(code int, message string, command string) HAProxyError {
 	return HAProxyError{
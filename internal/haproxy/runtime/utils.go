@@ -5,28 +5,46 @@ import (
 	"strings"
 )
 
-// parseCSVStats parses HAProxy stats output in CSV format
+// parseCSVStats parses HAProxy stats output in CSV format.
+// The header line is prefixed with "# " by HAProxy and data lines end with a trailing comma.
 func parseCSVStats(statsOutput string) ([]string, []map[string]string, error) {
-	lines := splitAndTrim(statsOutput)
-	if len(lines) < 2 {
-		return nil, nil, fmt.Errorf("invalid stats output format: insufficient lines")
+	lines := strings.Split(strings.TrimSpace(statsOutput), "\n")
+
+	// Find the header line, which is the first non-empty line
+	headerIndex := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			headerIndex = i
+			break
+		}
+	}
+	if headerIndex == -1 {
+		return nil, nil, fmt.Errorf("invalid stats output format: missing header line")
 	}
 
-	// Get headers from first line
-	headers := strings.Split(lines[0], ",")
+	headerLine := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[headerIndex]), "#"))
+	headers := strings.Split(headerLine, ",")
 
 	// Process data lines
-	results := make([]map[string]string, 0, len(lines)-1)
+	results := make([]map[string]string, 0, len(lines)-headerIndex-1)
+
+	for _, line := range lines[headerIndex+1:] {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
 
-	for i := 1; i < len(lines); i++ {
-		data := strings.Split(lines[i], ",")
+		data := strings.Split(line, ",")
 		if len(data) < len(headers) {
 			continue // Skip incomplete lines
 		}
 
-		// Create a map of field name to value
+		// Create a map of field name to value, ignoring the empty trailing column
 		fieldMap := make(map[string]string)
 		for j := 0; j < len(headers) && j < len(data); j++ {
+			if headers[j] == "" {
+				continue
+			}
 			fieldMap[headers[j]] = data[j]
 		}
 
@@ -36,17 +54,62 @@ func parseCSVStats(statsOutput string) ([]string, []map[string]string, error) {
 	return headers, results, nil
 }
 
-// splitAndTrim splits a string by newline and trims each line
-func splitAndTrim(s string) []string {
-	lines := strings.Split(strings.TrimSpace(s), "\n")
-	result := make([]string, 0, len(lines))
+// parseServersState parses the output of 'show servers state'.
+// HAProxy prints a format version line, then a "# "-prefixed header line, then one line per server.
+func parseServersState(output string) []map[string]string {
+	var headers []string
+	servers := make([]map[string]string, 0)
+
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// The header line names the columns for the lines that follow
+		if strings.HasPrefix(line, "#") {
+			headers = strings.Fields(strings.TrimPrefix(line, "#"))
+			continue
+		}
+
+		// Skip the version line and anything else seen before the header
+		if headers == nil {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		row := make(map[string]string, len(headers))
+		for i := 0; i < len(headers) && i < len(fields); i++ {
+			row[headers[i]] = fields[i]
+		}
+		servers = append(servers, row)
+	}
+
+	return servers
+}
 
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
-			result = append(result, trimmed)
+// findServerState returns the 'show servers state' row for the named server
+func findServerState(servers []map[string]string, server string) (map[string]string, bool) {
+	for _, row := range servers {
+		if row["srv_name"] == server {
+			return row, true
 		}
 	}
+	return nil, false
+}
 
-	return result
+// statTypeNames maps the numeric 'type' column of 'show stat' to proxy kinds
+var statTypeNames = map[string]string{
+	"0": "frontend",
+	"1": "backend",
+	"2": "server",
+	"3": "listener",
+}
+
+// statType returns the kind of a 'show stat' row ("frontend", "backend", "server" or "listener")
+func statType(stat map[string]string) string {
+	if name, ok := statTypeNames[stat["type"]]; ok {
+		return name
+	}
+	return stat["type"]
 }
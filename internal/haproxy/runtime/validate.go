@@ -0,0 +1,49 @@
+package haproxy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// The Runtime API splits a line into commands at ';' and a command into
+// arguments at whitespace, so arguments containing them would run other
+// commands than the one built.
+
+// ValidateName returns an *InvalidArgumentError if name cannot be passed as a
+// proxy, server or section name of kind, e.g. "backend". Names must not
+// contain ';', '/', whitespace or control characters. Empty names are valid.
+func ValidateName(kind, name string) error {
+	return validateArgument(kind, name, func(r rune) bool { return r == '/' || separatesArgument(r) })
+}
+
+// validateServerName checks the backend and server names of a backend/server argument
+func validateServerName(backend, server string) error {
+	if err := ValidateName("backend", backend); err != nil {
+		return err
+	}
+	return ValidateName("server", server)
+}
+
+// validatePath checks a single argument that may contain '/', such as a file
+// name, a map reference or an address
+func validatePath(kind, value string) error {
+	return validateArgument(kind, value, separatesArgument)
+}
+
+// validateWords checks an argument made of several words, such as a show stat filter
+func validateWords(kind, value string) error {
+	return validateArgument(kind, value, func(r rune) bool { return r != ' ' && separatesArgument(r) })
+}
+
+// validateArgument returns an *InvalidArgumentError for the first rune of value rejected by invalid
+func validateArgument(kind, value string, invalid func(rune) bool) error {
+	if i := strings.IndexFunc(value, invalid); i >= 0 {
+		return &InvalidArgumentError{Kind: kind, Value: value, Char: []rune(value[i:])[0]}
+	}
+	return nil
+}
+
+// separatesArgument reports whether r ends a Runtime API command or argument
+func separatesArgument(r rune) bool {
+	return r == ';' || unicode.IsSpace(r) || unicode.IsControl(r)
+}
@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,28 +13,97 @@ import (
 	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy/common"
 )
 
+// DefaultTimeout is used when no timeout is configured for the stats client
+const DefaultTimeout = 10 * time.Second
+
+// ClientOptions configures authentication, TLS and request settings for the stats client
+type ClientOptions struct {
+	Username    string            // Basic auth user (matches "stats auth" in HAProxy)
+	Password    string            // Basic auth password
+	BearerToken string            // Sent as "Authorization: Bearer <token>" when set
+	Headers     map[string]string // Extra headers added to every request
+	TLS         common.TLSOptions // CA bundle, client certificate and verification settings
+	Timeout     time.Duration     // Request timeout; DefaultTimeout when zero
+}
+
 // StatsClient is a client for fetching HAProxy stats from the stats page
 type StatsClient struct {
 	StatsURL   string       // URL to HAProxy stats page (e.g., http://127.0.0.1:1936/;json)
 	httpClient *http.Client // Shared HTTP client
+	options    ClientOptions
 }
 
 // NewStatsClient creates a new HAProxy stats client
 func NewStatsClient(statsURL string) (*StatsClient, error) {
+	return NewStatsClientWithOptions(statsURL, ClientOptions{})
+}
+
+// NewStatsClientWithOptions creates a new HAProxy stats client with authentication and TLS settings
+func NewStatsClientWithOptions(statsURL string, opts ClientOptions) (*StatsClient, error) {
 	// Validate URL
 	_, err := url.Parse(statsURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid stats URL: %w", err)
 	}
 
+	if opts.Username != "" && opts.BearerToken != "" {
+		return nil, fmt.Errorf("basic auth and bearer token are mutually exclusive")
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !opts.TLS.IsZero() {
+		tlsConfig, err := common.NewTLSConfig(opts.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure TLS for stats client: %w", err)
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
 	return &StatsClient{
 		StatsURL: statsURL,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   timeout,
+			Transport: transport,
 		},
+		options: opts,
 	}, nil
 }
 
+// newRequest builds a GET request carrying the configured credentials and headers
+func (c *StatsClient) newRequest(ctx context.Context, target string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for name, value := range c.options.Headers {
+		req.Header.Set(name, value)
+	}
+
+	switch {
+	case c.options.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.options.BearerToken)
+	case c.options.Username != "":
+		req.SetBasicAuth(c.options.Username, c.options.Password)
+	}
+
+	return req, nil
+}
+
+// get performs an authenticated GET request against the stats page
+func (c *StatsClient) get(ctx context.Context, target string) (*http.Response, error) {
+	req, err := c.newRequest(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(req)
+}
+
 // buildURL builds a URL with the given suffix
 func (c *StatsClient) buildURL(suffix string) string {
 	baseURL := c.StatsURL
@@ -45,11 +115,16 @@ func (c *StatsClient) buildURL(suffix string) string {
 
 // GetStats fetches statistics from HAProxy stats page
 func (c *StatsClient) GetStats() (*HAProxyStats, error) {
+	return c.GetStatsWithContext(context.Background())
+}
+
+// GetStatsWithContext fetches statistics from HAProxy stats page with context support
+func (c *StatsClient) GetStatsWithContext(ctx context.Context) (*HAProxyStats, error) {
 	// Construct URL for JSON stats
 	statsURL := c.buildURL(";json")
 
 	// Make HTTP request
-	resp, err := c.httpClient.Get(statsURL)
+	resp, err := c.get(ctx, statsURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch HAProxy stats: %w", err)
 	}
@@ -60,6 +135,9 @@ func (c *StatsClient) GetStats() (*HAProxyStats, error) {
 	}()
 
 	// Check status code
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("HAProxy stats request was rejected with status code %d: check stats credentials", resp.StatusCode)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("HAProxy stats request failed with status code: %d", resp.StatusCode)
 	}
@@ -80,6 +158,11 @@ func (c *StatsClient) GetStats() (*HAProxyStats, error) {
 
 // GetSchema fetches the JSON schema for HAProxy stats
 func (c *StatsClient) GetSchema() (*StatsSchema, error) {
+	return c.GetSchemaWithContext(context.Background())
+}
+
+// GetSchemaWithContext fetches the JSON schema for HAProxy stats with context support
+func (c *StatsClient) GetSchemaWithContext(ctx context.Context) (*StatsSchema, error) {
 	// Construct URL for schema
 	schemaURL := c.StatsURL
 	if schemaURL[len(schemaURL)-6:] == ";json" {
@@ -89,7 +172,7 @@ func (c *StatsClient) GetSchema() (*StatsSchema, error) {
 	}
 
 	// Make HTTP request
-	resp, err := c.httpClient.Get(schemaURL)
+	resp, err := c.get(ctx, schemaURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch HAProxy stats schema: %w", err)
 	}
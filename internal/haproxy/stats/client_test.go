@@ -0,0 +1,91 @@
+package stats
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestGetStatsAuthentication tests that credentials and headers are sent to the stats page
+func TestGetStatsAuthentication(t *testing.T) {
+	testCases := []struct {
+		name       string
+		options    ClientOptions
+		wantAuth   string
+		wantHeader string
+	}{
+		{
+			name:     "Basic auth",
+			options:  ClientOptions{Username: "admin", Password: "secret"},
+			wantAuth: "Basic YWRtaW46c2VjcmV0",
+		},
+		{
+			name:     "Bearer token",
+			options:  ClientOptions{BearerToken: "token123"},
+			wantAuth: "Bearer token123",
+		},
+		{
+			name:       "Custom header",
+			options:    ClientOptions{Headers: map[string]string{"X-Proxy-Key": "abc"}},
+			wantHeader: "abc",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != tc.wantAuth {
+					t.Errorf("Expected Authorization %q, got %q", tc.wantAuth, got)
+				}
+				if got := r.Header.Get("X-Proxy-Key"); got != tc.wantHeader {
+					t.Errorf("Expected X-Proxy-Key %q, got %q", tc.wantHeader, got)
+				}
+				_, _ = w.Write([]byte(`{"stats":[{"pxname":"web","svname":"BACKEND","type":1,"status":"UP"}]}`))
+			}))
+			defer server.Close()
+
+			client, err := NewStatsClientWithOptions(server.URL, tc.options)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			stats, err := client.GetStats()
+			if err != nil {
+				t.Fatalf("GetStats failed: %v", err)
+			}
+			if len(stats.Stats) != 1 || stats.Stats[0].PxName != "web" {
+				t.Errorf("Unexpected stats: %+v", stats.Stats)
+			}
+		})
+	}
+}
+
+// TestGetStatsUnauthorized tests that rejected credentials produce a clear error
+func TestGetStatsUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := NewStatsClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.GetStats()
+	if err == nil || !strings.Contains(err.Error(), "credentials") {
+		t.Errorf("Expected credentials error, got: %v", err)
+	}
+}
+
+// TestNewStatsClientConflictingAuth tests that basic auth and bearer tokens cannot be combined
+func TestNewStatsClientConflictingAuth(t *testing.T) {
+	_, err := NewStatsClientWithOptions("http://127.0.0.1:8404/stats", ClientOptions{
+		Username:    "admin",
+		BearerToken: "token",
+	})
+	if err == nil {
+		t.Error("Expected error for conflicting authentication options")
+	}
+}
@@ -1,6 +1,8 @@
 package testing
 
 import (
+	"context"
+
 	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
 	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy/common"
 	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy/stats"
@@ -21,11 +23,21 @@ func (a *StatsClientAdapter) GetStats() (*stats.HAProxyStats, error) {
 	return a.mock.GetStats()
 }
 
+// GetStatsWithContext implements haproxy.StatsClient
+func (a *StatsClientAdapter) GetStatsWithContext(ctx context.Context) (*stats.HAProxyStats, error) {
+	return a.mock.GetStatsWithContext(ctx)
+}
+
 // GetSchema implements haproxy.StatsClient
 func (a *StatsClientAdapter) GetSchema() (*stats.StatsSchema, error) {
 	return a.mock.GetSchema()
 }
 
+// GetSchemaWithContext implements haproxy.StatsClient
+func (a *StatsClientAdapter) GetSchemaWithContext(ctx context.Context) (*stats.StatsSchema, error) {
+	return a.mock.GetSchemaWithContext(ctx)
+}
+
 // FilterStats implements haproxy.StatsClient
 func (a *StatsClientAdapter) FilterStats(stats *stats.HAProxyStats, proxyName, serviceName string) []common.StatItem {
 	return a.mock.FilterStats(stats, proxyName, serviceName)
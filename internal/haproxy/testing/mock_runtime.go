@@ -3,6 +3,8 @@ package testing
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
 )
@@ -22,16 +24,55 @@ type MockRuntimeClient struct {
 	FailDisableServer    bool
 	FailSetServerWeight  bool
 	FailSetServerMaxconn bool
+	FailShutdownSession  bool // Fails ShutdownSession and ShutdownServerSessions
 	FailGetServerState   bool
+	FailAddServer        bool
+	FailDelServer        bool
+	FailServersState     bool
+	FailToggleCheck      bool
+	FailShowStat         bool
+	FailDebugCounters    bool
+	FailClearCounters    bool
+	FailDumpStatsFile    bool
+	FailReload           bool
+	FailShowMap          bool
+	FailShowErrors       bool
+	FailShowResolvers    bool
+	FailShowPeers        bool
+	FailShowProcess      bool // Fails ShowActivity, ShowThreads, ShowPools, ShowTasks and ShowFDs
+	FailSSLCert          bool
 
 	// Mocked return values
-	CommandResponses map[string]string
-	ProcessInfo      map[string]string
-	Backends         []string
-	BackendInfo      *runtimeclient.BackendInfo
-	Servers          map[string][]string
-	ServerDetails    map[string]map[string]interface{}
-	ServerStates     map[string]string
+	CommandResponses   map[string]string
+	Capabilities       *runtimeclient.Capabilities // Probed capabilities; nil derives them from ProcessInfo and the "help" response
+	ProcessInfo        map[string]string
+	Backends           []string
+	BackendInfo        *runtimeclient.BackendInfo
+	Servers            map[string][]string
+	ServerDetails      map[string]map[string]interface{}
+	ServerStates       map[string]string
+	ServersState       []map[string]string
+	StatRows           []map[string]string
+	Counters           map[string]string
+	Maps               map[string][]runtimeclient.MapEntry
+	Errors             string
+	ErrorCaptures      []runtimeclient.ErrorCapture
+	Resolvers          []runtimeclient.ResolversSection
+	Peers              []runtimeclient.PeersSection
+	Activity           *runtimeclient.ProcessActivity
+	Threads            []runtimeclient.ThreadInfo
+	Pools              *runtimeclient.PoolsInfo
+	Tasks              *runtimeclient.TasksInfo
+	FDs                *runtimeclient.FDInfo
+	SSLCerts           map[string]*runtimeclient.SSLCertInfo
+	SSLTransaction     string // Certificate with an uncommitted transaction
+	CrtLists           map[string][]runtimeclient.CrtListEntry
+	CAFiles            map[string]*runtimeclient.CAFileInfo
+	CAFileTransaction  string // CA file with an uncommitted transaction
+	CRLFiles           map[string]*runtimeclient.CRLFileInfo
+	CRLFileTransaction string // CRL file with an uncommitted transaction
+	OCSPResponseIDs    []runtimeclient.OCSPResponseID
+	OCSPResponses      map[string]*runtimeclient.OCSPResponse // Keyed by certificate ID key
 
 	// Record method calls for verification
 	ExecutedCommands []string
@@ -41,13 +82,25 @@ type MockRuntimeClient struct {
 	DisabledServers  []map[string]string
 	WeightUpdates    []map[string]interface{}
 	MaxconnUpdates   []map[string]interface{}
+	ShutdownSessions []string
+	ShutdownServers  []map[string]string
+	AddedServers     []map[string]interface{}
+	DeletedServers   []map[string]string
+	CheckToggles     []map[string]string
+	DumpedStatsFiles []string
+	CountersCleared  int
+	Reloads          int
+	SSLPayloads      map[string]string
+	SSLCommits       []string
+	DeletedSSLCerts  []string
+	OCSPUpdates      []string
 }
 
 // NewMockRuntimeClient creates a new mock runtime client with default settings
 func NewMockRuntimeClient() *MockRuntimeClient {
 	return &MockRuntimeClient{
 		CommandResponses: make(map[string]string),
-		ProcessInfo:      map[string]string{"version": "2.4.0"},
+		ProcessInfo:      map[string]string{"Version": "2.4.0"},
 		Backends:         []string{"backend1", "backend2"},
 		BackendInfo: &runtimeclient.BackendInfo{
 			Name:     "backend1",
@@ -59,11 +112,107 @@ func NewMockRuntimeClient() *MockRuntimeClient {
 		Servers:       make(map[string][]string),
 		ServerDetails: make(map[string]map[string]interface{}),
 		ServerStates:  make(map[string]string),
+		ServersState: []map[string]string{
+			{"be_name": "backend1", "srv_name": "server1", "srv_addr": "10.0.0.1", "srv_op_state": "2"},
+			{"be_name": "backend1", "srv_name": "server2", "srv_addr": "10.0.0.2", "srv_op_state": "2"},
+		},
+		StatRows: []map[string]string{
+			{"pxname": "backend1", "svname": "server1", "status": "UP", "weight": "100"},
+			{"pxname": "backend1", "svname": "BACKEND", "status": "UP", "weight": "200"},
+		},
+		Counters: map[string]string{"connections": "0"},
+		Maps: map[string][]runtimeclient.MapEntry{
+			"0": {{ID: "0x1", Key: "example.com", Value: "backend1"}},
+		},
+		Errors: "Total events captured on [18/Oct/2026:10:00:00.000] : 0\n",
+		ErrorCaptures: []runtimeclient.ErrorCapture{
+			{Timestamp: "18/Oct/2026:09:58:00.000", Proxy: "http-in", ProxyID: 2, Direction: "request", Frontend: "http-in", Event: 1, Source: "10.0.0.9:50000", Length: 18, ErrorPosition: 3, Excerpt: "GET\\x01 / HTTP/1.1\r\n"},
+			{Timestamp: "18/Oct/2026:09:59:00.000", Proxy: "backend1", ProxyID: 3, Direction: "response", Frontend: "http-in", Backend: "backend1", Server: "server1", Event: 0, Length: 10, Excerpt: "HTTP/1.1 ?"},
+		},
+		Resolvers: []runtimeclient.ResolversSection{
+			{Name: "dns", Nameservers: []runtimeclient.NameserverStats{
+				{Name: "dns1", Sent: 100, Valid: 98, Update: 2, NX: 2},
+				{Name: "dns2", Sent: 100, Valid: 60, Timeouts: 40},
+			}},
+		},
+		Peers: []runtimeclient.PeersSection{
+			{Name: "mypeers", ResyncTimeout: "<PAST>", Peers: []runtimeclient.PeerInfo{
+				{Name: "hostA", Local: true, Address: "10.0.0.1:10000", Status: "NONE", Reconnect: "<NEVER>"},
+				{Name: "hostB", Activity: "active", Address: "10.0.0.2:10000", Status: "ESTA", State: "EST", LastHandshake: "10s", Reconnect: "4s",
+					Tables: []runtimeclient.PeerTable{{Table: "stkt", LastAcked: 3, LastPushed: 3, Update: 3, LocalUpdate: 3, CommitUpdate: 3, Synced: true}}},
+			}},
+		},
+		Activity: &runtimeclient.ProcessActivity{Threads: 2, Counters: map[string]runtimeclient.ActivityCounter{
+			"loops":     {Total: 2000, PerThread: []int64{1000, 1000}},
+			"long_rq":   {Total: 0, PerThread: []int64{0, 0}},
+			"pool_fail": {Total: 0, PerThread: []int64{0, 0}},
+		}},
+		Threads: []runtimeclient.ThreadInfo{
+			{ID: 1, Calling: true, Active: true},
+			{ID: 2, Stuck: true, Active: true, RunQueue: 1, CurrentTask: "process_stream", CurrentTaskCalls: 1},
+		},
+		Pools: &runtimeclient.PoolsInfo{TotalPools: 1, AllocatedBytes: 98304, UsedBytes: 32768, Pools: []runtimeclient.PoolUsage{
+			{Name: "buffer", Size: 16384, Allocated: 6, AllocatedBytes: 98304, Used: 2, Users: 2},
+		}},
+		Tasks: &runtimeclient.TasksInfo{Running: 1, Threads: 2, Tasks: []runtimeclient.TaskUsage{{Function: "process_stream", Places: 1, Percent: 100}}},
+		FDs:   &runtimeclient.FDInfo{Total: 3, ByType: map[string]int{"listener": 1, "frontend_connection": 1, "pipe": 1}},
+		SSLCerts: map[string]*runtimeclient.SSLCertInfo{
+			"/etc/haproxy/ssl/site.pem": {
+				Filename:        "/etc/haproxy/ssl/site.pem",
+				Status:          "Used",
+				NotAfter:        "Jan  1 00:00:00 2027 GMT",
+				SubjectAltNames: []string{"DNS:example.com"},
+				Subject:         "/CN=example.com",
+			},
+		},
+		SSLPayloads: make(map[string]string),
+		CrtLists: map[string][]runtimeclient.CrtListEntry{
+			"/etc/haproxy/crt-list.txt": {
+				{Certificate: "/etc/haproxy/ssl/site.pem", Line: 1, SSLOptions: "alpn h2", SNIFilters: []string{"example.com"}},
+			},
+		},
+		CAFiles: map[string]*runtimeclient.CAFileInfo{
+			"/etc/haproxy/ssl/clients-ca.pem": {
+				Filename: "/etc/haproxy/ssl/clients-ca.pem",
+				Status:   "Used",
+				Certificates: []runtimeclient.SSLCertInfo{
+					{Filename: "/etc/haproxy/ssl/clients-ca.pem:1", NotAfter: "Jan  1 00:00:00 2030 GMT", Subject: "/CN=Clients CA", Issuer: "/CN=Clients CA"},
+				},
+			},
+		},
+		OCSPResponseIDs: []runtimeclient.OCSPResponseID{
+			{ID: "303b300906052b0e03021a0500", Certificate: "/etc/haproxy/ssl/site.pem", SerialNumber: "100A"},
+		},
+		OCSPResponses: map[string]*runtimeclient.OCSPResponse{
+			"303b300906052b0e03021a0500": {
+				ID:             "303b300906052b0e03021a0500",
+				ResponseStatus: "successful (0x0)",
+				SerialNumber:   "100A",
+				CertStatus:     "good",
+				NextUpdate:     "Oct 25 00:00:00 2026 GMT",
+			},
+		},
+		CRLFiles: map[string]*runtimeclient.CRLFileInfo{
+			"/etc/haproxy/ssl/clients.crl": {
+				Filename: "/etc/haproxy/ssl/clients.crl",
+				Status:   "Used",
+				CRLs: []runtimeclient.CRLInfo{
+					{
+						Issuer:     "/CN=Clients CA",
+						NextUpdate: "Jan  1 00:00:00 2027 GMT",
+						Revoked:    []runtimeclient.RevokedCert{{Serial: "1008", RevocationDate: "Oct 18 00:00:00 2026 GMT"}},
+					},
+				},
+			},
+		},
 
 		EnabledServers:  make([]map[string]string, 0),
 		DisabledServers: make([]map[string]string, 0),
 		WeightUpdates:   make([]map[string]interface{}, 0),
 		MaxconnUpdates:  make([]map[string]interface{}, 0),
+		AddedServers:    make([]map[string]interface{}, 0),
+		DeletedServers:  make([]map[string]string, 0),
+		CheckToggles:    make([]map[string]string, 0),
 	}
 }
 
@@ -101,6 +250,25 @@ func (m *MockRuntimeClient) GetProcessInfo() (map[string]string, error) {
 	return m.ProcessInfo, nil
 }
 
+// ProbeCapabilities implements RuntimeClient.ProbeCapabilities
+func (m *MockRuntimeClient) ProbeCapabilities() (*runtimeclient.Capabilities, error) {
+	if m.FailGetProcessInfo {
+		return nil, fmt.Errorf("mock error probing capabilities")
+	}
+	if m.Capabilities != nil {
+		return m.Capabilities, nil
+	}
+	return runtimeclient.NewCapabilities(m.ProcessInfo["Version"], m.CommandResponses["help"]), nil
+}
+
+// ProbeCapabilitiesWithContext implements RuntimeClient.ProbeCapabilitiesWithContext
+func (m *MockRuntimeClient) ProbeCapabilitiesWithContext(ctx context.Context) (*runtimeclient.Capabilities, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ProbeCapabilities()
+}
+
 // GetProcessInfoWithContext implements RuntimeClient.GetProcessInfoWithContext
 func (m *MockRuntimeClient) GetProcessInfoWithContext(ctx context.Context) (map[string]string, error) {
 	// Check if context is already canceled
@@ -125,6 +293,14 @@ func (m *MockRuntimeClient) ListBackends() ([]string, error) {
 	return m.Backends, nil
 }
 
+// ListBackendsWithContext implements RuntimeClient.ListBackendsWithContext
+func (m *MockRuntimeClient) ListBackendsWithContext(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ListBackends()
+}
+
 // GetBackendInfo implements RuntimeClient.GetBackendInfo
 func (m *MockRuntimeClient) GetBackendInfo(name string) (*runtimeclient.BackendInfo, error) {
 	if m.FailGetBackendInfo {
@@ -138,6 +314,14 @@ func (m *MockRuntimeClient) GetBackendInfo(name string) (*runtimeclient.BackendI
 	return nil, fmt.Errorf("backend not found: %s", name)
 }
 
+// GetBackendInfoWithContext implements RuntimeClient.GetBackendInfoWithContext
+func (m *MockRuntimeClient) GetBackendInfoWithContext(ctx context.Context, name string) (*runtimeclient.BackendInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.GetBackendInfo(name)
+}
+
 // EnableBackend implements RuntimeClient.EnableBackend
 func (m *MockRuntimeClient) EnableBackend(name string) error {
 	m.EnabledBackends = append(m.EnabledBackends, name)
@@ -148,6 +332,14 @@ func (m *MockRuntimeClient) EnableBackend(name string) error {
 	return nil
 }
 
+// EnableBackendWithContext implements RuntimeClient.EnableBackendWithContext
+func (m *MockRuntimeClient) EnableBackendWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.EnableBackend(name)
+}
+
 // DisableBackend implements RuntimeClient.DisableBackend
 func (m *MockRuntimeClient) DisableBackend(name string) error {
 	m.DisabledBackends = append(m.DisabledBackends, name)
@@ -158,6 +350,14 @@ func (m *MockRuntimeClient) DisableBackend(name string) error {
 	return nil
 }
 
+// DisableBackendWithContext implements RuntimeClient.DisableBackendWithContext
+func (m *MockRuntimeClient) DisableBackendWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.DisableBackend(name)
+}
+
 // ListServers implements RuntimeClient.ListServers
 func (m *MockRuntimeClient) ListServers(backend string) ([]string, error) {
 	if m.FailListServers {
@@ -171,6 +371,14 @@ func (m *MockRuntimeClient) ListServers(backend string) ([]string, error) {
 	return []string{"server1", "server2"}, nil
 }
 
+// ListServersWithContext implements RuntimeClient.ListServersWithContext
+func (m *MockRuntimeClient) ListServersWithContext(ctx context.Context, backend string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ListServers(backend)
+}
+
 // GetServerDetails implements RuntimeClient.GetServerDetails
 func (m *MockRuntimeClient) GetServerDetails(backend, server string) (map[string]interface{}, error) {
 	if m.FailGetServerDetails {
@@ -189,6 +397,14 @@ func (m *MockRuntimeClient) GetServerDetails(backend, server string) (map[string
 	}, nil
 }
 
+// GetServerDetailsWithContext implements RuntimeClient.GetServerDetailsWithContext
+func (m *MockRuntimeClient) GetServerDetailsWithContext(ctx context.Context, backend, server string) (map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.GetServerDetails(backend, server)
+}
+
 // EnableServer implements RuntimeClient.EnableServer
 func (m *MockRuntimeClient) EnableServer(backend, server string) error {
 	m.EnabledServers = append(m.EnabledServers, map[string]string{
@@ -202,6 +418,14 @@ func (m *MockRuntimeClient) EnableServer(backend, server string) error {
 	return nil
 }
 
+// EnableServerWithContext implements RuntimeClient.EnableServerWithContext
+func (m *MockRuntimeClient) EnableServerWithContext(ctx context.Context, backend, server string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.EnableServer(backend, server)
+}
+
 // DisableServer implements RuntimeClient.DisableServer
 func (m *MockRuntimeClient) DisableServer(backend, server string) error {
 	m.DisabledServers = append(m.DisabledServers, map[string]string{
@@ -215,6 +439,14 @@ func (m *MockRuntimeClient) DisableServer(backend, server string) error {
 	return nil
 }
 
+// DisableServerWithContext implements RuntimeClient.DisableServerWithContext
+func (m *MockRuntimeClient) DisableServerWithContext(ctx context.Context, backend, server string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.DisableServer(backend, server)
+}
+
 // SetServerWeight implements RuntimeClient.SetServerWeight
 func (m *MockRuntimeClient) SetServerWeight(backend, server string, weight int) error {
 	m.WeightUpdates = append(m.WeightUpdates, map[string]interface{}{
@@ -229,6 +461,14 @@ func (m *MockRuntimeClient) SetServerWeight(backend, server string, weight int)
 	return nil
 }
 
+// SetServerWeightWithContext implements RuntimeClient.SetServerWeightWithContext
+func (m *MockRuntimeClient) SetServerWeightWithContext(ctx context.Context, backend, server string, weight int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.SetServerWeight(backend, server, weight)
+}
+
 // SetServerMaxconn implements RuntimeClient.SetServerMaxconn
 func (m *MockRuntimeClient) SetServerMaxconn(backend, server string, maxconn int) error {
 	m.MaxconnUpdates = append(m.MaxconnUpdates, map[string]interface{}{
@@ -243,6 +483,53 @@ func (m *MockRuntimeClient) SetServerMaxconn(backend, server string, maxconn int
 	return nil
 }
 
+// SetServerMaxconnWithContext implements RuntimeClient.SetServerMaxconnWithContext
+func (m *MockRuntimeClient) SetServerMaxconnWithContext(ctx context.Context, backend, server string, maxconn int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.SetServerMaxconn(backend, server, maxconn)
+}
+
+// ShutdownSession implements RuntimeClient.ShutdownSession
+func (m *MockRuntimeClient) ShutdownSession(id string) error {
+	m.ShutdownSessions = append(m.ShutdownSessions, id)
+
+	if m.FailShutdownSession {
+		return fmt.Errorf("mock error shutting down session: %s", id)
+	}
+	return nil
+}
+
+// ShutdownSessionWithContext implements RuntimeClient.ShutdownSessionWithContext
+func (m *MockRuntimeClient) ShutdownSessionWithContext(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.ShutdownSession(id)
+}
+
+// ShutdownServerSessions implements RuntimeClient.ShutdownServerSessions
+func (m *MockRuntimeClient) ShutdownServerSessions(backend, server string) error {
+	m.ShutdownServers = append(m.ShutdownServers, map[string]string{
+		"backend": backend,
+		"server":  server,
+	})
+
+	if m.FailShutdownSession {
+		return fmt.Errorf("mock error shutting down sessions of server: %s/%s", backend, server)
+	}
+	return nil
+}
+
+// ShutdownServerSessionsWithContext implements RuntimeClient.ShutdownServerSessionsWithContext
+func (m *MockRuntimeClient) ShutdownServerSessionsWithContext(ctx context.Context, backend, server string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.ShutdownServerSessions(backend, server)
+}
+
 // GetServerState implements RuntimeClient.GetServerState
 func (m *MockRuntimeClient) GetServerState(backend, server string) (string, error) {
 	if m.FailGetServerState {
@@ -256,3 +543,1055 @@ func (m *MockRuntimeClient) GetServerState(backend, server string) (string, erro
 
 	return "ready", nil
 }
+
+// GetServerStateWithContext implements RuntimeClient.GetServerStateWithContext
+func (m *MockRuntimeClient) GetServerStateWithContext(ctx context.Context, backend, server string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return m.GetServerState(backend, server)
+}
+
+// SetWeight implements RuntimeClient.SetWeight
+func (m *MockRuntimeClient) SetWeight(backend, server string, weight int) (string, error) {
+	if err := m.SetServerWeight(backend, server, weight); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("New weight %d", weight), nil
+}
+
+// SetWeightWithContext implements RuntimeClient.SetWeightWithContext
+func (m *MockRuntimeClient) SetWeightWithContext(ctx context.Context, backend, server string, weight int) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return m.SetWeight(backend, server, weight)
+}
+
+// AddServer implements RuntimeClient.AddServer
+func (m *MockRuntimeClient) AddServer(backend, name, addr string, port, weight int) error {
+	m.AddedServers = append(m.AddedServers, map[string]interface{}{
+		"backend": backend,
+		"name":    name,
+		"addr":    addr,
+		"port":    port,
+		"weight":  weight,
+	})
+
+	if m.FailAddServer {
+		return fmt.Errorf("mock error adding server: %s/%s", backend, name)
+	}
+	return nil
+}
+
+// AddServerWithContext implements RuntimeClient.AddServerWithContext
+func (m *MockRuntimeClient) AddServerWithContext(ctx context.Context, backend, name, addr string, port, weight int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.AddServer(backend, name, addr, port, weight)
+}
+
+// DelServer implements RuntimeClient.DelServer
+func (m *MockRuntimeClient) DelServer(backend, name string) error {
+	m.DeletedServers = append(m.DeletedServers, map[string]string{
+		"backend": backend,
+		"name":    name,
+	})
+
+	if m.FailDelServer {
+		return fmt.Errorf("mock error deleting server: %s/%s", backend, name)
+	}
+	return nil
+}
+
+// DelServerWithContext implements RuntimeClient.DelServerWithContext
+func (m *MockRuntimeClient) DelServerWithContext(ctx context.Context, backend, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.DelServer(backend, name)
+}
+
+// ShowServersState implements RuntimeClient.ShowServersState
+func (m *MockRuntimeClient) ShowServersState(backend string) ([]map[string]string, error) {
+	if m.FailServersState {
+		return nil, fmt.Errorf("mock error showing servers state: %s", backend)
+	}
+
+	result := make([]map[string]string, 0, len(m.ServersState))
+	for _, row := range m.ServersState {
+		if backend == "" || row["be_name"] == backend {
+			result = append(result, row)
+		}
+	}
+	return result, nil
+}
+
+// ShowServersStateWithContext implements RuntimeClient.ShowServersStateWithContext
+func (m *MockRuntimeClient) ShowServersStateWithContext(ctx context.Context, backend string) ([]map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowServersState(backend)
+}
+
+// toggleCheck records a health or agent check change
+func (m *MockRuntimeClient) toggleCheck(action, check, backend, server string) error {
+	m.CheckToggles = append(m.CheckToggles, map[string]string{
+		"action":  action,
+		"check":   check,
+		"backend": backend,
+		"server":  server,
+	})
+
+	if m.FailToggleCheck {
+		return fmt.Errorf("mock error: %s %s %s/%s", action, check, backend, server)
+	}
+	return nil
+}
+
+// EnableHealth implements RuntimeClient.EnableHealth
+func (m *MockRuntimeClient) EnableHealth(backend, server string) error {
+	return m.toggleCheck("enable", "health", backend, server)
+}
+
+// EnableHealthWithContext implements RuntimeClient.EnableHealthWithContext
+func (m *MockRuntimeClient) EnableHealthWithContext(ctx context.Context, backend, server string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.EnableHealth(backend, server)
+}
+
+// DisableHealth implements RuntimeClient.DisableHealth
+func (m *MockRuntimeClient) DisableHealth(backend, server string) error {
+	return m.toggleCheck("disable", "health", backend, server)
+}
+
+// DisableHealthWithContext implements RuntimeClient.DisableHealthWithContext
+func (m *MockRuntimeClient) DisableHealthWithContext(ctx context.Context, backend, server string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.DisableHealth(backend, server)
+}
+
+// EnableAgent implements RuntimeClient.EnableAgent
+func (m *MockRuntimeClient) EnableAgent(backend, server string) error {
+	return m.toggleCheck("enable", "agent", backend, server)
+}
+
+// EnableAgentWithContext implements RuntimeClient.EnableAgentWithContext
+func (m *MockRuntimeClient) EnableAgentWithContext(ctx context.Context, backend, server string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.EnableAgent(backend, server)
+}
+
+// DisableAgent implements RuntimeClient.DisableAgent
+func (m *MockRuntimeClient) DisableAgent(backend, server string) error {
+	return m.toggleCheck("disable", "agent", backend, server)
+}
+
+// DisableAgentWithContext implements RuntimeClient.DisableAgentWithContext
+func (m *MockRuntimeClient) DisableAgentWithContext(ctx context.Context, backend, server string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.DisableAgent(backend, server)
+}
+
+// ShowStat implements RuntimeClient.ShowStat
+func (m *MockRuntimeClient) ShowStat(filter string) ([]map[string]string, error) {
+	if m.FailShowStat {
+		return nil, fmt.Errorf("mock error showing stats: %s", filter)
+	}
+
+	result := make([]map[string]string, 0, len(m.StatRows))
+	for _, row := range m.StatRows {
+		if filter == "" || row["pxname"] == filter {
+			result = append(result, row)
+		}
+	}
+	return result, nil
+}
+
+// ShowStatWithContext implements RuntimeClient.ShowStatWithContext
+func (m *MockRuntimeClient) ShowStatWithContext(ctx context.Context, filter string) ([]map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowStat(filter)
+}
+
+// DebugCounters implements RuntimeClient.DebugCounters
+func (m *MockRuntimeClient) DebugCounters() (map[string]string, error) {
+	if m.FailDebugCounters {
+		return nil, fmt.Errorf("mock error getting debug counters")
+	}
+	return m.Counters, nil
+}
+
+// DebugCountersWithContext implements RuntimeClient.DebugCountersWithContext
+func (m *MockRuntimeClient) DebugCountersWithContext(ctx context.Context) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.DebugCounters()
+}
+
+// ClearCountersAll implements RuntimeClient.ClearCountersAll
+func (m *MockRuntimeClient) ClearCountersAll() error {
+	m.CountersCleared++
+
+	if m.FailClearCounters {
+		return fmt.Errorf("mock error clearing counters")
+	}
+	return nil
+}
+
+// ClearCountersAllWithContext implements RuntimeClient.ClearCountersAllWithContext
+func (m *MockRuntimeClient) ClearCountersAllWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.ClearCountersAll()
+}
+
+// DumpStatsFile implements RuntimeClient.DumpStatsFile
+func (m *MockRuntimeClient) DumpStatsFile(filepath string) (string, error) {
+	m.DumpedStatsFiles = append(m.DumpedStatsFiles, filepath)
+
+	if m.FailDumpStatsFile {
+		return "", fmt.Errorf("mock error dumping stats to file: %s", filepath)
+	}
+	return filepath, nil
+}
+
+// DumpStatsFileWithContext implements RuntimeClient.DumpStatsFileWithContext
+func (m *MockRuntimeClient) DumpStatsFileWithContext(ctx context.Context, filepath string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return m.DumpStatsFile(filepath)
+}
+
+// ShowErrors implements RuntimeClient.ShowErrors
+func (m *MockRuntimeClient) ShowErrors(proxy string) (string, error) {
+	if m.FailShowErrors {
+		return "", fmt.Errorf("mock error showing errors: %s", proxy)
+	}
+	return m.Errors, nil
+}
+
+// ShowErrorsWithContext implements RuntimeClient.ShowErrorsWithContext
+func (m *MockRuntimeClient) ShowErrorsWithContext(ctx context.Context, proxy string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return m.ShowErrors(proxy)
+}
+
+// ShowErrorCaptures implements RuntimeClient.ShowErrorCaptures
+func (m *MockRuntimeClient) ShowErrorCaptures(proxy, direction string) ([]runtimeclient.ErrorCapture, error) {
+	if m.FailShowErrors {
+		return nil, fmt.Errorf("mock error showing errors: %s", proxy)
+	}
+
+	captures := make([]runtimeclient.ErrorCapture, 0, len(m.ErrorCaptures))
+	for _, capture := range m.ErrorCaptures {
+		if (proxy == "" || capture.Proxy == proxy) && (direction == "" || capture.Direction == direction) {
+			captures = append(captures, capture)
+		}
+	}
+	return captures, nil
+}
+
+// ShowErrorCapturesWithContext implements RuntimeClient.ShowErrorCapturesWithContext
+func (m *MockRuntimeClient) ShowErrorCapturesWithContext(ctx context.Context, proxy, direction string) ([]runtimeclient.ErrorCapture, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowErrorCaptures(proxy, direction)
+}
+
+// ShowResolvers implements RuntimeClient.ShowResolvers
+func (m *MockRuntimeClient) ShowResolvers(id string) ([]runtimeclient.ResolversSection, error) {
+	if m.FailShowResolvers {
+		return nil, fmt.Errorf("mock error showing resolvers: %s", id)
+	}
+
+	sections := make([]runtimeclient.ResolversSection, 0, len(m.Resolvers))
+	for _, section := range m.Resolvers {
+		if id == "" || section.Name == id {
+			sections = append(sections, section)
+		}
+	}
+	if id != "" && len(sections) == 0 {
+		return nil, fmt.Errorf("failed to show resolvers %s: Can't find that resolvers section", id)
+	}
+	return sections, nil
+}
+
+// ShowResolversWithContext implements RuntimeClient.ShowResolversWithContext
+func (m *MockRuntimeClient) ShowResolversWithContext(ctx context.Context, id string) ([]runtimeclient.ResolversSection, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowResolvers(id)
+}
+
+// ShowPeers implements RuntimeClient.ShowPeers
+func (m *MockRuntimeClient) ShowPeers(name string) ([]runtimeclient.PeersSection, error) {
+	if m.FailShowPeers {
+		return nil, fmt.Errorf("mock error showing peers: %s", name)
+	}
+
+	sections := make([]runtimeclient.PeersSection, 0, len(m.Peers))
+	for _, section := range m.Peers {
+		if name == "" || section.Name == name {
+			sections = append(sections, section)
+		}
+	}
+	if name != "" && len(sections) == 0 {
+		return nil, fmt.Errorf("failed to show peers %s: No such peers section", name)
+	}
+	return sections, nil
+}
+
+// ShowPeersWithContext implements RuntimeClient.ShowPeersWithContext
+func (m *MockRuntimeClient) ShowPeersWithContext(ctx context.Context, name string) ([]runtimeclient.PeersSection, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowPeers(name)
+}
+
+// ShowActivity implements RuntimeClient.ShowActivity
+func (m *MockRuntimeClient) ShowActivity() (*runtimeclient.ProcessActivity, error) {
+	if m.FailShowProcess {
+		return nil, fmt.Errorf("mock error running ShowActivity")
+	}
+	return m.Activity, nil
+}
+
+// ShowActivityWithContext implements RuntimeClient.ShowActivityWithContext
+func (m *MockRuntimeClient) ShowActivityWithContext(ctx context.Context) (*runtimeclient.ProcessActivity, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowActivity()
+}
+
+// ShowThreads implements RuntimeClient.ShowThreads
+func (m *MockRuntimeClient) ShowThreads() ([]runtimeclient.ThreadInfo, error) {
+	if m.FailShowProcess {
+		return nil, fmt.Errorf("mock error running ShowThreads")
+	}
+	return m.Threads, nil
+}
+
+// ShowThreadsWithContext implements RuntimeClient.ShowThreadsWithContext
+func (m *MockRuntimeClient) ShowThreadsWithContext(ctx context.Context) ([]runtimeclient.ThreadInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowThreads()
+}
+
+// ShowPools implements RuntimeClient.ShowPools
+func (m *MockRuntimeClient) ShowPools() (*runtimeclient.PoolsInfo, error) {
+	if m.FailShowProcess {
+		return nil, fmt.Errorf("mock error running ShowPools")
+	}
+	return m.Pools, nil
+}
+
+// ShowPoolsWithContext implements RuntimeClient.ShowPoolsWithContext
+func (m *MockRuntimeClient) ShowPoolsWithContext(ctx context.Context) (*runtimeclient.PoolsInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowPools()
+}
+
+// ShowTasks implements RuntimeClient.ShowTasks
+func (m *MockRuntimeClient) ShowTasks() (*runtimeclient.TasksInfo, error) {
+	if m.FailShowProcess {
+		return nil, fmt.Errorf("mock error running ShowTasks")
+	}
+	return m.Tasks, nil
+}
+
+// ShowTasksWithContext implements RuntimeClient.ShowTasksWithContext
+func (m *MockRuntimeClient) ShowTasksWithContext(ctx context.Context) (*runtimeclient.TasksInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowTasks()
+}
+
+// ShowFDs implements RuntimeClient.ShowFDs
+func (m *MockRuntimeClient) ShowFDs() (*runtimeclient.FDInfo, error) {
+	if m.FailShowProcess {
+		return nil, fmt.Errorf("mock error running ShowFDs")
+	}
+	return m.FDs, nil
+}
+
+// ShowFDsWithContext implements RuntimeClient.ShowFDsWithContext
+func (m *MockRuntimeClient) ShowFDsWithContext(ctx context.Context) (*runtimeclient.FDInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowFDs()
+}
+
+// ReloadHAProxy implements RuntimeClient.ReloadHAProxy
+func (m *MockRuntimeClient) ReloadHAProxy() error {
+	m.Reloads++
+
+	if m.FailReload {
+		return fmt.Errorf("mock error reloading HAProxy")
+	}
+	return nil
+}
+
+// ReloadHAProxyWithContext implements RuntimeClient.ReloadHAProxyWithContext
+func (m *MockRuntimeClient) ReloadHAProxyWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.ReloadHAProxy()
+}
+
+// ListMaps implements RuntimeClient.ListMaps
+func (m *MockRuntimeClient) ListMaps() ([]runtimeclient.MapInfo, error) {
+	if m.FailShowMap {
+		return nil, fmt.Errorf("mock error listing maps")
+	}
+
+	ids := make([]string, 0, len(m.Maps))
+	for id := range m.Maps {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	maps := make([]runtimeclient.MapInfo, 0, len(ids))
+	for _, id := range ids {
+		maps = append(maps, runtimeclient.MapInfo{ID: id})
+	}
+	return maps, nil
+}
+
+// ListMapsWithContext implements RuntimeClient.ListMapsWithContext
+func (m *MockRuntimeClient) ListMapsWithContext(ctx context.Context) ([]runtimeclient.MapInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ListMaps()
+}
+
+// ShowMap implements RuntimeClient.ShowMap
+func (m *MockRuntimeClient) ShowMap(id string) ([]runtimeclient.MapEntry, error) {
+	if m.FailShowMap {
+		return nil, fmt.Errorf("mock error showing map: %s", id)
+	}
+
+	if entries, exists := m.Maps[id]; exists {
+		return entries, nil
+	}
+
+	return nil, fmt.Errorf("map not found: %s", id)
+}
+
+// ShowMapWithContext implements RuntimeClient.ShowMapWithContext
+func (m *MockRuntimeClient) ShowMapWithContext(ctx context.Context, id string) ([]runtimeclient.MapEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowMap(id)
+}
+
+// ListSSLCerts implements RuntimeClient.ListSSLCerts
+func (m *MockRuntimeClient) ListSSLCerts() (*runtimeclient.SSLCertList, error) {
+	if m.FailSSLCert {
+		return nil, fmt.Errorf("mock error listing SSL certificates")
+	}
+
+	list := &runtimeclient.SSLCertList{Certificates: make([]string, 0, len(m.SSLCerts)), Transaction: m.SSLTransaction}
+	for name := range m.SSLCerts {
+		list.Certificates = append(list.Certificates, name)
+	}
+	sort.Strings(list.Certificates)
+	return list, nil
+}
+
+// ListSSLCertsWithContext implements RuntimeClient.ListSSLCertsWithContext
+func (m *MockRuntimeClient) ListSSLCertsWithContext(ctx context.Context) (*runtimeclient.SSLCertList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ListSSLCerts()
+}
+
+// ShowSSLCert implements RuntimeClient.ShowSSLCert
+func (m *MockRuntimeClient) ShowSSLCert(name string) (*runtimeclient.SSLCertInfo, error) {
+	if m.FailSSLCert {
+		return nil, fmt.Errorf("mock error showing SSL certificate: %s", name)
+	}
+
+	if pending, ok := strings.CutPrefix(name, "*"); ok {
+		if pending != m.SSLTransaction {
+			return nil, fmt.Errorf("no transaction for certificate: %s", pending)
+		}
+		return &runtimeclient.SSLCertInfo{Filename: "*" + pending, Status: "Uncommitted"}, nil
+	}
+	if info, exists := m.SSLCerts[name]; exists {
+		return info, nil
+	}
+	return nil, fmt.Errorf("certificate not found: %s", name)
+}
+
+// ShowSSLCertWithContext implements RuntimeClient.ShowSSLCertWithContext
+func (m *MockRuntimeClient) ShowSSLCertWithContext(ctx context.Context, name string) (*runtimeclient.SSLCertInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowSSLCert(name)
+}
+
+// NewSSLCert implements RuntimeClient.NewSSLCert
+func (m *MockRuntimeClient) NewSSLCert(name string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error creating SSL certificate: %s", name)
+	}
+	if _, exists := m.SSLCerts[name]; exists {
+		return fmt.Errorf("certificate already exists: %s", name)
+	}
+	m.SSLCerts[name] = &runtimeclient.SSLCertInfo{Filename: name, Status: "Unused"}
+	return nil
+}
+
+// NewSSLCertWithContext implements RuntimeClient.NewSSLCertWithContext
+func (m *MockRuntimeClient) NewSSLCertWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.NewSSLCert(name)
+}
+
+// SetSSLCert implements RuntimeClient.SetSSLCert
+func (m *MockRuntimeClient) SetSSLCert(name, payload string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error setting SSL certificate: %s", name)
+	}
+	if _, exists := m.SSLCerts[name]; !exists {
+		return fmt.Errorf("certificate not found: %s", name)
+	}
+	if m.SSLTransaction != "" && m.SSLTransaction != name {
+		return fmt.Errorf("transaction already in progress for certificate: %s", m.SSLTransaction)
+	}
+	m.SSLTransaction = name
+	m.SSLPayloads[name] = payload
+	return nil
+}
+
+// SetSSLCertWithContext implements RuntimeClient.SetSSLCertWithContext
+func (m *MockRuntimeClient) SetSSLCertWithContext(ctx context.Context, name, payload string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.SetSSLCert(name, payload)
+}
+
+// CommitSSLCert implements RuntimeClient.CommitSSLCert
+func (m *MockRuntimeClient) CommitSSLCert(name string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error committing SSL certificate: %s", name)
+	}
+	if m.SSLTransaction != name {
+		return fmt.Errorf("no transaction for certificate: %s", name)
+	}
+	m.SSLTransaction = ""
+	m.SSLCommits = append(m.SSLCommits, name)
+	return nil
+}
+
+// CommitSSLCertWithContext implements RuntimeClient.CommitSSLCertWithContext
+func (m *MockRuntimeClient) CommitSSLCertWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.CommitSSLCert(name)
+}
+
+// AbortSSLCert implements RuntimeClient.AbortSSLCert
+func (m *MockRuntimeClient) AbortSSLCert(name string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error aborting SSL certificate: %s", name)
+	}
+	if m.SSLTransaction != name {
+		return fmt.Errorf("no transaction for certificate: %s", name)
+	}
+	m.SSLTransaction = ""
+	delete(m.SSLPayloads, name)
+	return nil
+}
+
+// AbortSSLCertWithContext implements RuntimeClient.AbortSSLCertWithContext
+func (m *MockRuntimeClient) AbortSSLCertWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.AbortSSLCert(name)
+}
+
+// DelSSLCert implements RuntimeClient.DelSSLCert
+func (m *MockRuntimeClient) DelSSLCert(name string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error deleting SSL certificate: %s", name)
+	}
+	if _, exists := m.SSLCerts[name]; !exists {
+		return fmt.Errorf("certificate not found: %s", name)
+	}
+	delete(m.SSLCerts, name)
+	m.DeletedSSLCerts = append(m.DeletedSSLCerts, name)
+	return nil
+}
+
+// DelSSLCertWithContext implements RuntimeClient.DelSSLCertWithContext
+func (m *MockRuntimeClient) DelSSLCertWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.DelSSLCert(name)
+}
+
+// ListCrtLists implements RuntimeClient.ListCrtLists
+func (m *MockRuntimeClient) ListCrtLists() ([]string, error) {
+	if m.FailSSLCert {
+		return nil, fmt.Errorf("mock error listing crt-lists")
+	}
+
+	lists := make([]string, 0, len(m.CrtLists))
+	for name := range m.CrtLists {
+		lists = append(lists, name)
+	}
+	sort.Strings(lists)
+	return lists, nil
+}
+
+// ListCrtListsWithContext implements RuntimeClient.ListCrtListsWithContext
+func (m *MockRuntimeClient) ListCrtListsWithContext(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ListCrtLists()
+}
+
+// ShowCrtList implements RuntimeClient.ShowCrtList
+func (m *MockRuntimeClient) ShowCrtList(crtList string) ([]runtimeclient.CrtListEntry, error) {
+	if m.FailSSLCert {
+		return nil, fmt.Errorf("mock error showing crt-list: %s", crtList)
+	}
+	if entries, exists := m.CrtLists[crtList]; exists {
+		return entries, nil
+	}
+	return nil, fmt.Errorf("crt-list not found: %s", crtList)
+}
+
+// ShowCrtListWithContext implements RuntimeClient.ShowCrtListWithContext
+func (m *MockRuntimeClient) ShowCrtListWithContext(ctx context.Context, crtList string) ([]runtimeclient.CrtListEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowCrtList(crtList)
+}
+
+// AddCrtListEntry implements RuntimeClient.AddCrtListEntry
+func (m *MockRuntimeClient) AddCrtListEntry(crtList string, entry runtimeclient.CrtListEntry) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error adding to crt-list: %s", crtList)
+	}
+	entries, exists := m.CrtLists[crtList]
+	if !exists {
+		return fmt.Errorf("crt-list not found: %s", crtList)
+	}
+	if _, loaded := m.SSLCerts[entry.Certificate]; !loaded {
+		return fmt.Errorf("certificate not found: %s", entry.Certificate)
+	}
+	entry.Line = len(entries) + 1
+	m.CrtLists[crtList] = append(entries, entry)
+	return nil
+}
+
+// AddCrtListEntryWithContext implements RuntimeClient.AddCrtListEntryWithContext
+func (m *MockRuntimeClient) AddCrtListEntryWithContext(ctx context.Context, crtList string, entry runtimeclient.CrtListEntry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.AddCrtListEntry(crtList, entry)
+}
+
+// DelCrtListEntry implements RuntimeClient.DelCrtListEntry
+func (m *MockRuntimeClient) DelCrtListEntry(crtList, certificate string, line int) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error deleting from crt-list: %s", crtList)
+	}
+	entries := m.CrtLists[crtList]
+	for i, entry := range entries {
+		if entry.Certificate == certificate && (line == 0 || entry.Line == line) {
+			m.CrtLists[crtList] = append(entries[:i:i], entries[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("entry not found in crt-list %s: %s", crtList, certificate)
+}
+
+// DelCrtListEntryWithContext implements RuntimeClient.DelCrtListEntryWithContext
+func (m *MockRuntimeClient) DelCrtListEntryWithContext(ctx context.Context, crtList, certificate string, line int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.DelCrtListEntry(crtList, certificate, line)
+}
+
+// ListCAFiles implements RuntimeClient.ListCAFiles
+func (m *MockRuntimeClient) ListCAFiles() (*runtimeclient.SSLFileList, error) {
+	if m.FailSSLCert {
+		return nil, fmt.Errorf("mock error listing CA files")
+	}
+
+	list := &runtimeclient.SSLFileList{Files: make([]string, 0, len(m.CAFiles)), Transaction: m.CAFileTransaction}
+	for name := range m.CAFiles {
+		list.Files = append(list.Files, name)
+	}
+	sort.Strings(list.Files)
+	return list, nil
+}
+
+// ListCAFilesWithContext implements RuntimeClient.ListCAFilesWithContext
+func (m *MockRuntimeClient) ListCAFilesWithContext(ctx context.Context) (*runtimeclient.SSLFileList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ListCAFiles()
+}
+
+// ShowCAFile implements RuntimeClient.ShowCAFile
+func (m *MockRuntimeClient) ShowCAFile(name string) (*runtimeclient.CAFileInfo, error) {
+	if m.FailSSLCert {
+		return nil, fmt.Errorf("mock error showing CA file: %s", name)
+	}
+	if pending, ok := strings.CutPrefix(name, "*"); ok {
+		if pending != m.CAFileTransaction {
+			return nil, fmt.Errorf("no transaction for CA file: %s", pending)
+		}
+		return &runtimeclient.CAFileInfo{Filename: "*" + pending, Status: "Uncommitted", Certificates: []runtimeclient.SSLCertInfo{}}, nil
+	}
+	if info, exists := m.CAFiles[name]; exists {
+		return info, nil
+	}
+	return nil, fmt.Errorf("CA file not found: %s", name)
+}
+
+// ShowCAFileWithContext implements RuntimeClient.ShowCAFileWithContext
+func (m *MockRuntimeClient) ShowCAFileWithContext(ctx context.Context, name string) (*runtimeclient.CAFileInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowCAFile(name)
+}
+
+// NewCAFile implements RuntimeClient.NewCAFile
+func (m *MockRuntimeClient) NewCAFile(name string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error creating CA file: %s", name)
+	}
+	if _, exists := m.CAFiles[name]; exists {
+		return fmt.Errorf("CA file already exists: %s", name)
+	}
+	m.CAFiles[name] = &runtimeclient.CAFileInfo{Filename: name, Status: "Unused", Certificates: []runtimeclient.SSLCertInfo{}}
+	return nil
+}
+
+// NewCAFileWithContext implements RuntimeClient.NewCAFileWithContext
+func (m *MockRuntimeClient) NewCAFileWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.NewCAFile(name)
+}
+
+// SetCAFile implements RuntimeClient.SetCAFile
+func (m *MockRuntimeClient) SetCAFile(name, payload string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error setting CA file: %s", name)
+	}
+	if err := m.stageCAFile(name); err != nil {
+		return err
+	}
+	m.SSLPayloads[name] = payload
+	return nil
+}
+
+// SetCAFileWithContext implements RuntimeClient.SetCAFileWithContext
+func (m *MockRuntimeClient) SetCAFileWithContext(ctx context.Context, name, payload string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.SetCAFile(name, payload)
+}
+
+// AddCAFile implements RuntimeClient.AddCAFile
+func (m *MockRuntimeClient) AddCAFile(name, payload string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error adding to CA file: %s", name)
+	}
+	if err := m.stageCAFile(name); err != nil {
+		return err
+	}
+	if staged := m.SSLPayloads[name]; staged != "" {
+		payload = staged + "\n" + payload
+	}
+	m.SSLPayloads[name] = payload
+	return nil
+}
+
+// AddCAFileWithContext implements RuntimeClient.AddCAFileWithContext
+func (m *MockRuntimeClient) AddCAFileWithContext(ctx context.Context, name, payload string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.AddCAFile(name, payload)
+}
+
+// stageCAFile opens the transaction of a CA file for SetCAFile and AddCAFile
+func (m *MockRuntimeClient) stageCAFile(name string) error {
+	if _, exists := m.CAFiles[name]; !exists {
+		return fmt.Errorf("CA file not found: %s", name)
+	}
+	if m.CAFileTransaction != "" && m.CAFileTransaction != name {
+		return fmt.Errorf("transaction already in progress for CA file: %s", m.CAFileTransaction)
+	}
+	m.CAFileTransaction = name
+	return nil
+}
+
+// CommitCAFile implements RuntimeClient.CommitCAFile
+func (m *MockRuntimeClient) CommitCAFile(name string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error committing CA file: %s", name)
+	}
+	if m.CAFileTransaction != name {
+		return fmt.Errorf("no transaction for CA file: %s", name)
+	}
+	m.CAFileTransaction = ""
+	m.SSLCommits = append(m.SSLCommits, name)
+	return nil
+}
+
+// CommitCAFileWithContext implements RuntimeClient.CommitCAFileWithContext
+func (m *MockRuntimeClient) CommitCAFileWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.CommitCAFile(name)
+}
+
+// AbortCAFile implements RuntimeClient.AbortCAFile
+func (m *MockRuntimeClient) AbortCAFile(name string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error aborting CA file: %s", name)
+	}
+	if m.CAFileTransaction != name {
+		return fmt.Errorf("no transaction for CA file: %s", name)
+	}
+	m.CAFileTransaction = ""
+	delete(m.SSLPayloads, name)
+	return nil
+}
+
+// AbortCAFileWithContext implements RuntimeClient.AbortCAFileWithContext
+func (m *MockRuntimeClient) AbortCAFileWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.AbortCAFile(name)
+}
+
+// ListCRLFiles implements RuntimeClient.ListCRLFiles
+func (m *MockRuntimeClient) ListCRLFiles() (*runtimeclient.SSLFileList, error) {
+	if m.FailSSLCert {
+		return nil, fmt.Errorf("mock error listing CRL files")
+	}
+
+	list := &runtimeclient.SSLFileList{Files: make([]string, 0, len(m.CRLFiles)), Transaction: m.CRLFileTransaction}
+	for name := range m.CRLFiles {
+		list.Files = append(list.Files, name)
+	}
+	sort.Strings(list.Files)
+	return list, nil
+}
+
+// ListCRLFilesWithContext implements RuntimeClient.ListCRLFilesWithContext
+func (m *MockRuntimeClient) ListCRLFilesWithContext(ctx context.Context) (*runtimeclient.SSLFileList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ListCRLFiles()
+}
+
+// ShowCRLFile implements RuntimeClient.ShowCRLFile
+func (m *MockRuntimeClient) ShowCRLFile(name string) (*runtimeclient.CRLFileInfo, error) {
+	if m.FailSSLCert {
+		return nil, fmt.Errorf("mock error showing CRL file: %s", name)
+	}
+	if pending, ok := strings.CutPrefix(name, "*"); ok {
+		if pending != m.CRLFileTransaction {
+			return nil, fmt.Errorf("no transaction for CRL file: %s", pending)
+		}
+		return &runtimeclient.CRLFileInfo{Filename: "*" + pending, Status: "Uncommitted", CRLs: []runtimeclient.CRLInfo{}}, nil
+	}
+	if info, exists := m.CRLFiles[name]; exists {
+		return info, nil
+	}
+	return nil, fmt.Errorf("CRL file not found: %s", name)
+}
+
+// ShowCRLFileWithContext implements RuntimeClient.ShowCRLFileWithContext
+func (m *MockRuntimeClient) ShowCRLFileWithContext(ctx context.Context, name string) (*runtimeclient.CRLFileInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowCRLFile(name)
+}
+
+// SetCRLFile implements RuntimeClient.SetCRLFile
+func (m *MockRuntimeClient) SetCRLFile(name, payload string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error setting CRL file: %s", name)
+	}
+	if _, exists := m.CRLFiles[name]; !exists {
+		return fmt.Errorf("CRL file not found: %s", name)
+	}
+	if m.CRLFileTransaction != "" && m.CRLFileTransaction != name {
+		return fmt.Errorf("transaction already in progress for CRL file: %s", m.CRLFileTransaction)
+	}
+	m.CRLFileTransaction = name
+	m.SSLPayloads[name] = payload
+	return nil
+}
+
+// SetCRLFileWithContext implements RuntimeClient.SetCRLFileWithContext
+func (m *MockRuntimeClient) SetCRLFileWithContext(ctx context.Context, name, payload string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.SetCRLFile(name, payload)
+}
+
+// CommitCRLFile implements RuntimeClient.CommitCRLFile
+func (m *MockRuntimeClient) CommitCRLFile(name string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error committing CRL file: %s", name)
+	}
+	if m.CRLFileTransaction != name {
+		return fmt.Errorf("no transaction for CRL file: %s", name)
+	}
+	m.CRLFileTransaction = ""
+	m.SSLCommits = append(m.SSLCommits, name)
+	return nil
+}
+
+// CommitCRLFileWithContext implements RuntimeClient.CommitCRLFileWithContext
+func (m *MockRuntimeClient) CommitCRLFileWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.CommitCRLFile(name)
+}
+
+// AbortCRLFile implements RuntimeClient.AbortCRLFile
+func (m *MockRuntimeClient) AbortCRLFile(name string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error aborting CRL file: %s", name)
+	}
+	if m.CRLFileTransaction != name {
+		return fmt.Errorf("no transaction for CRL file: %s", name)
+	}
+	m.CRLFileTransaction = ""
+	delete(m.SSLPayloads, name)
+	return nil
+}
+
+// AbortCRLFileWithContext implements RuntimeClient.AbortCRLFileWithContext
+func (m *MockRuntimeClient) AbortCRLFileWithContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.AbortCRLFile(name)
+}
+
+// ListOCSPResponses implements RuntimeClient.ListOCSPResponses
+func (m *MockRuntimeClient) ListOCSPResponses() ([]runtimeclient.OCSPResponseID, error) {
+	if m.FailSSLCert {
+		return nil, fmt.Errorf("mock error listing OCSP responses")
+	}
+	return m.OCSPResponseIDs, nil
+}
+
+// ListOCSPResponsesWithContext implements RuntimeClient.ListOCSPResponsesWithContext
+func (m *MockRuntimeClient) ListOCSPResponsesWithContext(ctx context.Context) ([]runtimeclient.OCSPResponseID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ListOCSPResponses()
+}
+
+// ShowOCSPResponse implements RuntimeClient.ShowOCSPResponse
+func (m *MockRuntimeClient) ShowOCSPResponse(id string) (*runtimeclient.OCSPResponse, error) {
+	if m.FailSSLCert {
+		return nil, fmt.Errorf("mock error showing OCSP response: %s", id)
+	}
+	for _, response := range m.OCSPResponseIDs {
+		if response.Certificate == id {
+			id = response.ID
+		}
+	}
+	if response, exists := m.OCSPResponses[id]; exists {
+		return response, nil
+	}
+	return nil, fmt.Errorf("OCSP response not found: %s", id)
+}
+
+// ShowOCSPResponseWithContext implements RuntimeClient.ShowOCSPResponseWithContext
+func (m *MockRuntimeClient) ShowOCSPResponseWithContext(ctx context.Context, id string) (*runtimeclient.OCSPResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ShowOCSPResponse(id)
+}
+
+// UpdateOCSPResponse implements RuntimeClient.UpdateOCSPResponse
+func (m *MockRuntimeClient) UpdateOCSPResponse(certificate string) error {
+	if m.FailSSLCert {
+		return fmt.Errorf("mock error updating OCSP response: %s", certificate)
+	}
+	if _, exists := m.SSLCerts[certificate]; !exists {
+		return fmt.Errorf("certificate not found: %s", certificate)
+	}
+	m.OCSPUpdates = append(m.OCSPUpdates, certificate)
+	return nil
+}
+
+// UpdateOCSPResponseWithContext implements RuntimeClient.UpdateOCSPResponseWithContext
+func (m *MockRuntimeClient) UpdateOCSPResponseWithContext(ctx context.Context, certificate string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.UpdateOCSPResponse(certificate)
+}
@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
@@ -59,6 +60,14 @@ func (m *MockStatsClient) GetStats() (*stats.HAProxyStats, error) {
 	return m.Stats, nil
 }
 
+// GetStatsWithContext implements StatsClient.GetStatsWithContext
+func (m *MockStatsClient) GetStatsWithContext(ctx context.Context) (*stats.HAProxyStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.GetStats()
+}
+
 // GetSchema implements StatsClient.GetSchema
 func (m *MockStatsClient) GetSchema() (*stats.StatsSchema, error) {
 	if m.FailGetSchema {
@@ -67,6 +76,14 @@ func (m *MockStatsClient) GetSchema() (*stats.StatsSchema, error) {
 	return m.Schema, nil
 }
 
+// GetSchemaWithContext implements StatsClient.GetSchemaWithContext
+func (m *MockStatsClient) GetSchemaWithContext(ctx context.Context) (*stats.StatsSchema, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.GetSchema()
+}
+
 // FilterStats implements StatsClient.FilterStats
 func (m *MockStatsClient) FilterStats(stats *stats.HAProxyStats, proxyName, serviceName string) []common.StatItem {
 	var filtered []common.StatItem
@@ -0,0 +1,173 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultApprovalTools lists the destructive tools gated by approval mode unless configured otherwise
+var DefaultApprovalTools = []string{
+	"del_server",
+	"clear_counters_all",
+	"reload_haproxy",
+	"disable_server",
+	"shutdown_session",
+	"shutdown_sessions_server",
+	"del_ssl_cert",
+	"del_crt_list_entry",
+	"execute_command",
+}
+
+// DefaultApprovalTTL is how long a pending change can be approved
+const DefaultApprovalTTL = 5 * time.Minute
+
+// pendingChange is a gated tool call waiting for approve_change
+type pendingChange struct {
+	Token       string                 `json:"token"`
+	Tool        string                 `json:"tool"`
+	Arguments   map[string]interface{} `json:"arguments"`
+	RequestedAt time.Time              `json:"requested_at"`
+	ExpiresAt   time.Time              `json:"expires_at"`
+
+	owner   string // Identity that requested the change; only it may approve
+	execute server.ToolHandlerFunc
+	request mcp.CallToolRequest
+}
+
+// ApprovalGate holds calls to destructive tools until they are approved with
+// approve_change, so that a human confirms each change before it reaches HAProxy
+type ApprovalGate struct {
+	mu      sync.Mutex
+	tools   []string // Tool name patterns requiring approval
+	ttl     time.Duration
+	pending map[string]*pendingChange
+	now     func() time.Time
+}
+
+// NewApprovalGate gates the tools matching the given patterns. Pending changes expire after ttl.
+func NewApprovalGate(tools []string, ttl time.Duration) *ApprovalGate {
+	if ttl <= 0 {
+		ttl = DefaultApprovalTTL
+	}
+	return &ApprovalGate{
+		tools:   tools,
+		ttl:     ttl,
+		pending: make(map[string]*pendingChange),
+		now:     time.Now,
+	}
+}
+
+// requiresApproval reports whether calls to tool are gated
+func (g *ApprovalGate) requiresApproval(tool string) bool {
+	if tool == "approve_change" {
+		return false
+	}
+	for _, pattern := range g.tools {
+		if ok, _ := path.Match(pattern, tool); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware turns calls to gated tools into pending changes
+func (g *ApprovalGate) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !g.requiresApproval(req.Params.Name) {
+				return next(ctx, req)
+			}
+
+			now := g.now()
+			change := &pendingChange{
+				Token:       uuid.New().String(),
+				Tool:        req.Params.Name,
+				Arguments:   req.Params.Arguments,
+				RequestedAt: now,
+				ExpiresAt:   now.Add(g.ttl),
+				owner:       sessionOwner(ctx),
+				execute:     next,
+				request:     req,
+			}
+
+			g.mu.Lock()
+			g.expire(ctx, now)
+			g.pending[change.Token] = change
+			g.mu.Unlock()
+
+			slog.InfoContext(ctx, "Change pending approval", "token", change.Token, "tool", change.Tool, "arguments", change.Arguments, "expires_at", change.ExpiresAt)
+			out, err := json.Marshal(map[string]interface{}{
+				"status":  "pending_approval",
+				"change":  change,
+				"message": fmt.Sprintf("%s requires approval. Confirm the change with the user, then call approve_change with this token before it expires.", change.Tool),
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to marshal pending change", "error", err)
+				return mcp.NewToolResultError("Internal server error: failed to marshal results"), nil
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		}
+	}
+}
+
+// expire drops pending changes past their deadline. Callers hold g.mu.
+func (g *ApprovalGate) expire(ctx context.Context, now time.Time) {
+	for token, change := range g.pending {
+		if now.After(change.ExpiresAt) {
+			delete(g.pending, token)
+			slog.InfoContext(ctx, "Pending change expired", "token", token, "tool", change.Tool)
+		}
+	}
+}
+
+// approve removes and returns the pending change for token if ctx may approve it
+func (g *ApprovalGate) approve(ctx context.Context, token string) (*pendingChange, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	change, ok := g.pending[token]
+	g.expire(ctx, now)
+	if !ok {
+		return nil, fmt.Errorf("no pending change with token %q", token)
+	}
+	if now.After(change.ExpiresAt) {
+		return nil, fmt.Errorf("change %s expired at %s", token, change.ExpiresAt.Format(time.RFC3339))
+	}
+	if change.owner != sessionOwner(ctx) {
+		return nil, fmt.Errorf("change %s can only be approved by the identity that requested it", token)
+	}
+	delete(g.pending, token)
+	return change, nil
+}
+
+// Register adds the approve_change tool to the server
+func (g *ApprovalGate) Register(s *server.MCPServer) {
+	approveChange := mcp.NewTool("approve_change",
+		mcp.WithDescription("Executes a change that is pending approval. Only call this after the user has confirmed the change."),
+		updateTool("Approve pending change", true, false),
+		mcp.WithString("token", mcp.Required(), mcp.Description("Token of the pending change")),
+	)
+	s.AddTool(approveChange, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		token := getString(req, "token")
+		slog.InfoContext(ctx, "Executing approve_change", "token", token)
+
+		change, err := g.approve(ctx, token)
+		if err != nil {
+			slog.WarnContext(ctx, "Change approval rejected", "token", token, "error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to approve change: %v", err)), nil
+		}
+
+		slog.InfoContext(ctx, "Change approved", "token", change.Token, "tool", change.Tool, "arguments", change.Arguments, "requested_at", change.RequestedAt)
+		return change.execute(ctx, change.request)
+	})
+}
@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+	haproxytesting "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/testing"
+)
+
+// pendingToken extracts the token of a pending change from a tool result
+func pendingToken(t *testing.T, text string) string {
+	t.Helper()
+
+	var pending struct {
+		Status string        `json:"status"`
+		Change pendingChange `json:"change"`
+	}
+	if err := json.Unmarshal([]byte(text), &pending); err != nil || pending.Status != "pending_approval" || pending.Change.Token == "" {
+		t.Fatalf("Expected a pending change, got %v %s", err, text)
+	}
+	return pending.Change.Token
+}
+
+// TestApprovalGate tests that gated tools only run once approved by the requester
+func TestApprovalGate(t *testing.T) {
+	client := haproxytesting.NewMockHAProxyClient()
+	runtime := client.RuntimeClient.(*haproxytesting.MockRuntimeClient)
+	gate := NewApprovalGate(DefaultApprovalTools, time.Minute)
+	now := time.Unix(1_800_000_000, 0)
+	gate.now = func() time.Time { return now }
+
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test",
+		server.WithToolCapabilities(true),
+		server.WithToolHandlerMiddleware(gate.Middleware()),
+	)
+	RegisterTools(s, client)
+	gate.Register(s)
+
+	alice := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "alice", Method: auth.MethodToken})
+	bob := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "bob", Method: auth.MethodToken})
+
+	// Ungated tools run immediately
+	if result := callToolWithContext(t, alice, s, "enable_server", map[string]interface{}{"backend": "web", "server": "web1"}); result.IsError || len(runtime.EnabledServers) != 1 {
+		t.Fatalf("Expected enable_server to run, got %s", resultText(t, result))
+	}
+
+	token := pendingToken(t, resultText(t, callToolWithContext(t, alice, s, "del_server", map[string]interface{}{"backend": "web", "name": "web1"})))
+	if len(runtime.DeletedServers) != 0 {
+		t.Fatal("Expected del_server to wait for approval")
+	}
+
+	result := callToolWithContext(t, bob, s, "approve_change", map[string]interface{}{"token": token})
+	if !result.IsError || !strings.Contains(resultText(t, result), "identity that requested it") {
+		t.Errorf("Expected another identity to be refused, got %s", resultText(t, result))
+	}
+
+	result = callToolWithContext(t, alice, s, "approve_change", map[string]interface{}{"token": token})
+	if result.IsError || len(runtime.DeletedServers) != 1 || runtime.DeletedServers[0]["name"] != "web1" {
+		t.Fatalf("Expected approved change to run, got %s %v", resultText(t, result), runtime.DeletedServers)
+	}
+
+	result = callToolWithContext(t, alice, s, "approve_change", map[string]interface{}{"token": token})
+	if !result.IsError || !strings.Contains(resultText(t, result), "no pending change") {
+		t.Errorf("Expected token to be single use, got %s", resultText(t, result))
+	}
+
+	token = pendingToken(t, resultText(t, callTool(t, s, "reload_haproxy", nil)))
+	now = now.Add(2 * time.Minute)
+	result = callTool(t, s, "approve_change", map[string]interface{}{"token": token})
+	if !result.IsError || !strings.Contains(resultText(t, result), "expired") || runtime.Reloads != 0 {
+		t.Errorf("Expected expired change to be refused, got %s", resultText(t, result))
+	}
+
+	// Approving any change drops the expired ones
+	pendingToken(t, resultText(t, callTool(t, s, "clear_counters_all", nil)))
+	now = now.Add(2 * time.Minute)
+	callTool(t, s, "approve_change", map[string]interface{}{"token": "unknown"})
+	if len(gate.pending) != 0 {
+		t.Errorf("Expected expired changes to be dropped, got %d pending", len(gate.pending))
+	}
+}
+
+// TestDefaultApprovalTools tests that every default gated tool is registered
+func TestDefaultApprovalTools(t *testing.T) {
+	result := handleRewritten(t, NewOutputRewriter(nil), `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+
+	registered := make(map[string]bool)
+	tools, _ := result["tools"].([]interface{})
+	for _, raw := range tools {
+		registered[raw.(map[string]interface{})["name"].(string)] = true
+	}
+	for _, name := range DefaultApprovalTools {
+		if !registered[name] {
+			t.Errorf("Default approval tool %q is not registered", name)
+		}
+	}
+}
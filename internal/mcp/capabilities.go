@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"sort"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+// toolCommands maps the tools relying on commands that older HAProxy
+// versions lack to those commands
+var toolCommands = map[string]string{
+	"debug_counters":       "debug counters",
+	"dump_stats_file":      "dump stats-file",
+	"add_server":           "add server",
+	"del_server":           "del server",
+	"list_ssl_certs":       "show ssl cert",
+	"show_ssl_cert":        "show ssl cert",
+	"new_ssl_cert":         "new ssl cert",
+	"set_ssl_cert":         "set ssl cert",
+	"commit_ssl_cert":      "commit ssl cert",
+	"abort_ssl_cert":       "abort ssl cert",
+	"del_ssl_cert":         "del ssl cert",
+	"cert_expiry_report":   "show ssl cert",
+	"list_ocsp_responses":  "show ssl ocsp-response",
+	"show_ocsp_response":   "show ssl ocsp-response",
+	"update_ocsp_response": "update ssl ocsp-response",
+	"list_crt_lists":       "show ssl crt-list",
+	"show_crt_list":        "show ssl crt-list",
+	"add_crt_list_entry":   "add ssl crt-list",
+	"del_crt_list_entry":   "del ssl crt-list",
+	"list_ca_files":        "show ssl ca-file",
+	"show_ca_file":         "show ssl ca-file",
+	"new_ca_file":          "new ssl ca-file",
+	"set_ca_file":          "set ssl ca-file",
+	"add_ca_file":          "add ssl ca-file",
+	"commit_ca_file":       "commit ssl ca-file",
+	"abort_ca_file":        "abort ssl ca-file",
+	"list_crl_files":       "show ssl crl-file",
+	"show_crl_file":        "show ssl crl-file",
+	"set_crl_file":         "set ssl crl-file",
+	"commit_crl_file":      "commit ssl crl-file",
+	"abort_crl_file":       "abort ssl crl-file",
+}
+
+// RemoveUnsupportedTools removes the tools whose commands the connected
+// HAProxy does not support, so clients only see tools that can work, and
+// returns the names of the removed tools
+func RemoveUnsupportedTools(s *server.MCPServer, capabilities *runtimeclient.Capabilities) []string {
+	var removed []string
+	for tool, command := range toolCommands {
+		if !capabilities.Supports(command) {
+			removed = append(removed, tool)
+		}
+	}
+	sort.Strings(removed)
+	if len(removed) > 0 {
+		s.DeleteTools(removed...)
+	}
+	return removed
+}
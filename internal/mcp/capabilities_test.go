@@ -0,0 +1,33 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+// TestRemoveUnsupportedTools tests that tools needing newer commands are removed
+func TestRemoveUnsupportedTools(t *testing.T) {
+	s, _, _ := newTestServer(t)
+
+	removed := RemoveUnsupportedTools(s, runtimeclient.NewCapabilities("2.2.14", ""))
+	for _, tool := range []string{"add_server", "list_ca_files", "set_crl_file", "update_ocsp_response", "debug_counters"} {
+		if !strings.Contains(","+strings.Join(removed, ",")+",", ","+tool+",") {
+			t.Errorf("Expected %s to be removed, got %v", tool, removed)
+		}
+	}
+	for _, tool := range removed {
+		if tool == "list_ssl_certs" || tool == "add_crt_list_entry" {
+			t.Errorf("Expected %s to be kept on HAProxy 2.2", tool)
+		}
+	}
+
+	result := callTool(t, s, "list_ssl_certs", map[string]interface{}{})
+	if result.IsError {
+		t.Errorf("Expected list_ssl_certs to remain, got %s", resultText(t, result))
+	}
+	if removed := RemoveUnsupportedTools(s, runtimeclient.NewCapabilities("", "")); len(removed) != 0 {
+		t.Errorf("Expected no tools removed for an unknown version, got %v", removed)
+	}
+}
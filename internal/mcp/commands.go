@@ -0,0 +1,247 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
+)
+
+// DefaultCommandDeny lists the command prefixes execute_command rejects unless configured otherwise
+var DefaultCommandDeny = []string{
+	"prompt",              // Switches the connection to interactive mode
+	"quit",                // Closes the connection
+	"expert-mode",         // Unlocks commands that can crash the process
+	"experimental-mode",   // Unlocks unsupported commands
+	"mcli-debug-mode",     // Unlocks debugging of the master process
+	"debug",               // "debug dev" can crash or hang the process
+	"shutdown",            // Kills frontends and sessions
+	"set severity-output", // Changes the response format the client parses
+}
+
+// readCommands are the first words of the commands that only read HAProxy state
+var readCommands = map[string]bool{
+	"show": true,
+	"get":  true,
+	"help": true,
+	"echo": true,
+}
+
+// commandTargets lists the commands acting on a proxy, whose name follows the
+// command words, or on a server, given as <backend>/<server>
+var commandTargets = []struct {
+	prefix string
+	server bool
+}{
+	{"set server", true},
+	{"get weight", true},
+	{"set weight", true},
+	{"enable server", true},
+	{"disable server", true},
+	{"enable health", true},
+	{"disable health", true},
+	{"enable agent", true},
+	{"disable agent", true},
+	{"add server", true},
+	{"del server", true},
+	{"set maxconn server", true},
+	{"shutdown sessions server", true},
+	{"show servers state", false},
+	{"show servers conn", false},
+	{"show stat", false},
+	{"show errors", false},
+	{"show table", false},
+	{"clear table", false},
+	{"set table", false},
+	{"enable frontend", false},
+	{"disable frontend", false},
+	{"shutdown frontend", false},
+	{"set maxconn frontend", false},
+	{"enable dynamic-cookie backend", false},
+	{"disable dynamic-cookie backend", false},
+	{"set dynamic-cookie-key backend", false},
+}
+
+// commandRequests describes each ';'-separated command of a raw command for
+// policy evaluation. Commands not naming a proxy or server act on the whole
+// process, so they are authorized as spanning every backend.
+func commandRequests(command string) []auth.Request {
+	var requests []auth.Request
+	for _, part := range strings.Split(command, ";") {
+		words := strings.Fields(part)
+		if len(words) > 0 && strings.HasPrefix(words[0], "@") {
+			words = words[1:]
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		r := auth.Request{Tool: "execute_command"}
+		lower := strings.Fields(strings.ToLower(strings.Join(words, " ")))
+		for _, target := range commandTargets {
+			n := len(strings.Fields(target.prefix))
+			if !matchesCommand(lower, target.prefix) || len(words) <= n {
+				continue
+			}
+			r.Backend = words[n]
+			if target.server {
+				r.Backend, r.Server, _ = strings.Cut(words[n], "/")
+			}
+			if optionalProxyCommands[target.prefix] {
+				r.Backend = optionalProxy(words[n:])
+			}
+			break
+		}
+		r.AllBackends = r.Backend == ""
+		requests = append(requests, r)
+	}
+	return requests
+}
+
+// optionalProxyCommands are the commandTargets whose proxy may be omitted or
+// given as an ID instead of a name
+var optionalProxyCommands = map[string]bool{
+	"show stat":   true,
+	"show errors": true,
+}
+
+// proxyOptions are the words that may follow "show stat" or "show errors" in
+// place of a proxy name
+var proxyOptions = map[string]bool{
+	"domain":   true,
+	"typed":    true,
+	"json":     true,
+	"desc":     true,
+	"up":       true,
+	"no-maint": true,
+	"request":  true,
+	"response": true,
+}
+
+// optionalProxy returns the proxy name starting the arguments of "show stat"
+// or "show errors", or "" when they start with a proxy ID such as "-1" or
+// "#2", or with an option instead of a proxy
+func optionalProxy(args []string) string {
+	if len(args) >= 2 && strings.EqualFold(args[0], "domain") {
+		args = args[2:]
+	}
+	if len(args) == 0 || proxyOptions[strings.ToLower(args[0])] || strings.HasPrefix(args[0], "#") {
+		return ""
+	}
+	if _, err := strconv.Atoi(args[0]); err == nil {
+		return ""
+	}
+	return args[0]
+}
+
+// CommandGuard decides which raw Runtime API commands execute_command may
+// send. A command is rejected when it matches a denied prefix, when an
+// allowlist is set and it matches none of its prefixes, or when it changes
+// state and writes are not allowed. Prefixes match whole words, so "set
+// weight" matches "set weight app/web1 50" but not "set weights".
+type CommandGuard struct {
+	allow       []string
+	deny        []string
+	allowWrites bool
+}
+
+// NewCommandGuard allows the commands matching allow, or every command when
+// allow is empty, except those matching deny. Commands other than show, get,
+// help and echo are only allowed with allowWrites.
+func NewCommandGuard(allow, deny []string, allowWrites bool) *CommandGuard {
+	return &CommandGuard{allow: allow, deny: deny, allowWrites: allowWrites}
+}
+
+// Check returns an error if command may not be sent. HAProxy runs each of the
+// ';'-separated commands of a line, so each one is checked.
+func (g *CommandGuard) Check(command string) error {
+	if strings.ContainsAny(command, "\r\n") {
+		return fmt.Errorf("command must be a single line; use the dedicated tools for commands with a payload")
+	}
+
+	checked := 0
+	for _, part := range strings.Split(command, ";") {
+		words := strings.Fields(strings.ToLower(part))
+		if len(words) > 0 && strings.HasPrefix(words[0], "@") {
+			words = words[1:] // Master CLI routing prefix, e.g. "@1 show info"
+		}
+		if len(words) == 0 {
+			continue
+		}
+		checked++
+
+		name := strings.Join(words, " ")
+		for _, prefix := range g.deny {
+			if matchesCommand(words, prefix) {
+				return fmt.Errorf("command %q is denied by %q", name, prefix)
+			}
+		}
+		if len(g.allow) > 0 && !g.allowed(words) {
+			return fmt.Errorf("command %q is not in the allowlist", name)
+		}
+		if !readCommands[words[0]] && !g.allowWrites {
+			return fmt.Errorf("command %q changes HAProxy state and write commands are disabled", name)
+		}
+	}
+	if checked == 0 {
+		return fmt.Errorf("command is required")
+	}
+	return nil
+}
+
+// allowed reports whether words match a prefix of the allowlist
+func (g *CommandGuard) allowed(words []string) bool {
+	for _, prefix := range g.allow {
+		if matchesCommand(words, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesCommand reports whether the words of a command start with the words of prefix
+func matchesCommand(words []string, prefix string) bool {
+	prefixWords := strings.Fields(strings.ToLower(prefix))
+	if len(prefixWords) == 0 || len(prefixWords) > len(words) {
+		return false
+	}
+	for i, word := range prefixWords {
+		if words[i] != word {
+			return false
+		}
+	}
+	return true
+}
+
+// Register adds the execute_command tool to the server. With a policy, each
+// command must also be granted on the proxy or server it names.
+func (g *CommandGuard) Register(s *server.MCPServer, client haproxy.Client, policy *auth.Policy) {
+	executeCommand := mcp.NewTool("execute_command",
+		mcp.WithDescription("Sends a raw HAProxy Runtime API command and returns its output, for commands no other tool covers. Commands are checked against the server's allowlist and denylist, and commands other than show, get, help and echo are rejected unless write commands are enabled."),
+		updateTool("Execute Runtime API command", true, false),
+		mcp.WithString("command", mcp.Required(), mcp.Description("Runtime API command, e.g. show pools")),
+	)
+	s.AddTool(executeCommand, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		command := getString(req, "command")
+		slog.InfoContext(ctx, "Executing execute_command", "command", command)
+		return callJSON(ctx, "execute command", "output", func() (interface{}, error) {
+			if err := g.Check(command); err != nil {
+				slog.WarnContext(ctx, "Command rejected", "command", command, "error", err)
+				return nil, err
+			}
+			for _, r := range commandRequests(command) {
+				if err := authorize(ctx, policy, r); err != nil {
+					return nil, err
+				}
+			}
+			return client.ExecuteRuntimeCommandWithContext(ctx, command)
+		})
+	})
+}
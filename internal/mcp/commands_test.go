@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+	haproxytesting "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/testing"
+)
+
+// TestCommandGuard tests the allowlist, denylist and write checks of raw commands
+func TestCommandGuard(t *testing.T) {
+	testCases := []struct {
+		name    string
+		guard   *CommandGuard
+		command string
+		err     string
+	}{
+		{name: "Read", guard: NewCommandGuard(nil, DefaultCommandDeny, false), command: "show pools"},
+		{name: "Master CLI prefix", guard: NewCommandGuard(nil, DefaultCommandDeny, false), command: "@1 show info"},
+		{name: "Write disabled", guard: NewCommandGuard(nil, DefaultCommandDeny, false), command: "set weight app/web1 50", err: "write commands are disabled"},
+		{name: "Write enabled", guard: NewCommandGuard(nil, DefaultCommandDeny, true), command: "set weight app/web1 50"},
+		{name: "Denied", guard: NewCommandGuard(nil, DefaultCommandDeny, true), command: "SHUTDOWN sessions server app/web1", err: `denied by "shutdown"`},
+		{name: "Denied after separator", guard: NewCommandGuard(nil, DefaultCommandDeny, true), command: "show info; expert-mode on", err: `denied by "expert-mode"`},
+		{name: "Multi-word deny", guard: NewCommandGuard(nil, DefaultCommandDeny, true), command: "set severity-output number", err: "denied"},
+		{name: "Allowed", guard: NewCommandGuard([]string{"show table", "clear table"}, nil, true), command: "clear table sticky key 10.0.0.1"},
+		{name: "Not allowed", guard: NewCommandGuard([]string{"show table"}, nil, true), command: "show tables", err: "not in the allowlist"},
+		{name: "Deny wins over allow", guard: NewCommandGuard([]string{"show"}, []string{"show sess"}, false), command: "show sess all", err: "denied"},
+		{name: "Payload", guard: NewCommandGuard(nil, nil, true), command: "set ssl cert a.pem <<\n-----BEGIN", err: "single line"},
+		{name: "Empty", guard: NewCommandGuard(nil, nil, true), command: " ; ", err: "command is required"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.guard.Check(tc.command)
+			if tc.err == "" && err != nil {
+				t.Errorf("Expected %q to be allowed, got %v", tc.command, err)
+			}
+			if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+				t.Errorf("Expected %q to be rejected with %q, got %v", tc.command, tc.err, err)
+			}
+		})
+	}
+}
+
+// TestExecuteCommandTool tests that execute_command only sends commands the guard allows
+func TestExecuteCommandTool(t *testing.T) {
+	s, _, runtime := newTestServer(t)
+	runtime.CommandResponses["show pools"] = "Dumping pools usage."
+
+	result := callTool(t, s, "execute_command", map[string]interface{}{"command": "show pools"})
+	if result.IsError || !strings.Contains(resultText(t, result), "Dumping pools usage.") {
+		t.Errorf("Expected the command output, got %s", resultText(t, result))
+	}
+
+	result = callTool(t, s, "execute_command", map[string]interface{}{"command": "disable server app/web1"})
+	if !result.IsError || !strings.Contains(resultText(t, result), "write commands are disabled") {
+		t.Errorf("Expected the write command to be rejected, got %s", resultText(t, result))
+	}
+	if len(runtime.ExecutedCommands) != 1 {
+		t.Errorf("Expected only the allowed command to be sent, got %v", runtime.ExecutedCommands)
+	}
+}
+
+// TestCommandRequests tests the proxies and servers read from raw commands for policy evaluation
+func TestCommandRequests(t *testing.T) {
+	testCases := []struct {
+		command string
+		want    []auth.Request
+	}{
+		{command: "set weight teamA-web/web1 50", want: []auth.Request{{Tool: "execute_command", Backend: "teamA-web", Server: "web1"}}},
+		{command: "@1 SET MAXCONN SERVER teamA-web/web1 10", want: []auth.Request{{Tool: "execute_command", Backend: "teamA-web", Server: "web1"}}},
+		{command: "disable frontend teamA-fe", want: []auth.Request{{Tool: "execute_command", Backend: "teamA-fe"}}},
+		{command: "show info", want: []auth.Request{{Tool: "execute_command", AllBackends: true}}},
+		{command: "show servers state", want: []auth.Request{{Tool: "execute_command", AllBackends: true}}},
+		{command: "show stat json", want: []auth.Request{{Tool: "execute_command", AllBackends: true}}},
+		{command: "show stat -1 4 -1 typed", want: []auth.Request{{Tool: "execute_command", AllBackends: true}}},
+		{command: "show stat domain proxy teamA-web", want: []auth.Request{{Tool: "execute_command", Backend: "teamA-web"}}},
+		{command: "show errors #3 request", want: []auth.Request{{Tool: "execute_command", AllBackends: true}}},
+		{command: "show errors teamA-web response", want: []auth.Request{{Tool: "execute_command", Backend: "teamA-web"}}},
+		{command: "show stat teamA-web; del server teamB/x", want: []auth.Request{
+			{Tool: "execute_command", Backend: "teamA-web"},
+			{Tool: "execute_command", Backend: "teamB", Server: "x"},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.command, func(t *testing.T) {
+			got := commandRequests(tc.command)
+			if len(got) != len(tc.want) {
+				t.Fatalf("Expected %v, got %v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("Expected %+v, got %+v", tc.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+// TestExecuteCommandPolicy tests that raw commands are authorized on the backends they name
+func TestExecuteCommandPolicy(t *testing.T) {
+	policy := &auth.Policy{Rules: []auth.PolicyRule{{
+		Groups:   []string{"team-a"},
+		Tools:    []string{"execute_command"},
+		Backends: []string{"teamA-*"},
+	}}}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Invalid policy: %v", err)
+	}
+
+	client := haproxytesting.NewMockHAProxyClient()
+	runtime := client.RuntimeClient.(*haproxytesting.MockRuntimeClient)
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test",
+		server.WithToolCapabilities(true),
+		server.WithToolHandlerMiddleware(PolicyMiddleware(policy)),
+	)
+	NewCommandGuard(nil, DefaultCommandDeny, true).Register(s, client, policy)
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "alice", Method: auth.MethodToken, Groups: []string{"team-a"}})
+
+	result := callToolWithContext(t, ctx, s, "execute_command", map[string]interface{}{"command": "disable server teamA-web/web1"})
+	if result.IsError {
+		t.Errorf("Expected own backend to be allowed, got %s", resultText(t, result))
+	}
+
+	for _, command := range []string{
+		"disable server teamB-web/web1",
+		"show stat teamA-web; del server teamB/x",
+		"show info",
+		"show stat json",
+		"show stat typed",
+		"show stat -1 -1 -1",
+		"show stat 2",
+		"show stat domain proxy",
+		"show errors -1",
+		"show errors response",
+	} {
+		result := callToolWithContext(t, ctx, s, "execute_command", map[string]interface{}{"command": command})
+		if !result.IsError || !strings.Contains(resultText(t, result), "permission denied") {
+			t.Errorf("Expected %q to be denied, got %s", command, resultText(t, result))
+		}
+	}
+	if len(runtime.ExecutedCommands) != 1 {
+		t.Errorf("Expected only the allowed command to be sent, got %v", runtime.ExecutedCommands)
+	}
+}
@@ -0,0 +1,430 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
+)
+
+const (
+	// methodCompletionComplete is the MCP method requesting argument completions
+	methodCompletionComplete = "completion/complete"
+
+	// completionIDPrefix marks the IDs of completion requests rewritten into pings
+	completionIDPrefix = "haproxy-mcp-server/completion/"
+
+	// maxCompletionValues is the most values a completion result may carry
+	maxCompletionValues = 100
+
+	// completionAnswerTTL bounds how long an answer waits for its ping response
+	completionAnswerTTL = time.Minute
+)
+
+// DefaultCompletionCacheTTL is how long listed names are reused for completions
+const DefaultCompletionCacheTTL = 30 * time.Second
+
+// completionSource lists the candidate values of an argument. arguments holds
+// the arguments the client has already filled in.
+type completionSource func(ctx context.Context, c *Completions, arguments map[string]string) ([]string, error)
+
+// promptCompletions lists the completable arguments of each prompt
+var promptCompletions = map[string]map[string]completionSource{
+	backend5xxPrompt:       {"backend": backendNames},
+	drainServerPrompt:      {"backend": backendNames, "server": serverNames("backend")},
+	frontendCapacityPrompt: {"frontend": frontendNames},
+}
+
+// resourceCompletions lists the completable variables of each resource template
+var resourceCompletions = map[string]map[string]completionSource{
+	backendResourceTemplate: {"name": backendNames},
+	serverResourceTemplate:  {"name": backendNames, "server": serverNames("name")},
+	mapResourceTemplate:     {"id": mapIDs},
+}
+
+// Completions answers completion/complete requests for prompt arguments and
+// resource template variables naming backends, servers, frontends and maps.
+// Names are listed from HAProxy and cached, so completing as the user types
+// does not query HAProxy on every keystroke.
+//
+// mcp-go v0.25 does not route completion/complete, so requests are answered
+// at the transport: each request is handed to the MCP server as a ping under a
+// unique ID, and the ping's response is replaced with the completion result.
+// Initialize responses are rewritten to advertise the completions capability.
+//
+// With a policy, only the names the caller may read are offered.
+type Completions struct {
+	client haproxy.Client
+	ttl    time.Duration
+	policy *auth.Policy
+	now    func() time.Time
+
+	mu      sync.Mutex
+	cache   map[string]cachedNames
+	answers map[string]completionAnswer // Keyed by the rewritten request ID
+}
+
+// cachedNames is a listing of names and when it goes stale
+type cachedNames struct {
+	names   []string
+	expires time.Time
+}
+
+// completionAnswer is the response awaiting a rewritten completion request
+type completionAnswer struct {
+	id      json.RawMessage // ID of the client's request
+	result  mcp.CompleteResult
+	created time.Time
+}
+
+// NewCompletions completes names listed from client, caching them for ttl.
+// policy, if not nil, filters the names by caller.
+func NewCompletions(client haproxy.Client, ttl time.Duration, policy *auth.Policy) *Completions {
+	if ttl <= 0 {
+		ttl = DefaultCompletionCacheTTL
+	}
+	return &Completions{
+		client:  client,
+		ttl:     ttl,
+		policy:  policy,
+		now:     time.Now,
+		cache:   make(map[string]cachedNames),
+		answers: make(map[string]completionAnswer),
+	}
+}
+
+// completionRequest is a completion/complete request. Params.Context carries
+// previously filled arguments, which mcp.CompleteRequest does not expose.
+type completionRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  struct {
+		Ref struct {
+			Type string `json:"type"`
+			Name string `json:"name"`
+			URI  string `json:"uri"`
+		} `json:"ref"`
+		Argument struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"argument"`
+		Context struct {
+			Arguments map[string]string `json:"arguments"`
+		} `json:"context"`
+	} `json:"params"`
+}
+
+// Intercept answers completion requests in message, a JSON-RPC message or
+// batch, and returns the message to hand to the MCP server
+func (c *Completions) Intercept(ctx context.Context, message []byte) []byte {
+	if !bytes.Contains(message, []byte(methodCompletionComplete)) {
+		return message
+	}
+	return eachMessage(message, func(m []byte) []byte {
+		return c.interceptMessage(ctx, m)
+	})
+}
+
+// interceptMessage handles a single JSON-RPC message for Intercept
+func (c *Completions) interceptMessage(ctx context.Context, message []byte) []byte {
+	var request completionRequest
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil || request.Method != methodCompletionComplete {
+		return message
+	}
+
+	answer := completionAnswer{id: request.ID, result: c.complete(ctx, &request), created: c.now()}
+	id := completionIDPrefix + uuid.New().String()
+	rewritten, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": request.JSONRPC,
+		"id":      id,
+		"method":  mcp.MethodPing,
+	})
+	if err != nil {
+		return message
+	}
+
+	c.mu.Lock()
+	for key, a := range c.answers {
+		if answer.created.Sub(a.created) > completionAnswerTTL {
+			delete(c.answers, key)
+		}
+	}
+	c.answers[id] = answer
+	c.mu.Unlock()
+	return rewritten
+}
+
+// complete returns the values completing the requested argument. Unknown
+// arguments and failed lookups complete to nothing.
+func (c *Completions) complete(ctx context.Context, request *completionRequest) mcp.CompleteResult {
+	var result mcp.CompleteResult
+	result.Completion.Values = []string{}
+
+	var sources map[string]completionSource
+	ref := request.Params.Ref
+	switch ref.Type {
+	case "ref/prompt":
+		sources = promptCompletions[ref.Name]
+	case "ref/resource":
+		sources = resourceCompletions[ref.URI]
+	}
+	source, ok := sources[request.Params.Argument.Name]
+	if !ok {
+		return result
+	}
+
+	names, err := source(ctx, c, request.Params.Context.Arguments)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to list completion values", "ref", ref.Name+ref.URI, "argument", request.Params.Argument.Name, "error", err)
+		return result
+	}
+
+	prefix := strings.ToLower(request.Params.Argument.Value)
+	for _, name := range names {
+		if strings.HasPrefix(strings.ToLower(name), prefix) {
+			result.Completion.Total++
+			if len(result.Completion.Values) < maxCompletionValues {
+				result.Completion.Values = append(result.Completion.Values, name)
+			}
+		}
+	}
+	result.Completion.HasMore = result.Completion.Total > len(result.Completion.Values)
+	return result
+}
+
+// cached returns the names stored under key, listing them with list when missing or stale
+func (c *Completions) cached(key string, list func() ([]string, error)) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.names, nil
+	}
+
+	names, err := list()
+	if err != nil {
+		return nil, err
+	}
+	names = append([]string(nil), names...)
+	sort.Strings(names)
+
+	c.mu.Lock()
+	c.cache[key] = cachedNames{names: names, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return names, nil
+}
+
+// allowed returns the names for which the caller of ctx may make the request
+// built by access. Denials are not logged, as completions only hide names.
+func (c *Completions) allowed(ctx context.Context, names []string, access func(name string) auth.Request) []string {
+	if c.policy == nil {
+		return names
+	}
+	identity, _ := auth.FromContext(ctx)
+	filtered := make([]string, 0, len(names))
+	for _, name := range names {
+		if c.policy.Authorize(identity, access(name)) == nil {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
+}
+
+// backendNames lists the backend names the caller may read
+func backendNames(ctx context.Context, c *Completions, _ map[string]string) ([]string, error) {
+	names, err := c.cached("backends", func() ([]string, error) {
+		return c.client.GetBackendsWithContext(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.allowed(ctx, names, func(name string) auth.Request {
+		return auth.Request{Tool: "get_backend", Backend: name}
+	}), nil
+}
+
+// serverNames lists the servers of the backend named by the backendArg
+// argument, or of every backend when it is not filled in yet
+func serverNames(backendArg string) completionSource {
+	return func(ctx context.Context, c *Completions, arguments map[string]string) ([]string, error) {
+		backends := []string{arguments[backendArg]}
+		if backends[0] == "" {
+			var err error
+			if backends, err = backendNames(ctx, c, arguments); err != nil {
+				return nil, err
+			}
+		}
+
+		seen := make(map[string]bool)
+		var names []string
+		for _, backend := range backends {
+			servers, err := c.cached("servers/"+backend, func() ([]string, error) {
+				return c.client.ListServersWithContext(ctx, backend)
+			})
+			if err != nil {
+				return nil, err
+			}
+			servers = c.allowed(ctx, servers, func(name string) auth.Request {
+				return auth.Request{Tool: "get_server", Backend: backend, Server: name}
+			})
+			for _, name := range servers {
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+}
+
+// frontendNames lists the frontend names the caller may read from the show stat FRONTEND rows
+func frontendNames(ctx context.Context, c *Completions, _ map[string]string) ([]string, error) {
+	names, err := c.cached("frontends", func() ([]string, error) {
+		rows, err := c.client.ShowStatWithContext(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, row := range rows {
+			if row["svname"] == "FRONTEND" {
+				names = append(names, row["pxname"])
+			}
+		}
+		return names, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.allowed(ctx, names, func(name string) auth.Request {
+		return auth.Request{Tool: "show_stat", Backend: name}
+	}), nil
+}
+
+// mapIDs lists the numeric IDs of the loaded maps, if the caller may read maps
+func mapIDs(ctx context.Context, c *Completions, _ map[string]string) ([]string, error) {
+	ids, err := c.cached("maps", func() ([]string, error) {
+		maps, err := c.client.ListMapsWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(maps))
+		for _, m := range maps {
+			ids = append(ids, m.ID)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.allowed(ctx, ids, func(string) auth.Request {
+		return auth.Request{Tool: "show_map", Unscoped: true}
+	}), nil
+}
+
+// Rewrite replaces the responses to rewritten completion requests in message,
+// a JSON-RPC response or batch, and advertises the completions capability in
+// initialize responses. Other messages are returned unchanged.
+func (c *Completions) Rewrite(message []byte) []byte {
+	if !bytes.Contains(message, []byte(completionIDPrefix)) && !bytes.Contains(message, []byte(`"protocolVersion"`)) {
+		return message
+	}
+	return eachMessage(message, c.rewriteMessage)
+}
+
+// rewriteMessage rewrites a single JSON-RPC response for Rewrite
+func (c *Completions) rewriteMessage(message []byte) []byte {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(message, &response); err != nil {
+		return message
+	}
+
+	var id string
+	if err := json.Unmarshal(response["id"], &id); err == nil && strings.HasPrefix(id, completionIDPrefix) {
+		c.mu.Lock()
+		answer, ok := c.answers[id]
+		delete(c.answers, id)
+		c.mu.Unlock()
+		if !ok {
+			return message
+		}
+		out, err := json.Marshal(mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPC_VERSION, ID: answer.id, Result: answer.result})
+		if err != nil {
+			return message
+		}
+		return out
+	}
+
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(response["result"], &result); err != nil || result["protocolVersion"] == nil {
+		return message
+	}
+	var capabilities map[string]json.RawMessage
+	if err := json.Unmarshal(result["capabilities"], &capabilities); err != nil || capabilities == nil {
+		capabilities = make(map[string]json.RawMessage)
+	}
+	capabilities["completions"] = json.RawMessage(`{}`)
+
+	out, err := json.Marshal(capabilities)
+	if err != nil {
+		return message
+	}
+	result["capabilities"] = out
+	if out, err = json.Marshal(result); err != nil {
+		return message
+	}
+	response["result"] = out
+	if out, err = json.Marshal(response); err != nil {
+		return message
+	}
+	return out
+}
+
+// StdioReader wraps the stdio input so completion requests are answered
+// before the stdio server reads them
+func (c *Completions) StdioReader(r io.Reader) io.Reader {
+	return rewriteLines(r, func(line []byte) []byte {
+		return c.Intercept(context.Background(), line)
+	})
+}
+
+// Writer wraps the stdio output, delivering completion results
+func (c *Completions) Writer(w io.Writer) io.Writer {
+	return outputLineWriter{w: w, rewrite: c.Rewrite}
+}
+
+// HTTPMiddleware answers completion requests posted over HTTP and delivers
+// their results in the JSON or SSE responses of the transports
+func (c *Completions) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			_ = r.Body.Close()
+			if err != nil {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			body = c.Intercept(r.Context(), body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+		}
+
+		rw := &outputResponseWriter{ResponseWriter: w, rewrite: c.Rewrite}
+		next.ServeHTTP(rw, r)
+		rw.finish()
+	})
+}
@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	haproxytesting "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/testing"
+)
+
+// completionResult is the decoded response to a completion request
+type completionResult struct {
+	ID     interface{} `json:"id"`
+	Result struct {
+		Completion struct {
+			Values  []string `json:"values"`
+			Total   int      `json:"total"`
+			HasMore bool     `json:"hasMore"`
+		} `json:"completion"`
+	} `json:"result"`
+}
+
+// completionServer returns an MCP server with the prompts and resources, and completions over the mock client
+func completionServer(t *testing.T) (*server.MCPServer, *Completions, *haproxytesting.MockRuntimeClient) {
+	t.Helper()
+
+	client := haproxytesting.NewMockHAProxyClient()
+	client.StatsClient = nil // Frontends come from the runtime stat rows
+	runtime := client.RuntimeClient.(*haproxytesting.MockRuntimeClient)
+	runtime.StatRows = append(runtime.StatRows, map[string]string{"pxname": "http-in", "svname": "FRONTEND", "status": "OPEN"})
+	runtime.Servers = map[string][]string{"backend1": {"web1", "web2"}, "backend2": {"api1"}}
+
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithResourceCapabilities(true, false), server.WithPromptCapabilities(false))
+	RegisterResources(s, client, nil)
+	RegisterPrompts(s, client, nil)
+	return s, NewCompletions(client, time.Minute, nil), runtime
+}
+
+// complete sends a completion request through the completions and the MCP server
+func complete(t *testing.T, s *server.MCPServer, c *Completions, params string) completionResult {
+	t.Helper()
+
+	request := c.Intercept(context.Background(), []byte(`{"jsonrpc":"2.0","id":"req-1","method":"completion/complete","params":`+params+`}`))
+	response, err := json.Marshal(s.HandleMessage(context.Background(), request))
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	var decoded completionResult
+	if err := json.Unmarshal(c.Rewrite(response), &decoded); err != nil {
+		t.Fatalf("Invalid completion response: %v", err)
+	}
+	if decoded.ID != "req-1" || decoded.Result.Completion.Values == nil {
+		t.Fatalf("Expected a completion result for req-1, got %s", c.Rewrite(response))
+	}
+	return decoded
+}
+
+// TestCompletions tests completion of prompt arguments and resource template variables
+func TestCompletions(t *testing.T) {
+	s, c, _ := completionServer(t)
+
+	testCases := []struct {
+		name   string
+		params string
+		values string
+	}{
+		{name: "Prompt backend", params: `{"ref":{"type":"ref/prompt","name":"investigate_backend_5xx"},"argument":{"name":"backend","value":""}}`, values: "backend1,backend2"},
+		{name: "Prefix", params: `{"ref":{"type":"ref/prompt","name":"investigate_backend_5xx"},"argument":{"name":"backend","value":"BACKEND2"}}`, values: "backend2"},
+		{name: "Server of context backend", params: `{"ref":{"type":"ref/prompt","name":"drain_server"},"argument":{"name":"server","value":"w"},"context":{"arguments":{"backend":"backend1"}}}`, values: "web1,web2"},
+		{name: "Servers of all backends", params: `{"ref":{"type":"ref/prompt","name":"drain_server"},"argument":{"name":"server","value":""}}`, values: "api1,web1,web2"},
+		{name: "Frontend", params: `{"ref":{"type":"ref/prompt","name":"frontend_capacity_review"},"argument":{"name":"frontend","value":"h"}}`, values: "http-in"},
+		{name: "Resource server", params: `{"ref":{"type":"ref/resource","uri":"haproxy://backends/{name}/servers/{server}"},"argument":{"name":"server","value":""},"context":{"arguments":{"name":"backend2"}}}`, values: "api1"},
+		{name: "Resource map", params: `{"ref":{"type":"ref/resource","uri":"haproxy://maps/{id}"},"argument":{"name":"id","value":""}}`, values: "0"},
+		{name: "Unknown argument", params: `{"ref":{"type":"ref/prompt","name":"drain_server"},"argument":{"name":"reason","value":""}}`, values: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := complete(t, s, c, tc.params)
+			if values := strings.Join(got.Result.Completion.Values, ","); values != tc.values {
+				t.Errorf("Expected %q, got %q", tc.values, values)
+			}
+		})
+	}
+}
+
+// TestCompletionLimits tests the value cap and the name cache
+func TestCompletionLimits(t *testing.T) {
+	s, c, runtime := completionServer(t)
+
+	runtime.Backends = nil
+	for i := 0; i < 150; i++ {
+		runtime.Backends = append(runtime.Backends, fmt.Sprintf("be%03d", i))
+	}
+	params := `{"ref":{"type":"ref/resource","uri":"haproxy://backends/{name}"},"argument":{"name":"name","value":"be"}}`
+	got := complete(t, s, c, params)
+	if len(got.Result.Completion.Values) != maxCompletionValues || got.Result.Completion.Total != 150 || !got.Result.Completion.HasMore {
+		t.Errorf("Expected %d of 150 values, got %d of %d", maxCompletionValues, len(got.Result.Completion.Values), got.Result.Completion.Total)
+	}
+
+	// Cached names are served until they expire
+	runtime.FailListBackends = true
+	if got := complete(t, s, c, params); got.Result.Completion.Total != 150 {
+		t.Errorf("Expected cached backends, got %d", got.Result.Completion.Total)
+	}
+	c.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if got := complete(t, s, c, params); got.Result.Completion.Total != 0 {
+		t.Errorf("Expected no values once the cache expired and listing fails, got %d", got.Result.Completion.Total)
+	}
+}
+
+// TestCompletionTransports tests the capability advertisement and the HTTP middleware
+func TestCompletionTransports(t *testing.T) {
+	s, c, _ := completionServer(t)
+
+	initialize, err := json.Marshal(s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)))
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	if !strings.Contains(string(c.Rewrite(initialize)), `"completions":{}`) {
+		t.Errorf("Expected the completions capability, got %s", c.Rewrite(initialize))
+	}
+
+	handler := c.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Invalid request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.HandleMessage(r.Context(), body))
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(
+		`{"jsonrpc":"2.0","id":5,"method":"completion/complete","params":{"ref":{"type":"ref/prompt","name":"drain_server"},"argument":{"name":"backend","value":"backend1"}}}`)))
+	if body := rec.Body.String(); !strings.Contains(body, `"id":5`) || !strings.Contains(body, `"values":["backend1"]`) {
+		t.Errorf("Unexpected HTTP response: %s", body)
+	}
+}
@@ -0,0 +1,42 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// readinessTimeout bounds the HAProxy check behind the readiness endpoint
+const readinessTimeout = 5 * time.Second
+
+// HealthHandler reports that the server process is alive
+func HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+}
+
+// ReadinessHandler reports whether the server can reach HAProxy, using check to probe it.
+// The endpoint needs no authentication, so failures are only detailed in the log.
+func ReadinessHandler(check func(ctx context.Context) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		if err := check(ctx); err != nil {
+			slog.WarnContext(ctx, "Readiness check failed", "error", err)
+			writeStatus(w, http.StatusServiceUnavailable, map[string]string{"status": "unavailable"})
+			return
+		}
+		writeStatus(w, http.StatusOK, map[string]string{"status": "ready"})
+	})
+}
+
+// writeStatus writes a JSON status body
+func writeStatus(w http.ResponseWriter, status int, body map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,237 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// structuredContentMeta is the result metadata key under which tool handlers
+// pass structured content to the OutputRewriter
+const structuredContentMeta = "haproxy-mcp-server/structuredContent"
+
+// annotationHints are the tool annotation hints a client defaults to true or
+// false when absent; mcp-go omits the ones set to false
+var annotationHints = []string{"readOnlyHint", "destructiveHint", "idempotentHint", "openWorldHint"}
+
+// OutputRewriter completes tool metadata and results that mcp-go v0.25 cannot express.
+//
+// tools/list responses gain each tool's outputSchema and explicit false
+// annotation hints, which mcp-go drops, so clients do not fall back to the
+// defaults (e.g. destructiveHint: true). Tool results carrying structured
+// content in their metadata have it moved to structuredContent.
+type OutputRewriter struct {
+	schemas map[string]map[string]interface{}
+}
+
+// NewOutputRewriter advertises the output schemas of the built-in tools. Tools
+// held by gate return pending changes instead of their output, so their schemas
+// are left out; gate may be nil.
+func NewOutputRewriter(gate *ApprovalGate) *OutputRewriter {
+	schemas := make(map[string]map[string]interface{}, len(toolOutputSchemas))
+	for name, schema := range toolOutputSchemas {
+		if gate == nil || !gate.requiresApproval(name) {
+			schemas[name] = schema
+		}
+	}
+	return &OutputRewriter{schemas: schemas}
+}
+
+// Rewrite returns message, a JSON-RPC response or batch, with tool metadata and
+// results completed. Other messages are returned unchanged.
+func (o *OutputRewriter) Rewrite(message []byte) []byte {
+	if !bytes.Contains(message, []byte(`"tools"`)) && !bytes.Contains(message, []byte(structuredContentMeta)) {
+		return message
+	}
+	return eachMessage(message, o.rewriteMessage)
+}
+
+// rewriteMessage rewrites the result of a single JSON-RPC response
+func (o *OutputRewriter) rewriteMessage(message []byte) []byte {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(message, &response); err != nil || response["result"] == nil {
+		return message
+	}
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(response["result"], &result); err != nil {
+		return message
+	}
+
+	changed := false
+	if raw, ok := result["tools"]; ok {
+		var tools []map[string]interface{}
+		if err := json.Unmarshal(raw, &tools); err == nil {
+			for _, tool := range tools {
+				o.completeTool(tool)
+			}
+			if out, err := json.Marshal(tools); err == nil {
+				result["tools"] = out
+				changed = true
+			}
+		}
+	}
+	if raw, ok := result["_meta"]; ok {
+		var meta map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &meta); err == nil && meta[structuredContentMeta] != nil {
+			result["structuredContent"] = meta[structuredContentMeta]
+			delete(meta, structuredContentMeta)
+			if len(meta) == 0 {
+				delete(result, "_meta")
+			} else if out, err := json.Marshal(meta); err == nil {
+				result["_meta"] = out
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return message
+	}
+
+	out, err := json.Marshal(result)
+	if err != nil {
+		return message
+	}
+	response["result"] = out
+	if out, err = json.Marshal(response); err != nil {
+		return message
+	}
+	return out
+}
+
+// completeTool adds the output schema and missing annotation hints to a listed tool
+func (o *OutputRewriter) completeTool(tool map[string]interface{}) {
+	if name, ok := tool["name"].(string); ok {
+		if schema, ok := o.schemas[name]; ok {
+			tool["outputSchema"] = schema
+		}
+	}
+	annotations, ok := tool["annotations"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, hint := range annotationHints {
+		if _, ok := annotations[hint]; !ok {
+			annotations[hint] = false
+		}
+	}
+}
+
+// Writer wraps the stdio output, rewriting each response line
+func (o *OutputRewriter) Writer(w io.Writer) io.Writer {
+	return outputLineWriter{w: w, rewrite: o.Rewrite}
+}
+
+// outputLineWriter rewrites newline-terminated messages; mcp-go writes each
+// stdio response with a single call
+type outputLineWriter struct {
+	w       io.Writer
+	rewrite func([]byte) []byte
+}
+
+// Write implements io.Writer
+func (l outputLineWriter) Write(p []byte) (int, error) {
+	line, ok := bytes.CutSuffix(p, []byte("\n"))
+	if !ok {
+		return l.w.Write(p)
+	}
+	out := append(bytes.Clone(l.rewrite(line)), '\n')
+	if _, err := l.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// HTTPMiddleware rewrites responses of the HTTP transports: JSON bodies of the
+// streamable HTTP transport and the data lines of SSE streams
+func (o *OutputRewriter) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &outputResponseWriter{ResponseWriter: w, rewrite: o.Rewrite}
+		next.ServeHTTP(rw, r)
+		rw.finish()
+	})
+}
+
+// Response body handling of outputResponseWriter, picked from the Content-Type on first write
+const (
+	outputUndecided = iota
+	outputPassthrough
+	outputJSON   // Buffered and rewritten once the handler returns
+	outputStream // Rewritten line by line
+)
+
+// outputResponseWriter rewrites the JSON-RPC messages written by an HTTP transport
+type outputResponseWriter struct {
+	http.ResponseWriter
+	rewrite func([]byte) []byte
+	mode    int
+	buf     bytes.Buffer
+}
+
+// Write implements http.ResponseWriter
+func (w *outputResponseWriter) Write(p []byte) (int, error) {
+	if w.mode == outputUndecided {
+		contentType := w.Header().Get("Content-Type")
+		switch {
+		case strings.HasPrefix(contentType, "application/json"):
+			w.mode = outputJSON
+		case strings.HasPrefix(contentType, "text/event-stream"):
+			w.mode = outputStream
+		default:
+			w.mode = outputPassthrough
+		}
+	}
+
+	switch w.mode {
+	case outputJSON:
+		return w.buf.Write(p)
+	case outputStream:
+		w.buf.Write(p)
+		if err := w.writeLines(); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	default:
+		return w.ResponseWriter.Write(p)
+	}
+}
+
+// writeLines writes the complete lines buffered so far, rewriting SSE data lines
+func (w *outputResponseWriter) writeLines() error {
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			return nil
+		}
+		line := w.buf.Next(i + 1)
+		if data, ok := bytes.CutPrefix(line, []byte("data: ")); ok {
+			end := len(data) - 1 // Keep the line ending, "\n" or "\r\n"
+			if end > 0 && data[end-1] == '\r' {
+				end--
+			}
+			line = append(append([]byte("data: "), w.rewrite(data[:end])...), data[end:]...)
+		}
+		if _, err := w.ResponseWriter.Write(line); err != nil {
+			return err
+		}
+	}
+}
+
+// Flush implements http.Flusher for streaming transports
+func (w *outputResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// finish writes whatever is still buffered when the handler returns
+func (w *outputResponseWriter) finish() {
+	switch w.mode {
+	case outputJSON:
+		_, _ = w.ResponseWriter.Write(w.rewrite(w.buf.Bytes()))
+	case outputStream:
+		_ = w.writeLines()
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+}
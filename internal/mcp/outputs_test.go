@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// handleRewritten sends a JSON-RPC request to a server with every tool, including
+// approve_change, and returns the rewritten response
+func handleRewritten(t *testing.T, o *OutputRewriter, message string) map[string]interface{} {
+	t.Helper()
+
+	s, _, _ := newTestServer(t)
+	NewApprovalGate(DefaultApprovalTools, time.Minute).Register(s)
+	response, err := json.Marshal(s.HandleMessage(context.Background(), []byte(message)))
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	var decoded struct {
+		Result map[string]interface{} `json:"result"`
+	}
+	if err := json.Unmarshal(o.Rewrite(response), &decoded); err != nil || decoded.Result == nil {
+		t.Fatalf("Unexpected rewritten response: %v %s", err, response)
+	}
+	return decoded.Result
+}
+
+// TestToolMetadata tests that every tool advertises annotations and an output schema
+func TestToolMetadata(t *testing.T) {
+	result := handleRewritten(t, NewOutputRewriter(nil), `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+
+	tools, _ := result["tools"].([]interface{})
+	if len(tools) != len(toolOutputSchemas) {
+		t.Errorf("Expected %d tools with output schemas, got %d tools", len(toolOutputSchemas), len(tools))
+	}
+	for _, raw := range tools {
+		tool := raw.(map[string]interface{})
+		name := tool["name"].(string)
+		if _, ok := tool["outputSchema"].(map[string]interface{}); !ok {
+			t.Errorf("Tool %s has no output schema", name)
+		}
+		annotations := tool["annotations"].(map[string]interface{})
+		if annotations["title"] == "" || annotations["title"] == nil {
+			t.Errorf("Tool %s has no title", name)
+		}
+		for _, hint := range annotationHints {
+			if _, ok := annotations[hint].(bool); !ok {
+				t.Errorf("Tool %s does not declare %s", name, hint)
+			}
+		}
+
+		switch name {
+		case "list_backends":
+			if annotations["readOnlyHint"] != true || annotations["destructiveHint"] != false {
+				t.Errorf("Expected list_backends to be read-only, got %v", annotations)
+			}
+		case "enable_server":
+			if annotations["readOnlyHint"] != false || annotations["destructiveHint"] != false || annotations["idempotentHint"] != true {
+				t.Errorf("Expected enable_server to be a non-destructive idempotent update, got %v", annotations)
+			}
+		case "del_server":
+			if annotations["destructiveHint"] != true {
+				t.Errorf("Expected del_server to be destructive, got %v", annotations)
+			}
+		}
+	}
+
+	// Gated tools return pending changes, so their schemas are not advertised
+	gated := handleRewritten(t, NewOutputRewriter(NewApprovalGate(DefaultApprovalTools, time.Minute)), `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	for _, raw := range gated["tools"].([]interface{}) {
+		tool := raw.(map[string]interface{})
+		_, ok := tool["outputSchema"]
+		if ok && tool["name"] == "del_server" {
+			t.Error("Expected no output schema for a gated tool")
+		}
+		if !ok && tool["name"] == "approve_change" {
+			t.Error("Expected an output schema for approve_change")
+		}
+	}
+}
+
+// TestStructuredContent tests that tool results carry structured content matching their text
+func TestStructuredContent(t *testing.T) {
+	o := NewOutputRewriter(nil)
+
+	result := handleRewritten(t, o, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_backends","arguments":{}}}`)
+	structured, ok := result["structuredContent"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected structured content, got %v", result)
+	}
+	if backends, ok := structured["backends"].([]interface{}); !ok || len(backends) == 0 {
+		t.Errorf("Expected backends in structured content, got %v", structured)
+	}
+	if _, ok := result["_meta"]; ok {
+		t.Errorf("Expected structured content to be removed from _meta, got %v", result["_meta"])
+	}
+	text := result["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &decoded); err != nil || fmt.Sprint(decoded) != fmt.Sprint(structured) {
+		t.Errorf("Expected text to match structured content, got %s", text)
+	}
+
+	result = handleRewritten(t, o, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"enable_server","arguments":{"backend":"backend1","server":"server1"}}}`)
+	if structured, ok := result["structuredContent"].(map[string]interface{}); !ok || structured["message"] == "" {
+		t.Errorf("Expected a structured message, got %v", result)
+	}
+}
+
+// TestOutputRewriterTransports tests the stdio and HTTP wrappers
+func TestOutputRewriterTransports(t *testing.T) {
+	o := NewOutputRewriter(nil)
+	response := `{"jsonrpc":"2.0","id":1,"result":{"content":[],"_meta":{"` + structuredContentMeta + `":{"message":"ok"}}}}`
+
+	var stdout bytes.Buffer
+	if _, err := o.Writer(&stdout).Write([]byte(response + "\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), `"structuredContent":{"message":"ok"}`) || !strings.HasSuffix(stdout.String(), "\n") {
+		t.Errorf("Unexpected stdio output: %q", stdout.String())
+	}
+
+	stream := o.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: endpoint\ndata: /message?sessionId=1\r\n\r\n")
+		fmt.Fprintf(w, "event: message\ndata: %s", response)
+		fmt.Fprint(w, "\n\n")
+	}))
+	rec := httptest.NewRecorder()
+	stream.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+	body := rec.Body.String()
+	if !strings.Contains(body, "data: /message?sessionId=1\r\n\r\n") || !strings.Contains(body, `"structuredContent":{"message":"ok"}`) || !strings.HasSuffix(body, "}\n\n") {
+		t.Errorf("Unexpected event stream: %q", body)
+	}
+
+	jsonHandler := o.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, "["+response+"]")
+	}))
+	rec = httptest.NewRecorder()
+	jsonHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	if !strings.Contains(rec.Body.String(), `"structuredContent":{"message":"ok"}`) {
+		t.Errorf("Unexpected JSON body: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+)
+
+// toolTarget names the arguments holding the backend and server a tool acts on
+type toolTarget struct {
+	backend string
+	server  string
+}
+
+// toolTargets lists the backend-scoped tools. Tools missing here are
+// unscoped: rules restricting backends must name them explicitly.
+var toolTargets = map[string]toolTarget{
+	"get_backend":              {backend: "name"},
+	"show_servers_state":       {backend: "backend"},
+	"list_servers":             {backend: "backend"},
+	"get_server":               {backend: "backend", server: "server"},
+	"add_server":               {backend: "backend", server: "name"},
+	"del_server":               {backend: "backend", server: "name"},
+	"enable_server":            {backend: "backend", server: "server"},
+	"disable_server":           {backend: "backend", server: "server"},
+	"set_weight":               {backend: "backend", server: "server"},
+	"set_maxconn_server":       {backend: "backend", server: "server"},
+	"shutdown_sessions_server": {backend: "backend", server: "server"},
+	"enable_health":            {backend: "backend", server: "server"},
+	"disable_health":           {backend: "backend", server: "server"},
+	"enable_agent":             {backend: "backend", server: "server"},
+	"disable_agent":            {backend: "backend", server: "server"},
+	"show_errors":              {backend: "backend"},
+}
+
+// policyRequest describes a tool call for policy evaluation
+func policyRequest(req mcp.CallToolRequest) auth.Request {
+	r := auth.Request{Tool: req.Params.Name}
+	target, ok := toolTargets[r.Tool]
+	if !ok {
+		r.Unscoped = true
+		return r
+	}
+
+	r.Backend = getString(req, target.backend)
+	r.AllBackends = r.Backend == ""
+	if target.server != "" {
+		r.Server = getString(req, target.server)
+	}
+	return r
+}
+
+// PolicyMiddleware enforces policy on every tool call before its handler runs.
+// Denials are logged with the caller's identity and returned as tool errors.
+func PolicyMiddleware(policy *auth.Policy) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			identity, _ := auth.FromContext(ctx)
+			r := policyRequest(req)
+			if err := policy.Authorize(identity, r); err != nil {
+				slog.WarnContext(ctx, "Denied tool call by policy", "tool", r.Tool, "backend", r.Backend, "server", r.Server, "reason", err)
+				return mcp.NewToolResultError("Permission denied: " + err.Error()), nil
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// authorize checks a request made outside tool calls, such as a resource read,
+// against policy for the identity of ctx. A nil policy allows everything.
+func authorize(ctx context.Context, policy *auth.Policy, r auth.Request) error {
+	if policy == nil {
+		return nil
+	}
+	identity, _ := auth.FromContext(ctx)
+	if err := policy.Authorize(identity, r); err != nil {
+		slog.WarnContext(ctx, "Denied request by policy", "tool", r.Tool, "backend", r.Backend, "server", r.Server, "reason", err)
+		return fmt.Errorf("permission denied: %w", err)
+	}
+	return nil
+}
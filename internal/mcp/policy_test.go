@@ -0,0 +1,158 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+	haproxytesting "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/testing"
+)
+
+// TestPolicyMiddleware tests that tool calls are authorized before reaching HAProxy
+func TestPolicyMiddleware(t *testing.T) {
+	policy := &auth.Policy{Rules: []auth.PolicyRule{{
+		Groups:   []string{"payments"},
+		Tools:    []string{"*_server", "show_servers_state"},
+		Backends: []string{"payments-*"},
+	}}}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Invalid policy: %v", err)
+	}
+
+	client := haproxytesting.NewMockHAProxyClient()
+	runtime := client.RuntimeClient.(*haproxytesting.MockRuntimeClient)
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test",
+		server.WithToolCapabilities(true),
+		server.WithToolHandlerMiddleware(PolicyMiddleware(policy)),
+	)
+	RegisterTools(s, client)
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "alice", Method: auth.MethodToken, Groups: []string{"payments"}})
+
+	result := callToolWithContext(t, ctx, s, "disable_server", map[string]interface{}{"backend": "payments-api", "server": "web1"})
+	if result.IsError || len(runtime.DisabledServers) != 1 {
+		t.Errorf("Expected own backend to be allowed, got %s", resultText(t, result))
+	}
+
+	denied := []struct {
+		tool string
+		args map[string]interface{}
+		text string
+	}{
+		{tool: "del_server", args: map[string]interface{}{"backend": "checkout", "name": "web1"}, text: "on server checkout/web1"},
+		{tool: "show_servers_state", args: map[string]interface{}{}, text: "across all backends"},
+		{tool: "reload_haproxy", args: map[string]interface{}{}, text: "may not call reload_haproxy"},
+	}
+	for _, tc := range denied {
+		result := callToolWithContext(t, ctx, s, tc.tool, tc.args)
+		if !result.IsError || !strings.Contains(resultText(t, result), "Permission denied") || !strings.Contains(resultText(t, result), tc.text) {
+			t.Errorf("Expected %s to be denied with %q, got %s", tc.tool, tc.text, resultText(t, result))
+		}
+	}
+
+	if len(runtime.DeletedServers) != 0 || runtime.Reloads != 0 {
+		t.Errorf("Expected denied calls not to reach HAProxy, got %v deletions and %d reloads", runtime.DeletedServers, runtime.Reloads)
+	}
+
+	result = callTool(t, s, "show_servers_state", map[string]interface{}{"backend": "payments-api"})
+	if !result.IsError || !strings.Contains(resultText(t, result), "no policy rule applies to anonymous callers") {
+		t.Errorf("Expected anonymous caller to be denied, got %s", resultText(t, result))
+	}
+}
+
+// TestPolicyBeyondTools tests that unscoped tools, resources, prompts and
+// completions are authorized for rules restricting backends
+func TestPolicyBeyondTools(t *testing.T) {
+	policy := &auth.Policy{Rules: []auth.PolicyRule{{
+		Groups:   []string{"team-a"},
+		Tools:    []string{"list_*", "get_*", "show_*", "show_info"},
+		Backends: []string{"backend1"},
+	}}}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Invalid policy: %v", err)
+	}
+
+	client := haproxytesting.NewMockHAProxyClient()
+	client.StatsClient = nil
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test",
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, false),
+		server.WithPromptCapabilities(false),
+		server.WithToolHandlerMiddleware(PolicyMiddleware(policy)),
+	)
+	RegisterTools(s, client)
+	RegisterResources(s, client, policy)
+	RegisterPrompts(s, client, policy)
+	completions := NewCompletions(client, time.Minute, policy)
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "alice", Method: auth.MethodToken, Groups: []string{"team-a"}})
+
+	// Unscoped tools are only granted when listed by name
+	if result := callToolWithContext(t, ctx, s, "show_stat", map[string]interface{}{}); !result.IsError || !strings.Contains(resultText(t, result), "may not call show_stat") {
+		t.Errorf("Expected show_stat granted by pattern only to be denied, got %s", resultText(t, result))
+	}
+	if result := callToolWithContext(t, ctx, s, "show_info", map[string]interface{}{}); result.IsError {
+		t.Errorf("Expected show_info listed by name to be allowed, got %s", resultText(t, result))
+	}
+
+	// send handles a request as the team-a caller and returns the response as JSON
+	send := func(method string, params interface{}) string {
+		t.Helper()
+		message, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		out, err := json.Marshal(s.HandleMessage(ctx, message))
+		if err != nil {
+			t.Fatalf("Failed to marshal response: %v", err)
+		}
+		return string(out)
+	}
+
+	resources := []struct {
+		uri     string
+		allowed bool
+	}{
+		{uri: "haproxy://backends/backend1", allowed: true},
+		{uri: "haproxy://backends/backend2"},
+		{uri: "haproxy://backends/backend2/servers/server1"},
+		{uri: "haproxy://backends"},
+		{uri: "haproxy://maps/0"},
+	}
+	for _, tc := range resources {
+		response := send("resources/read", map[string]interface{}{"uri": tc.uri})
+		if denied := strings.Contains(response, "permission denied"); denied == tc.allowed {
+			t.Errorf("Expected %s allowed=%v, got %s", tc.uri, tc.allowed, response)
+		}
+	}
+
+	allowed := send("prompts/get", map[string]interface{}{"name": backend5xxPrompt, "arguments": map[string]string{"backend": "backend1"}})
+	if strings.Contains(allowed, "permission denied") || !strings.Contains(allowed, "server1") {
+		t.Errorf("Expected backend1 prompt data, got %s", allowed)
+	}
+	denied := send("prompts/get", map[string]interface{}{"name": backend5xxPrompt, "arguments": map[string]string{"backend": "backend2"}})
+	if !strings.Contains(denied, "Unavailable: permission denied") || strings.Contains(denied, `\"pxname\": \"backend2\"`) {
+		t.Errorf("Expected backend2 prompt data to be withheld, got %s", denied)
+	}
+
+	request := completions.Intercept(ctx, []byte(`{"jsonrpc":"2.0","id":"req-1","method":"completion/complete","params":{"ref":{"type":"ref/prompt","name":"investigate_backend_5xx"},"argument":{"name":"backend","value":""}}}`))
+	response, err := json.Marshal(s.HandleMessage(ctx, request))
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	var completion struct {
+		Result mcp.CompleteResult `json:"result"`
+	}
+	if err := json.Unmarshal(completions.Rewrite(response), &completion); err != nil {
+		t.Fatalf("Invalid completion response: %v", err)
+	}
+	if values := strings.Join(completion.Result.Completion.Values, ","); values != "backend1" {
+		t.Errorf("Expected only backend1 to be completed, got %q", values)
+	}
+}
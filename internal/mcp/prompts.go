@@ -0,0 +1,230 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+// Prompt names exposed by the server
+const (
+	backend5xxPrompt       = "investigate_backend_5xx"
+	drainServerPrompt      = "drain_server"
+	frontendCapacityPrompt = "frontend_capacity_review"
+)
+
+// Runtime info fields relevant to each runbook
+var (
+	healthInfoFields   = []string{"Version", "Uptime", "CurrConns", "Idle_pct"}
+	capacityInfoFields = []string{"Maxconn", "CurrConns", "MaxConnRate", "ConnRate", "SessRate", "MaxSessRate", "Nbthread", "Ulimit-n", "Idle_pct"}
+)
+
+// RegisterPrompts registers operational runbooks as MCP prompts.
+// Each prompt loads current HAProxy data into its message so clients start from the same facts.
+// Each section is authorized by policy, if any, as a call to the tool returning the same data.
+func RegisterPrompts(s *server.MCPServer, client haproxy.Client, policy *auth.Policy) {
+	slog.Info("Registering HAProxy MCP prompts...")
+
+	backend5xx := mcp.NewPrompt(backend5xxPrompt,
+		mcp.WithPromptDescription("Investigate a spike of 5xx responses on a backend"),
+		mcp.WithArgument("backend", mcp.ArgumentDescription("Name of the backend returning errors"), mcp.RequiredArgument()),
+	)
+	s.AddPrompt(backend5xx, func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		backend, err := promptArg(req, "backend")
+		if err != nil {
+			return nil, err
+		}
+		slog.InfoContext(ctx, "Building prompt", "prompt", backend5xxPrompt, "backend", backend)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "Backend %q is returning an elevated rate of 5xx responses. Find the cause.\n\n", backend)
+		b.WriteString("Steps:\n")
+		b.WriteString("1. Compare hrsp_5xx, eresp, econ and wretr across the servers below to tell backend-wide failures from a single bad server.\n")
+		b.WriteString("2. Check server status, check_status and last_chk for failing health checks.\n")
+		b.WriteString("3. Look at the captured protocol errors for malformed responses.\n")
+		b.WriteString("4. Compare qcur and scur with qlimit and slim to rule out saturation.\n")
+		b.WriteString("5. Summarize the likely cause and propose a remediation. Do not change server state without confirmation; use the drain_server prompt to take a server out of rotation.\n\n")
+		writeSection(ctx, &b, "Statistics for "+backend, authorized(ctx, policy, auth.Request{Tool: "get_backend", Backend: backend}, func() (interface{}, error) {
+			return proxyStats(ctx, client, backend)
+		}))
+		writeSection(ctx, &b, "Captured errors for "+backend, authorized(ctx, policy, auth.Request{Tool: "show_errors", Backend: backend}, func() (interface{}, error) {
+			return client.ShowErrorsWithContext(ctx, backend)
+		}))
+		writeSection(ctx, &b, "Process info", authorized(ctx, policy, auth.Request{Tool: "show_info", Unscoped: true}, func() (interface{}, error) {
+			return runtimeInfo(ctx, client, healthInfoFields)
+		}))
+
+		return promptResult(fmt.Sprintf("Investigate 5xx responses on backend %s", backend), b.String()), nil
+	})
+
+	drainServer := mcp.NewPrompt(drainServerPrompt,
+		mcp.WithPromptDescription("Safely take a server out of rotation"),
+		mcp.WithArgument("backend", mcp.ArgumentDescription("Name of the backend"), mcp.RequiredArgument()),
+		mcp.WithArgument("server", mcp.ArgumentDescription("Name of the server to take out of rotation"), mcp.RequiredArgument()),
+	)
+	s.AddPrompt(drainServer, func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		backend, err := promptArg(req, "backend")
+		if err != nil {
+			return nil, err
+		}
+		serverName, err := promptArg(req, "server")
+		if err != nil {
+			return nil, err
+		}
+		slog.InfoContext(ctx, "Building prompt", "prompt", drainServerPrompt, "backend", backend, "server", serverName)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "Take server %q out of rotation in backend %q without dropping traffic.\n\n", serverName, backend)
+		b.WriteString("Steps:\n")
+		b.WriteString("1. Using the statistics below, confirm the remaining UP servers can absorb this server's share of traffic. Stop if it is the last UP server.\n")
+		b.WriteString("2. Set its weight to 0 with set_weight so it stops receiving new connections.\n")
+		b.WriteString("3. Poll get_server until its current sessions (scur) reach 0.\n")
+		b.WriteString("4. Put it into maintenance with disable_server.\n")
+		b.WriteString("5. Report the original weight so it can be restored with set_weight and enable_server afterwards.\n")
+		b.WriteString("Ask for confirmation before each change.\n\n")
+		writeSection(ctx, &b, "Statistics for "+backend, authorized(ctx, policy, auth.Request{Tool: "get_backend", Backend: backend, Server: serverName}, func() (interface{}, error) {
+			return proxyStats(ctx, client, backend)
+		}))
+		writeSection(ctx, &b, "Captured errors for "+backend, authorized(ctx, policy, auth.Request{Tool: "show_errors", Backend: backend}, func() (interface{}, error) {
+			return client.ShowErrorsWithContext(ctx, backend)
+		}))
+		writeSection(ctx, &b, "Process info", authorized(ctx, policy, auth.Request{Tool: "show_info", Unscoped: true}, func() (interface{}, error) {
+			return runtimeInfo(ctx, client, healthInfoFields)
+		}))
+
+		return promptResult(fmt.Sprintf("Take server %s/%s out of rotation", backend, serverName), b.String()), nil
+	})
+
+	frontendCapacity := mcp.NewPrompt(frontendCapacityPrompt,
+		mcp.WithPromptDescription("Review the capacity headroom of a frontend"),
+		mcp.WithArgument("frontend", mcp.ArgumentDescription("Name of the frontend to review"), mcp.RequiredArgument()),
+	)
+	s.AddPrompt(frontendCapacity, func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		frontend, err := promptArg(req, "frontend")
+		if err != nil {
+			return nil, err
+		}
+		slog.InfoContext(ctx, "Building prompt", "prompt", frontendCapacityPrompt, "frontend", frontend)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "Review the capacity of frontend %q.\n\n", frontend)
+		b.WriteString("Steps:\n")
+		b.WriteString("1. Compare the frontend's current and peak sessions (scur, smax) with its limit (slim), and its session rate (rate, rate_max) with rate_lim.\n")
+		b.WriteString("2. Compare process-wide connections and rates with Maxconn, MaxConnRate and Ulimit-n, and check Idle_pct for CPU headroom.\n")
+		b.WriteString("3. Check denied and failed requests (dreq, ereq) and the captured errors for signs of overload or abuse.\n")
+		b.WriteString("4. Report the current headroom as a percentage of each limit and recommend any limit or scaling changes.\n\n")
+		writeSection(ctx, &b, "Statistics for "+frontend, authorized(ctx, policy, auth.Request{Tool: "show_stat", Backend: frontend}, func() (interface{}, error) {
+			return proxyStats(ctx, client, frontend)
+		}))
+		writeSection(ctx, &b, "Captured errors for "+frontend, authorized(ctx, policy, auth.Request{Tool: "show_errors", Backend: frontend}, func() (interface{}, error) {
+			return client.ShowErrorsWithContext(ctx, frontend)
+		}))
+		writeSection(ctx, &b, "Process info", authorized(ctx, policy, auth.Request{Tool: "show_info", Unscoped: true}, func() (interface{}, error) {
+			return runtimeInfo(ctx, client, capacityInfoFields)
+		}))
+
+		return promptResult(fmt.Sprintf("Capacity review of frontend %s", frontend), b.String()), nil
+	})
+
+	slog.Info("HAProxy MCP prompts registered")
+}
+
+// promptArg returns a required prompt argument naming a proxy or server. It is
+// validated before any runtime command is built from it.
+func promptArg(req mcp.GetPromptRequest, key string) (string, error) {
+	value := strings.TrimSpace(req.Params.Arguments[key])
+	if value == "" {
+		return "", fmt.Errorf("argument %q is required", key)
+	}
+	if err := runtimeclient.ValidateName(key, value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// proxyStats returns the 'show stat' rows of a single proxy with every
+// column, since the runbooks compare counters the stats page does not report
+func proxyStats(ctx context.Context, client haproxy.Client, proxy string) ([]map[string]string, error) {
+	rows, err := client.ShowStatRowsWithContext(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]map[string]string, 0)
+	for _, row := range rows {
+		if row["pxname"] == proxy {
+			filtered = append(filtered, row)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("no statistics found for %s", proxy)
+	}
+	return filtered, nil
+}
+
+// runtimeInfo returns the selected fields of 'show info'
+func runtimeInfo(ctx context.Context, client haproxy.Client, fields []string) (map[string]string, error) {
+	info, err := client.GetRuntimeInfoWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if value, ok := info[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected, nil
+}
+
+// authorized guards the data of a prompt section with policy, so a denied
+// section reports the denial instead of the data
+func authorized(ctx context.Context, policy *auth.Policy, r auth.Request, fn func() (interface{}, error)) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		if err := authorize(ctx, policy, r); err != nil {
+			return nil, err
+		}
+		return fn()
+	}
+}
+
+// writeSection appends a titled block of data to a prompt.
+// A failed lookup is reported in the block instead of failing the whole prompt.
+func writeSection(ctx context.Context, b *strings.Builder, title string, fn func() (interface{}, error)) {
+	fmt.Fprintf(b, "## %s\n\n", title)
+
+	v, err := fn()
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to load prompt data", "section", title, "error", err)
+		fmt.Fprintf(b, "Unavailable: %v\n\n", err)
+		return
+	}
+
+	if text, ok := v.(string); ok {
+		fmt.Fprintf(b, "```\n%s\n```\n\n", strings.TrimSpace(text))
+		return
+	}
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Fprintf(b, "Unavailable: %v\n\n", err)
+		return
+	}
+	fmt.Fprintf(b, "```json\n%s\n```\n\n", out)
+}
+
+// promptResult wraps the runbook text in a single user message
+func promptResult(description, text string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	})
+}
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	haproxytesting "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/testing"
+)
+
+// getPrompt sends a prompts/get request through the MCP server
+func getPrompt(t *testing.T, s *server.MCPServer, name string, args map[string]string) mcp.JSONRPCMessage {
+	t.Helper()
+
+	message, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "prompts/get",
+		"params":  map[string]interface{}{"name": name, "arguments": args},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	return s.HandleMessage(context.Background(), message)
+}
+
+// TestGetPrompts tests that each runbook prompt embeds the preloaded HAProxy data
+func TestGetPrompts(t *testing.T) {
+	// The stats page mock only carries a few columns; the runbook columns come from the runtime mock
+	client := haproxytesting.NewMockHAProxyClient()
+	runtime := client.RuntimeClient.(*haproxytesting.MockRuntimeClient)
+	runtime.StatRows[0]["hrsp_5xx"] = "7"
+	runtime.ProcessInfo["Maxconn"] = "4096"
+	runtime.StatRows = append(runtime.StatRows, map[string]string{"pxname": "http-in", "svname": "FRONTEND", "scur": "12", "slim": "2000"})
+	runtime.Errors = "Total events captured on [18/Oct/2026:10:00:00.000] : 1\n"
+
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithPromptCapabilities(false))
+	RegisterPrompts(s, client, nil)
+
+	testCases := []struct {
+		prompt   string
+		args     map[string]string
+		contains []string
+	}{
+		{
+			prompt:   "investigate_backend_5xx",
+			args:     map[string]string{"backend": "backend1"},
+			contains: []string{`"svname": "server1"`, `"hrsp_5xx": "7"`, "Total events captured", `"Version": "2.4.0"`},
+		},
+		{
+			prompt:   "drain_server",
+			args:     map[string]string{"backend": "backend1", "server": "server1"},
+			contains: []string{`server "server1"`, "set_weight", `"svname": "BACKEND"`, `"Version": "2.4.0"`},
+		},
+		{
+			prompt:   "frontend_capacity_review",
+			args:     map[string]string{"frontend": "http-in"},
+			contains: []string{`"slim": "2000"`, `"Maxconn": "4096"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.prompt, func(t *testing.T) {
+			response, ok := getPrompt(t, s, tc.prompt, tc.args).(mcp.JSONRPCResponse)
+			if !ok {
+				t.Fatalf("Expected JSON-RPC response for %s", tc.prompt)
+			}
+			result, ok := response.Result.(mcp.GetPromptResult)
+			if !ok || len(result.Messages) != 1 {
+				t.Fatalf("Expected one prompt message, got %#v", response.Result)
+			}
+			text, ok := result.Messages[0].Content.(mcp.TextContent)
+			if !ok {
+				t.Fatalf("Expected text content, got %T", result.Messages[0].Content)
+			}
+			for _, want := range tc.contains {
+				if !strings.Contains(text.Text, want) {
+					t.Errorf("Expected %q in prompt, got: %s", want, text.Text)
+				}
+			}
+		})
+	}
+}
+
+// TestGetPromptErrors tests missing arguments and unavailable data
+func TestGetPromptErrors(t *testing.T) {
+	client := haproxytesting.NewMockHAProxyClient()
+	runtime := client.RuntimeClient.(*haproxytesting.MockRuntimeClient)
+	runtime.FailShowErrors = true
+
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithPromptCapabilities(false))
+	RegisterPrompts(s, client, nil)
+
+	if _, ok := getPrompt(t, s, "drain_server", map[string]string{"backend": "backend1"}).(mcp.JSONRPCError); !ok {
+		t.Error("Expected JSON-RPC error when a required argument is missing")
+	}
+
+	// Arguments that would chain other runtime commands are rejected before any is run
+	injected := []struct {
+		prompt string
+		args   map[string]string
+	}{
+		{prompt: "investigate_backend_5xx", args: map[string]string{"backend": "web; disable server web/web1"}},
+		{prompt: "drain_server", args: map[string]string{"backend": "web", "server": "web1\nshutdown sessions server web/web1"}},
+		{prompt: "frontend_capacity_review", args: map[string]string{"frontend": "www;shutdown frontend www"}},
+	}
+	for _, tc := range injected {
+		rpcErr, ok := getPrompt(t, s, tc.prompt, tc.args).(mcp.JSONRPCError)
+		if !ok || !strings.Contains(rpcErr.Error.Message, "must not contain") {
+			t.Errorf("Expected %s to reject %v, got %#v", tc.prompt, tc.args, rpcErr)
+		}
+	}
+
+	// A failed lookup is reported inside the prompt rather than failing it
+	response, ok := getPrompt(t, s, "investigate_backend_5xx", map[string]string{"backend": "missing"}).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatal("Expected JSON-RPC response")
+	}
+	text := response.Result.(mcp.GetPromptResult).Messages[0].Content.(mcp.TextContent).Text
+	for _, want := range []string{"no statistics found for missing", "mock error showing errors"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in prompt, got: %s", want, text)
+		}
+	}
+}
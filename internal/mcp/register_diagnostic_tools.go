@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+func registerDiagnosticTools(s *server.MCPServer, client haproxy.Client) {
+	slog.Info("Registering HAProxy diagnostic tools...")
+
+	// show_errors tool
+	showErrors := mcp.NewTool("show_errors",
+		mcp.WithDescription("Shows the last invalid request or response captured by each proxy: when, from which client, where the message became invalid, and the decoded message around the error"),
+		readOnlyTool("Show captured protocol errors"),
+		mcp.WithString("frontend", mcp.Description("Only show errors of requests received by, or responses sent to, this frontend")),
+		mcp.WithString("backend", mcp.Description("Only show errors of requests forwarded to, or responses received by, this backend")),
+		mcp.WithString("direction", mcp.Description("Only show invalid requests or invalid responses"), mcp.Enum("request", "response")),
+	)
+	s.AddTool(showErrors, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		frontend := getString(req, "frontend")
+		backend := getString(req, "backend")
+		direction := getString(req, "direction")
+		slog.InfoContext(ctx, "Executing show_errors", "frontend", frontend, "backend", backend, "direction", direction)
+		return callJSON(ctx, "show errors", "errors", func() (interface{}, error) {
+			captures, err := client.ShowErrorCapturesWithContext(ctx, "", direction)
+			if err != nil {
+				return nil, err
+			}
+			return filterErrorCaptures(captures, frontend, backend), nil
+		})
+	})
+
+	// show_resolvers tool
+	showResolvers := mcp.NewTool("show_resolvers",
+		mcp.WithDescription("Reports the DNS query counters of each nameserver of the resolvers sections, flagging nameservers that never answered or failed too many queries with timeouts, REFUSED or errors. Counters accumulate since HAProxy started."),
+		readOnlyTool("Show DNS resolvers"),
+		mcp.WithString("resolvers", mcp.Description("Only report the nameservers of this resolvers section")),
+		mcp.WithNumber("max_failure_percent", mcp.Description(fmt.Sprintf("Flag nameservers failing at least this percentage of their queries (default %d)", haproxy.DefaultMaxNameserverFailurePercent))),
+	)
+	s.AddTool(showResolvers, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		resolvers := getString(req, "resolvers")
+		maxFailurePercent := getInt(req, "max_failure_percent")
+		slog.InfoContext(ctx, "Executing show_resolvers", "resolvers", resolvers, "max_failure_percent", maxFailurePercent)
+		return callJSON(ctx, "show resolvers", "report", func() (interface{}, error) {
+			return client.ResolverHealthWithContext(ctx, resolvers, maxFailurePercent)
+		})
+	})
+
+	// show_peers tool
+	showPeers := mcp.NewTool("show_peers",
+		mcp.WithDescription("Reports whether every remote peer of the peers sections is connected and has received and acknowledged every local update of the stick tables it shares, listing the connection state and per-table update counters of each peer"),
+		readOnlyTool("Show peers synchronization"),
+		mcp.WithString("peers", mcp.Description("Only report the peers of this peers section")),
+	)
+	s.AddTool(showPeers, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		peers := getString(req, "peers")
+		slog.InfoContext(ctx, "Executing show_peers", "peers", peers)
+		return callJSON(ctx, "show peers", "report", func() (interface{}, error) {
+			return client.PeersReportWithContext(ctx, peers)
+		})
+	})
+
+	// diagnose_process tool
+	diagnoseProcess := mcp.NewTool("diagnose_process",
+		mcp.WithDescription("Collects show activity, show threads, show pools, show tasks and show fd into one snapshot of the HAProxy process: per-thread loop counters, thread states, the largest memory pools, the most queued task functions and file descriptors by type. Flags stuck threads, allocation failures, long run queues, buffer waits and file descriptor exhaustion."),
+		readOnlyTool("Diagnose HAProxy process"),
+	)
+	s.AddTool(diagnoseProcess, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.InfoContext(ctx, "Executing diagnose_process")
+		return callJSON(ctx, "diagnose process", "diagnosis", func() (interface{}, error) {
+			return client.DiagnoseProcessWithContext(ctx)
+		})
+	})
+
+	slog.Info("Diagnostic tools registered")
+}
+
+// filterErrorCaptures keeps the captures involving frontend and backend, when
+// set. HAProxy filters captures by the proxy that captured them only, which is
+// the frontend for requests and the backend for responses.
+func filterErrorCaptures(captures []runtimeclient.ErrorCapture, frontend, backend string) []runtimeclient.ErrorCapture {
+	filtered := make([]runtimeclient.ErrorCapture, 0, len(captures))
+	for _, capture := range captures {
+		if (frontend == "" || capture.Frontend == frontend) && (backend == "" || capture.Backend == backend) {
+			filtered = append(filtered, capture)
+		}
+	}
+	return filtered
+}
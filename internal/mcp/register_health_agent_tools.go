@@ -11,11 +11,12 @@ import (
     "github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
 )
 
-func registerHealthAgentTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
+func registerHealthAgentTools(s *server.MCPServer, client haproxy.Client) {
     slog.Info("Registering HAProxy health & agent check tools...")
 
     enableHealth := mcp.NewTool("enable_health",
         mcp.WithDescription("Enables health checks for a server in a backend"),
+        updateTool("Enable health checks", false, true),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
         mcp.WithString("server", mcp.Required(), mcp.Description("Name of the server to enable health checks for")),
     )
@@ -24,7 +25,7 @@ func registerHealthAgentTools(s *server.MCPServer, client *haproxy.HAProxyClient
         serverName := getString(req, "server")
         slog.InfoContext(ctx, "Executing enable_health", "backend", backend, "server", serverName)
         return callExec(ctx, "enable health checks", func() (string, error) {
-            if err := client.EnableHealth(backend, serverName); err != nil {
+            if err := client.EnableHealthWithContext(ctx, backend, serverName); err != nil {
                 return "", err
             }
             return fmt.Sprintf("Health checks for server %s/%s enabled successfully", backend, serverName), nil
@@ -33,6 +34,7 @@ func registerHealthAgentTools(s *server.MCPServer, client *haproxy.HAProxyClient
 
     disableHealth := mcp.NewTool("disable_health",
         mcp.WithDescription("Disables health checks for a server in a backend"),
+        updateTool("Disable health checks", true, true),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
         mcp.WithString("server", mcp.Required(), mcp.Description("Name of the server to disable health checks for")),
     )
@@ -41,7 +43,7 @@ func registerHealthAgentTools(s *server.MCPServer, client *haproxy.HAProxyClient
         serverName := getString(req, "server")
         slog.InfoContext(ctx, "Executing disable_health", "backend", backend, "server", serverName)
         return callExec(ctx, "disable health checks", func() (string, error) {
-            if err := client.DisableHealth(backend, serverName); err != nil {
+            if err := client.DisableHealthWithContext(ctx, backend, serverName); err != nil {
                 return "", err
             }
             return fmt.Sprintf("Health checks for server %s/%s disabled successfully", backend, serverName), nil
@@ -50,6 +52,7 @@ func registerHealthAgentTools(s *server.MCPServer, client *haproxy.HAProxyClient
 
     enableAgent := mcp.NewTool("enable_agent",
         mcp.WithDescription("Enables agent checks for a server in a backend"),
+        updateTool("Enable agent checks", false, true),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
         mcp.WithString("server", mcp.Required(), mcp.Description("Name of the server to enable agent checks for")),
     )
@@ -58,7 +61,7 @@ func registerHealthAgentTools(s *server.MCPServer, client *haproxy.HAProxyClient
         serverName := getString(req, "server")
         slog.InfoContext(ctx, "Executing enable_agent", "backend", backend, "server", serverName)
         return callExec(ctx, "enable agent checks", func() (string, error) {
-            if err := client.EnableAgent(backend, serverName); err != nil {
+            if err := client.EnableAgentWithContext(ctx, backend, serverName); err != nil {
                 return "", err
             }
             return fmt.Sprintf("Agent checks for server %s/%s enabled successfully", backend, serverName), nil
@@ -67,6 +70,7 @@ func registerHealthAgentTools(s *server.MCPServer, client *haproxy.HAProxyClient
 
     disableAgent := mcp.NewTool("disable_agent",
         mcp.WithDescription("Disables agent checks for a server in a backend"),
+        updateTool("Disable agent checks", true, true),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
         mcp.WithString("server", mcp.Required(), mcp.Description("Name of the server to disable agent checks for")),
     )
@@ -75,7 +79,7 @@ func registerHealthAgentTools(s *server.MCPServer, client *haproxy.HAProxyClient
         serverName := getString(req, "server")
         slog.InfoContext(ctx, "Executing disable_agent", "backend", backend, "server", serverName)
         return callExec(ctx, "disable agent checks", func() (string, error) {
-            if err := client.DisableAgent(backend, serverName); err != nil {
+            if err := client.DisableAgentWithContext(ctx, backend, serverName); err != nil {
                 return "", err
             }
             return fmt.Sprintf("Agent checks for server %s/%s disabled successfully", backend, serverName), nil
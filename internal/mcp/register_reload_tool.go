@@ -9,16 +9,17 @@ import (
 	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
 )
 
-func registerReloadTool(s *server.MCPServer, client *haproxy.HAProxyClient) {
+func registerReloadTool(s *server.MCPServer, client haproxy.Client) {
 	slog.Info("Registering HAProxy reload tool...")
 
 	reloadTool := mcp.NewTool("reload_haproxy",
 		mcp.WithDescription("Triggers a reload of the HAProxy configuration"),
+		updateTool("Reload HAProxy", true, false),
 	)
 	s.AddTool(reloadTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		slog.InfoContext(ctx, "Executing reload_haproxy")
 		return callExec(ctx, "reload haproxy", func() (string, error) {
-			if err := client.ReloadHAProxy(); err != nil {
+			if err := client.ReloadHAProxyWithContext(ctx); err != nil {
 				return "", err
 			}
 			return "HAProxy configuration reloaded successfully", nil
@@ -11,25 +11,27 @@ import (
     "github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
 )
 
-func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
+func registerServerTools(s *server.MCPServer, client haproxy.Client) {
     slog.Info("Registering HAProxy server management tools...")
 
     // list_servers tool
     listServers := mcp.NewTool("list_servers",
         mcp.WithDescription("Lists servers within a specific HAProxy backend"),
+        readOnlyTool("List servers"),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the servers")),
     )
     s.AddTool(listServers, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
         backend := getString(req, "backend")
         slog.InfoContext(ctx, "Executing list_servers", "backend", backend)
         return callJSON(ctx, "list servers", "servers", func() (interface{}, error) {
-            return client.ListServers(backend)
+            return client.ListServersWithContext(ctx, backend)
         })
     })
 
     // get_server tool
     getServer := mcp.NewTool("get_server",
         mcp.WithDescription("Gets details of a specific server within an HAProxy backend"),
+        readOnlyTool("Get server"),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
         mcp.WithString("server", mcp.Required(), mcp.Description("Name of the server to retrieve")),
     )
@@ -38,13 +40,14 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
         serverName := getString(req, "server")
         slog.InfoContext(ctx, "Executing get_server", "backend", backend, "server", serverName)
         return callJSON(ctx, "get server details", "server", func() (interface{}, error) {
-            return client.GetServerDetails(backend, serverName)
+            return client.GetServerDetailsWithContext(ctx, backend, serverName)
         })
     })
 
     // add_server tool
     addServer := mcp.NewTool("add_server",
         mcp.WithDescription("Adds a new server to a backend"),
+        updateTool("Add server", false, false),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend to add the server to")),
         mcp.WithString("name", mcp.Required(), mcp.Description("Name for the new server")),
         mcp.WithString("addr", mcp.Required(), mcp.Description("Address for the new server")),
@@ -59,7 +62,7 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
         weight := getInt(req, "weight")
         slog.InfoContext(ctx, "Executing add_server", "backend", backend, "name", name, "addr", addr, "port", port, "weight", weight)
         return callExec(ctx, "add server", func() (string, error) {
-            if err := client.AddServer(backend, name, addr, port, weight); err != nil {
+            if err := client.AddServerWithContext(ctx, backend, name, addr, port, weight); err != nil {
                 return "", err
             }
             return fmt.Sprintf("Server %s added successfully to backend %s", name, backend), nil
@@ -69,6 +72,7 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
     // del_server tool
     delServer := mcp.NewTool("del_server",
         mcp.WithDescription("Deletes a server from a backend"),
+        updateTool("Delete server", true, false),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
         mcp.WithString("name", mcp.Required(), mcp.Description("Name of the server to delete")),
     )
@@ -77,7 +81,7 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
         name := getString(req, "name")
         slog.InfoContext(ctx, "Executing del_server", "backend", backend, "name", name)
         return callExec(ctx, "delete server", func() (string, error) {
-            if err := client.DelServer(backend, name); err != nil {
+            if err := client.DelServerWithContext(ctx, backend, name); err != nil {
                 return "", err
             }
             return fmt.Sprintf("Server %s deleted successfully from backend %s", name, backend), nil
@@ -87,6 +91,7 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
     // enable_server tool
     enableServer := mcp.NewTool("enable_server",
         mcp.WithDescription("Enables a server in a backend"),
+        updateTool("Enable server", false, true),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
         mcp.WithString("server", mcp.Required(), mcp.Description("Name of the server to enable")),
     )
@@ -95,7 +100,7 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
         serverName := getString(req, "server")
         slog.InfoContext(ctx, "Executing enable_server", "backend", backend, "server", serverName)
         return callExec(ctx, "enable server", func() (string, error) {
-            if err := client.EnableServer(backend, serverName); err != nil {
+            if err := client.EnableServerWithContext(ctx, backend, serverName); err != nil {
                 return "", err
             }
             return fmt.Sprintf("Server %s/%s enabled successfully", backend, serverName), nil
@@ -105,6 +110,7 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
     // disable_server tool
     disableServer := mcp.NewTool("disable_server",
         mcp.WithDescription("Disables a server in a backend"),
+        updateTool("Disable server", true, true),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
         mcp.WithString("server", mcp.Required(), mcp.Description("Name of the server to disable")),
     )
@@ -113,7 +119,7 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
         serverName := getString(req, "server")
         slog.InfoContext(ctx, "Executing disable_server", "backend", backend, "server", serverName)
         return callExec(ctx, "disable server", func() (string, error) {
-            if err := client.DisableServer(backend, serverName); err != nil {
+            if err := client.DisableServerWithContext(ctx, backend, serverName); err != nil {
                 return "", err
             }
             return fmt.Sprintf("Server %s/%s disabled successfully", backend, serverName), nil
@@ -123,6 +129,7 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
     // set_weight tool
     setWeight := mcp.NewTool("set_weight",
         mcp.WithDescription("Sets server weight in a backend"),
+        updateTool("Set server weight", true, true),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
         mcp.WithString("server", mcp.Required(), mcp.Description("Name of the server to modify")),
         mcp.WithNumber("weight", mcp.Required(), mcp.Description("New weight value to set")),
@@ -133,13 +140,14 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
         weight := getInt(req, "weight")
         slog.InfoContext(ctx, "Executing set_weight", "backend", backend, "server", serverName, "weight", weight)
         return callExec(ctx, "set weight", func() (string, error) {
-            return client.SetWeight(backend, serverName, weight)
+            return client.SetWeightWithContext(ctx, backend, serverName, weight)
         })
     })
 
     // set_maxconn_server tool
     setMaxconn := mcp.NewTool("set_maxconn_server",
         mcp.WithDescription("Sets maximum connections for a server"),
+        updateTool("Set server maxconn", true, true),
         mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
         mcp.WithString("server", mcp.Required(), mcp.Description("Name of the server to modify")),
         mcp.WithNumber("maxconn", mcp.Required(), mcp.Description("New maxconn value to set")),
@@ -150,12 +158,48 @@ func registerServerTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
         maxconn := getInt(req, "maxconn")
         slog.InfoContext(ctx, "Executing set_maxconn_server", "backend", backend, "server", serverName, "maxconn", maxconn)
         return callExec(ctx, "set maxconn", func() (string, error) {
-            if err := client.SetServerMaxconn(backend, serverName, maxconn); err != nil {
+            if err := client.SetServerMaxconnWithContext(ctx, backend, serverName, maxconn); err != nil {
                 return "", err
             }
             return fmt.Sprintf("Maxconn for server %s/%s set to %d", backend, serverName, maxconn), nil
         })
     })
 
+    // shutdown_sessions_server tool
+    shutdownServerSessions := mcp.NewTool("shutdown_sessions_server",
+        mcp.WithDescription("Immediately terminates every session attached to a server"),
+        updateTool("Shut down server sessions", true, true),
+        mcp.WithString("backend", mcp.Required(), mcp.Description("Name of the backend containing the server")),
+        mcp.WithString("server", mcp.Required(), mcp.Description("Name of the server whose sessions to terminate")),
+    )
+    s.AddTool(shutdownServerSessions, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+        backend := getString(req, "backend")
+        serverName := getString(req, "server")
+        slog.InfoContext(ctx, "Executing shutdown_sessions_server", "backend", backend, "server", serverName)
+        return callExec(ctx, "shutdown sessions server", func() (string, error) {
+            if err := client.ShutdownServerSessionsWithContext(ctx, backend, serverName); err != nil {
+                return "", err
+            }
+            return fmt.Sprintf("Sessions of server %s/%s shut down", backend, serverName), nil
+        })
+    })
+
+    // shutdown_session tool
+    shutdownSession := mcp.NewTool("shutdown_session",
+        mcp.WithDescription("Immediately terminates a session by its ID, as listed by 'show sess'"),
+        updateTool("Shut down session", true, true),
+        mcp.WithString("id", mcp.Required(), mcp.Description("ID of the session to terminate, e.g. 0x55d1c3e0a2b0")),
+    )
+    s.AddTool(shutdownSession, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+        id := getString(req, "id")
+        slog.InfoContext(ctx, "Executing shutdown_session", "id", id)
+        return callExec(ctx, "shutdown session", func() (string, error) {
+            if err := client.ShutdownSessionWithContext(ctx, id); err != nil {
+                return "", err
+            }
+            return fmt.Sprintf("Session %s shut down", id), nil
+        })
+    })
+
     slog.Info("Server management tools registered")
 }
\ No newline at end of file
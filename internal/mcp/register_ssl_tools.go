@@ -0,0 +1,475 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
+	runtimeclient "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/runtime"
+)
+
+func registerSSLTools(s *server.MCPServer, client haproxy.Client) {
+	slog.Info("Registering HAProxy SSL certificate, crt-list, CA file and CRL file tools...")
+
+	// list_ssl_certs tool
+	listSSLCerts := mcp.NewTool("list_ssl_certs",
+		mcp.WithDescription("Lists the SSL certificates loaded by HAProxy and the certificate with an uncommitted transaction, if any"),
+		readOnlyTool("List SSL certificates"),
+	)
+	s.AddTool(listSSLCerts, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.InfoContext(ctx, "Executing list_ssl_certs")
+		return callJSON(ctx, "list SSL certificates", "certificates", func() (interface{}, error) {
+			return client.ListSSLCertsWithContext(ctx)
+		})
+	})
+
+	// show_ssl_cert tool
+	showSSLCert := mcp.NewTool("show_ssl_cert",
+		mcp.WithDescription("Shows the subject, SANs, issuer, validity and status of an SSL certificate"),
+		readOnlyTool("Show SSL certificate"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Certificate file name, as listed by list_ssl_certs")),
+		mcp.WithBoolean("transaction", mcp.Description("Show the uncommitted certificate staged by set_ssl_cert instead of the live one")),
+	)
+	s.AddTool(showSSLCert, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		transaction := getBool(req, "transaction")
+		slog.InfoContext(ctx, "Executing show_ssl_cert", "name", name, "transaction", transaction)
+		if transaction {
+			name = "*" + name
+		}
+		return callJSON(ctx, "show SSL certificate", "certificate", func() (interface{}, error) {
+			return client.ShowSSLCertWithContext(ctx, name)
+		})
+	})
+
+	// new_ssl_cert tool
+	newSSLCert := mcp.NewTool("new_ssl_cert",
+		mcp.WithDescription("Creates an empty SSL certificate, to be filled with set_ssl_cert and commit_ssl_cert"),
+		updateTool("Create SSL certificate", false, false),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Certificate file name")),
+	)
+	s.AddTool(newSSLCert, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing new_ssl_cert", "name", name)
+		return callExec(ctx, "create SSL certificate", func() (string, error) {
+			if err := client.NewSSLCertWithContext(ctx, name); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Empty certificate %s created; upload it with set_ssl_cert, then commit_ssl_cert", name), nil
+		})
+	})
+
+	// set_ssl_cert tool
+	setSSLCert := mcp.NewTool("set_ssl_cert",
+		mcp.WithDescription("Stages a new PEM certificate in a transaction without applying it. Check it with show_ssl_cert (transaction: true), then apply it with commit_ssl_cert or discard it with abort_ssl_cert."),
+		updateTool("Stage SSL certificate", false, true),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Certificate file name")),
+		mcp.WithString("payload", mcp.Required(), mcp.Description("PEM payload: the certificate, its chain and, unless stored in a separate key file, its private key")),
+	)
+	s.AddTool(setSSLCert, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		// The payload may hold a private key, so it is never logged
+		slog.InfoContext(ctx, "Executing set_ssl_cert", "name", name)
+		return callExec(ctx, "set SSL certificate", func() (string, error) {
+			if err := client.SetSSLCertWithContext(ctx, name, getString(req, "payload")); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Certificate %s staged; review it with show_ssl_cert, then commit_ssl_cert or abort_ssl_cert", name), nil
+		})
+	})
+
+	// commit_ssl_cert tool
+	commitSSLCert := mcp.NewTool("commit_ssl_cert",
+		mcp.WithDescription("Applies the certificate staged by set_ssl_cert to new connections, without a reload"),
+		updateTool("Commit SSL certificate", false, false),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Certificate file name")),
+	)
+	s.AddTool(commitSSLCert, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing commit_ssl_cert", "name", name)
+		return callExec(ctx, "commit SSL certificate", func() (string, error) {
+			if err := client.CommitSSLCertWithContext(ctx, name); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Certificate %s committed successfully", name), nil
+		})
+	})
+
+	// abort_ssl_cert tool
+	abortSSLCert := mcp.NewTool("abort_ssl_cert",
+		mcp.WithDescription("Discards the certificate staged by set_ssl_cert"),
+		updateTool("Abort SSL certificate", false, false),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Certificate file name")),
+	)
+	s.AddTool(abortSSLCert, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing abort_ssl_cert", "name", name)
+		return callExec(ctx, "abort SSL certificate", func() (string, error) {
+			if err := client.AbortSSLCertWithContext(ctx, name); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Transaction for certificate %s aborted", name), nil
+		})
+	})
+
+	// del_ssl_cert tool
+	delSSLCert := mcp.NewTool("del_ssl_cert",
+		mcp.WithDescription("Deletes an SSL certificate that is no longer used by any bind or crt-list"),
+		updateTool("Delete SSL certificate", true, false),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Certificate file name")),
+	)
+	s.AddTool(delSSLCert, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing del_ssl_cert", "name", name)
+		return callExec(ctx, "delete SSL certificate", func() (string, error) {
+			if err := client.DelSSLCertWithContext(ctx, name); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Certificate %s deleted successfully", name), nil
+		})
+	})
+
+	// cert_expiry_report tool
+	certExpiryReport := mcp.NewTool("cert_expiry_report",
+		mcp.WithDescription("Reports every loaded SSL certificate sorted by days to expiry, flagging those expired or expiring within the critical and warning thresholds"),
+		readOnlyTool("Certificate expiry report"),
+		mcp.WithNumber("warning_days", mcp.Description(fmt.Sprintf("Flag certificates expiring within this many days as warning (default %d)", haproxy.DefaultCertWarningDays))),
+		mcp.WithNumber("critical_days", mcp.Description(fmt.Sprintf("Flag certificates expiring within this many days as critical (default %d)", haproxy.DefaultCertCriticalDays))),
+	)
+	s.AddTool(certExpiryReport, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		warningDays := getInt(req, "warning_days")
+		criticalDays := getInt(req, "critical_days")
+		slog.InfoContext(ctx, "Executing cert_expiry_report", "warning_days", warningDays, "critical_days", criticalDays)
+		return callJSON(ctx, "report certificate expiry", "report", func() (interface{}, error) {
+			return client.CertExpiryReportWithContext(ctx, warningDays, criticalDays)
+		})
+	})
+
+	// list_ocsp_responses tool
+	listOCSPResponses := mcp.NewTool("list_ocsp_responses",
+		mcp.WithDescription("Lists the OCSP responses stapled by HAProxy and the certificate each one belongs to. Requires HAProxy 2.7 or later."),
+		readOnlyTool("List OCSP responses"),
+	)
+	s.AddTool(listOCSPResponses, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.InfoContext(ctx, "Executing list_ocsp_responses")
+		return callJSON(ctx, "list OCSP responses", "ocsp_responses", func() (interface{}, error) {
+			return client.ListOCSPResponsesWithContext(ctx)
+		})
+	})
+
+	// show_ocsp_response tool
+	showOCSPResponse := mcp.NewTool("show_ocsp_response",
+		mcp.WithDescription("Shows the status of a stapled OCSP response: certificate status, responder and this/next update"),
+		readOnlyTool("Show OCSP response"),
+		mcp.WithString("id", mcp.Required(), mcp.Description("Certificate ID key, as listed by list_ocsp_responses, or from HAProxy 2.8 the certificate file")),
+	)
+	s.AddTool(showOCSPResponse, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := getString(req, "id")
+		slog.InfoContext(ctx, "Executing show_ocsp_response", "id", id)
+		return callJSON(ctx, "show OCSP response", "ocsp_response", func() (interface{}, error) {
+			return client.ShowOCSPResponseWithContext(ctx, id)
+		})
+	})
+
+	// update_ocsp_response tool
+	updateOCSPResponse := mcp.NewTool("update_ocsp_response",
+		mcp.WithDescription("Fetches a fresh OCSP response for a certificate from its responder and staples it, without a reload. Requires HAProxy 2.8 or later."),
+		updateTool("Update OCSP response", false, true),
+		mcp.WithString("certificate", mcp.Required(), mcp.Description("Certificate file, as listed by list_ssl_certs")),
+	)
+	s.AddTool(updateOCSPResponse, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		certificate := getString(req, "certificate")
+		slog.InfoContext(ctx, "Executing update_ocsp_response", "certificate", certificate)
+		return callExec(ctx, "update OCSP response", func() (string, error) {
+			if err := client.UpdateOCSPResponseWithContext(ctx, certificate); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("OCSP response of certificate %s updated", certificate), nil
+		})
+	})
+
+	// list_crt_lists tool
+	listCrtLists := mcp.NewTool("list_crt_lists",
+		mcp.WithDescription("Lists the crt-list files that attach certificates to binds"),
+		readOnlyTool("List crt-lists"),
+	)
+	s.AddTool(listCrtLists, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.InfoContext(ctx, "Executing list_crt_lists")
+		return callJSON(ctx, "list crt-lists", "crt_lists", func() (interface{}, error) {
+			return client.ListCrtListsWithContext(ctx)
+		})
+	})
+
+	// show_crt_list tool
+	showCrtList := mcp.NewTool("show_crt_list",
+		mcp.WithDescription("Shows the entries of a crt-list: certificate, line number, SSL options and SNI filters"),
+		readOnlyTool("Show crt-list"),
+		mcp.WithString("crt_list", mcp.Required(), mcp.Description("crt-list file, as listed by list_crt_lists")),
+	)
+	s.AddTool(showCrtList, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		crtList := getString(req, "crt_list")
+		slog.InfoContext(ctx, "Executing show_crt_list", "crt_list", crtList)
+		return callJSON(ctx, "show crt-list", "entries", func() (interface{}, error) {
+			return client.ShowCrtListWithContext(ctx, crtList)
+		})
+	})
+
+	// add_crt_list_entry tool
+	addCrtListEntry := mcp.NewTool("add_crt_list_entry",
+		mcp.WithDescription("Attaches a loaded certificate to the binds using a crt-list, for the given SNI names. Load a new certificate first with new_ssl_cert, set_ssl_cert and commit_ssl_cert."),
+		updateTool("Add crt-list entry", false, false),
+		mcp.WithString("crt_list", mcp.Required(), mcp.Description("crt-list file")),
+		mcp.WithString("certificate", mcp.Required(), mcp.Description("Certificate file, as listed by list_ssl_certs")),
+		mcp.WithString("ssl_options", mcp.Description("SSL bind options for this entry, without brackets, e.g. alpn h2 ssl-min-ver TLSv1.2")),
+		mcp.WithString("sni_filters", mcp.Description("Comma separated SNI names to serve the certificate for, e.g. example.com,*.example.com,!www.example.com; defaults to the names in the certificate")),
+	)
+	s.AddTool(addCrtListEntry, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		crtList := getString(req, "crt_list")
+		entry := runtimeclient.CrtListEntry{
+			Certificate: getString(req, "certificate"),
+			SSLOptions:  getString(req, "ssl_options"),
+			SNIFilters:  getList(req, "sni_filters"),
+		}
+		slog.InfoContext(ctx, "Executing add_crt_list_entry", "crt_list", crtList, "entry", entry)
+		return callExec(ctx, "add crt-list entry", func() (string, error) {
+			if err := client.AddCrtListEntryWithContext(ctx, crtList, entry); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Certificate %s added to crt-list %s", entry.Certificate, crtList), nil
+		})
+	})
+
+	// del_crt_list_entry tool
+	delCrtListEntry := mcp.NewTool("del_crt_list_entry",
+		mcp.WithDescription("Removes a certificate from a crt-list, so its SNI names are no longer served with it"),
+		updateTool("Delete crt-list entry", true, false),
+		mcp.WithString("crt_list", mcp.Required(), mcp.Description("crt-list file")),
+		mcp.WithString("certificate", mcp.Required(), mcp.Description("Certificate file of the entry")),
+		mcp.WithNumber("line", mcp.Description("Line number of the entry, as shown by show_crt_list; required when the certificate appears more than once")),
+	)
+	s.AddTool(delCrtListEntry, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		crtList := getString(req, "crt_list")
+		certificate := getString(req, "certificate")
+		line := getInt(req, "line")
+		slog.InfoContext(ctx, "Executing del_crt_list_entry", "crt_list", crtList, "certificate", certificate, "line", line)
+		return callExec(ctx, "delete crt-list entry", func() (string, error) {
+			if err := client.DelCrtListEntryWithContext(ctx, crtList, certificate, line); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Certificate %s removed from crt-list %s", certificate, crtList), nil
+		})
+	})
+
+	registerSSLFileTools(s, client)
+
+	slog.Info("SSL certificate, crt-list, CA file and CRL file tools registered")
+}
+
+// registerSSLFileTools registers the tools managing the CA and CRL files used
+// to verify client certificates on mTLS binds
+func registerSSLFileTools(s *server.MCPServer, client haproxy.Client) {
+	// list_ca_files tool
+	listCAFiles := mcp.NewTool("list_ca_files",
+		mcp.WithDescription("Lists the CA files used to verify client certificates and the CA file with an uncommitted transaction, if any"),
+		readOnlyTool("List CA files"),
+	)
+	s.AddTool(listCAFiles, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.InfoContext(ctx, "Executing list_ca_files")
+		return callJSON(ctx, "list CA files", "ca_files", func() (interface{}, error) {
+			return client.ListCAFilesWithContext(ctx)
+		})
+	})
+
+	// show_ca_file tool
+	showCAFile := mcp.NewTool("show_ca_file",
+		mcp.WithDescription("Shows the CA certificates of a CA file: subject, issuer, serial and validity"),
+		readOnlyTool("Show CA file"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("CA file name, as listed by list_ca_files")),
+		mcp.WithBoolean("transaction", mcp.Description("Show the uncommitted CA file staged by set_ca_file or add_ca_file instead of the live one")),
+	)
+	s.AddTool(showCAFile, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		transaction := getBool(req, "transaction")
+		slog.InfoContext(ctx, "Executing show_ca_file", "name", name, "transaction", transaction)
+		if transaction {
+			name = "*" + name
+		}
+		return callJSON(ctx, "show CA file", "ca_file", func() (interface{}, error) {
+			return client.ShowCAFileWithContext(ctx, name)
+		})
+	})
+
+	// new_ca_file tool
+	newCAFile := mcp.NewTool("new_ca_file",
+		mcp.WithDescription("Creates an empty CA file, to be filled with set_ca_file and commit_ca_file"),
+		updateTool("Create CA file", false, false),
+		mcp.WithString("name", mcp.Required(), mcp.Description("CA file name")),
+	)
+	s.AddTool(newCAFile, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing new_ca_file", "name", name)
+		return callExec(ctx, "create CA file", func() (string, error) {
+			if err := client.NewCAFileWithContext(ctx, name); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Empty CA file %s created; upload it with set_ca_file, then commit_ca_file", name), nil
+		})
+	})
+
+	// set_ca_file tool
+	setCAFile := mcp.NewTool("set_ca_file",
+		mcp.WithDescription("Stages PEM CA certificates replacing the content of a CA file in a transaction. Check it with show_ca_file (transaction: true), then apply it with commit_ca_file or discard it with abort_ca_file."),
+		updateTool("Stage CA file", false, true),
+		mcp.WithString("name", mcp.Required(), mcp.Description("CA file name")),
+		mcp.WithString("payload", mcp.Required(), mcp.Description("PEM CA certificates")),
+	)
+	s.AddTool(setCAFile, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing set_ca_file", "name", name)
+		return callExec(ctx, "set CA file", func() (string, error) {
+			if err := client.SetCAFileWithContext(ctx, name, getString(req, "payload")); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("CA file %s staged; review it with show_ca_file, then commit_ca_file or abort_ca_file", name), nil
+		})
+	})
+
+	// add_ca_file tool
+	addCAFile := mcp.NewTool("add_ca_file",
+		mcp.WithDescription("Stages PEM CA certificates appended to a CA file in a transaction, e.g. the next CA of a rotation. Apply it with commit_ca_file. Requires HAProxy 2.8 or later."),
+		updateTool("Append to CA file", false, false),
+		mcp.WithString("name", mcp.Required(), mcp.Description("CA file name")),
+		mcp.WithString("payload", mcp.Required(), mcp.Description("PEM CA certificates to append")),
+	)
+	s.AddTool(addCAFile, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing add_ca_file", "name", name)
+		return callExec(ctx, "add to CA file", func() (string, error) {
+			if err := client.AddCAFileWithContext(ctx, name, getString(req, "payload")); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Certificates appended to CA file %s; review it with show_ca_file, then commit_ca_file or abort_ca_file", name), nil
+		})
+	})
+
+	// commit_ca_file tool
+	commitCAFile := mcp.NewTool("commit_ca_file",
+		mcp.WithDescription("Applies the CA file staged by set_ca_file or add_ca_file to new connections, without a reload"),
+		updateTool("Commit CA file", false, false),
+		mcp.WithString("name", mcp.Required(), mcp.Description("CA file name")),
+	)
+	s.AddTool(commitCAFile, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing commit_ca_file", "name", name)
+		return callExec(ctx, "commit CA file", func() (string, error) {
+			if err := client.CommitCAFileWithContext(ctx, name); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("CA file %s committed successfully", name), nil
+		})
+	})
+
+	// abort_ca_file tool
+	abortCAFile := mcp.NewTool("abort_ca_file",
+		mcp.WithDescription("Discards the CA file staged by set_ca_file or add_ca_file"),
+		updateTool("Abort CA file", false, false),
+		mcp.WithString("name", mcp.Required(), mcp.Description("CA file name")),
+	)
+	s.AddTool(abortCAFile, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing abort_ca_file", "name", name)
+		return callExec(ctx, "abort CA file", func() (string, error) {
+			if err := client.AbortCAFileWithContext(ctx, name); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Transaction for CA file %s aborted", name), nil
+		})
+	})
+
+	// list_crl_files tool
+	listCRLFiles := mcp.NewTool("list_crl_files",
+		mcp.WithDescription("Lists the CRL files used to reject revoked client certificates and the CRL file with an uncommitted transaction, if any"),
+		readOnlyTool("List CRL files"),
+	)
+	s.AddTool(listCRLFiles, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.InfoContext(ctx, "Executing list_crl_files")
+		return callJSON(ctx, "list CRL files", "crl_files", func() (interface{}, error) {
+			return client.ListCRLFilesWithContext(ctx)
+		})
+	})
+
+	// show_crl_file tool
+	showCRLFile := mcp.NewTool("show_crl_file",
+		mcp.WithDescription("Shows the revocation lists of a CRL file: issuer, last and next update, and the revoked serial numbers"),
+		readOnlyTool("Show CRL file"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("CRL file name, as listed by list_crl_files")),
+		mcp.WithBoolean("transaction", mcp.Description("Show the uncommitted CRL file staged by set_crl_file instead of the live one")),
+	)
+	s.AddTool(showCRLFile, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		transaction := getBool(req, "transaction")
+		slog.InfoContext(ctx, "Executing show_crl_file", "name", name, "transaction", transaction)
+		if transaction {
+			name = "*" + name
+		}
+		return callJSON(ctx, "show CRL file", "crl_file", func() (interface{}, error) {
+			return client.ShowCRLFileWithContext(ctx, name)
+		})
+	})
+
+	// set_crl_file tool
+	setCRLFile := mcp.NewTool("set_crl_file",
+		mcp.WithDescription("Stages PEM CRLs replacing the content of a CRL file in a transaction, e.g. to push new revocations. Check it with show_crl_file (transaction: true), then apply it with commit_crl_file or discard it with abort_crl_file."),
+		updateTool("Stage CRL file", false, true),
+		mcp.WithString("name", mcp.Required(), mcp.Description("CRL file name")),
+		mcp.WithString("payload", mcp.Required(), mcp.Description("PEM CRLs (X509 CRL blocks), one per CA of the CA file")),
+	)
+	s.AddTool(setCRLFile, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing set_crl_file", "name", name)
+		return callExec(ctx, "set CRL file", func() (string, error) {
+			if err := client.SetCRLFileWithContext(ctx, name, getString(req, "payload")); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("CRL file %s staged; review it with show_crl_file, then commit_crl_file or abort_crl_file", name), nil
+		})
+	})
+
+	// commit_crl_file tool
+	commitCRLFile := mcp.NewTool("commit_crl_file",
+		mcp.WithDescription("Applies the CRL file staged by set_crl_file to new connections, without a reload"),
+		updateTool("Commit CRL file", false, false),
+		mcp.WithString("name", mcp.Required(), mcp.Description("CRL file name")),
+	)
+	s.AddTool(commitCRLFile, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing commit_crl_file", "name", name)
+		return callExec(ctx, "commit CRL file", func() (string, error) {
+			if err := client.CommitCRLFileWithContext(ctx, name); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("CRL file %s committed successfully", name), nil
+		})
+	})
+
+	// abort_crl_file tool
+	abortCRLFile := mcp.NewTool("abort_crl_file",
+		mcp.WithDescription("Discards the CRL file staged by set_crl_file"),
+		updateTool("Abort CRL file", false, false),
+		mcp.WithString("name", mcp.Required(), mcp.Description("CRL file name")),
+	)
+	s.AddTool(abortCRLFile, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := getString(req, "name")
+		slog.InfoContext(ctx, "Executing abort_crl_file", "name", name)
+		return callExec(ctx, "abort CRL file", func() (string, error) {
+			if err := client.AbortCRLFileWithContext(ctx, name); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Transaction for CRL file %s aborted", name), nil
+		})
+	})
+}
@@ -11,52 +11,58 @@ import (
     "github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
 )
 
-func registerStatTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
+func registerStatTools(s *server.MCPServer, client haproxy.Client) {
     slog.Info("Registering HAProxy statistics & process info tools...")
 
     // show_stat tool
     showStat := mcp.NewTool("show_stat",
         mcp.WithDescription("Shows HAProxy statistics table (show stat command)"),
+        readOnlyTool("Show statistics"),
         mcp.WithString("filter", mcp.Description("Optional filter for proxy or server names")),
+        withRowQuery(true, "Matches the status column: UP, DOWN, MAINT, DRAIN or NOLB for servers and backends, possibly followed by check progress such as UP 1/3, and OPEN for frontends, e.g. DOWN,MAINT"),
     )
     s.AddTool(showStat, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
         filter := getString(req, "filter")
-        slog.InfoContext(ctx, "Executing show_stat", "filter", filter)
+        query := getRowQuery(req)
+        slog.InfoContext(ctx, "Executing show_stat", "filter", filter, "query", query)
         return callJSON(ctx, "get statistics", "stats", func() (interface{}, error) {
-            return client.ShowStat(filter)
+            return client.ShowStatPageWithContext(ctx, filter, query)
         })
     })
 
     // show_info tool
     showInfo := mcp.NewTool("show_info",
         mcp.WithDescription("Shows HAProxy runtime information (version, uptime, limits, mode)"),
+        readOnlyTool("Show runtime information"),
     )
     s.AddTool(showInfo, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
         slog.InfoContext(ctx, "Executing show_info")
         return callJSON(ctx, "get runtime info", "info", func() (interface{}, error) {
-            return client.GetRuntimeInfo()
+            return client.GetRuntimeInfoWithContext(ctx)
         })
     })
 
     // debug_counters tool
     debugCounters := mcp.NewTool("debug_counters",
         mcp.WithDescription("Shows HAProxy internal counters (allocations, events)"),
+        readOnlyTool("Show internal counters"),
     )
     s.AddTool(debugCounters, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
         slog.InfoContext(ctx, "Executing debug_counters")
         return callJSON(ctx, "get debug counters", "counters", func() (interface{}, error) {
-            return client.DebugCounters()
+            return client.DebugCountersWithContext(ctx)
         })
     })
 
     // clear_counters_all tool
     clearAll := mcp.NewTool("clear_counters_all",
         mcp.WithDescription("Reset all HAProxy statistics counters"),
+        updateTool("Clear all counters", true, true),
     )
     s.AddTool(clearAll, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
         slog.InfoContext(ctx, "Executing clear_counters_all")
         return callExec(ctx, "clear counters", func() (string, error) {
-            if err := client.ClearCountersAll(); err != nil {
+            if err := client.ClearCountersAllWithContext(ctx); err != nil {
                 return "", err
             }
             return "All statistics counters have been reset successfully", nil
@@ -66,13 +72,14 @@ func registerStatTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
     // dump_stats_file tool
     dumpStats := mcp.NewTool("dump_stats_file",
         mcp.WithDescription("Dump HAProxy stats to a file"),
+        updateTool("Dump statistics to file", false, true),
         mcp.WithString("filepath", mcp.Required(), mcp.Description("Path where stats file should be saved")),
     )
     s.AddTool(dumpStats, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
         path := getString(req, "filepath")
         slog.InfoContext(ctx, "Executing dump_stats_file", "filepath", path)
         return callExec(ctx, "dump stats to file", func() (string, error) {
-            out, err := client.DumpStatsFile(path)
+            out, err := client.DumpStatsFileWithContext(ctx, path)
             if err != nil {
                 return "", err
             }
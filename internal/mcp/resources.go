@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
+)
+
+// Resource URIs exposed by the server
+const (
+	infoResourceURI         = "haproxy://info"
+	backendsResourceURI     = "haproxy://backends"
+	backendResourceTemplate = "haproxy://backends/{name}"
+	serverResourceTemplate  = "haproxy://backends/{name}/servers/{server}"
+	mapResourceTemplate     = "haproxy://maps/{id}"
+	jsonMIMEType            = "application/json"
+)
+
+// RegisterResources registers HAProxy state as MCP resources so assistants can browse it without tool calls.
+// Each read is authorized by policy, if any, as a call to the tool returning the same data.
+func RegisterResources(s *server.MCPServer, client haproxy.Client, policy *auth.Policy) {
+	slog.Info("Registering HAProxy MCP resources...")
+
+	info := mcp.NewResource(infoResourceURI, "HAProxy process info",
+		mcp.WithResourceDescription("HAProxy process information, as reported by 'show info'"),
+		mcp.WithMIMEType(jsonMIMEType),
+	)
+	s.AddResource(info, func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if err := authorizeResource(ctx, policy, req.Params.URI); err != nil {
+			return nil, err
+		}
+		return readJSON(ctx, req.Params.URI, "read runtime info", func() (interface{}, error) {
+			return client.GetRuntimeInfoWithContext(ctx)
+		})
+	})
+
+	backends := mcp.NewResource(backendsResourceURI, "HAProxy backends",
+		mcp.WithResourceDescription("Names of all HAProxy backends"),
+		mcp.WithMIMEType(jsonMIMEType),
+	)
+	s.AddResource(backends, func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if err := authorizeResource(ctx, policy, req.Params.URI); err != nil {
+			return nil, err
+		}
+		return readJSON(ctx, req.Params.URI, "list backends", func() (interface{}, error) {
+			return client.GetBackendsWithContext(ctx)
+		})
+	})
+
+	backend := mcp.NewResourceTemplate(backendResourceTemplate, "HAProxy backend",
+		mcp.WithTemplateDescription("Status, statistics and servers of a single backend"),
+		mcp.WithTemplateMIMEType(jsonMIMEType),
+	)
+	s.AddResourceTemplate(backend, func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		name := resourceArg(req, "name")
+		if err := authorizeResource(ctx, policy, req.Params.URI); err != nil {
+			return nil, err
+		}
+		return readJSON(ctx, req.Params.URI, "get backend details", func() (interface{}, error) {
+			return client.GetBackendDetailsWithContext(ctx, name)
+		})
+	})
+
+	serverTemplate := mcp.NewResourceTemplate(serverResourceTemplate, "HAProxy server",
+		mcp.WithTemplateDescription("State and statistics of a single server within a backend"),
+		mcp.WithTemplateMIMEType(jsonMIMEType),
+	)
+	s.AddResourceTemplate(serverTemplate, func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		backendName := resourceArg(req, "name")
+		serverName := resourceArg(req, "server")
+		if err := authorizeResource(ctx, policy, req.Params.URI); err != nil {
+			return nil, err
+		}
+		return readJSON(ctx, req.Params.URI, "get server details", func() (interface{}, error) {
+			return client.GetServerDetailsWithContext(ctx, backendName, serverName)
+		})
+	})
+
+	mapTemplate := mcp.NewResourceTemplate(mapResourceTemplate, "HAProxy map",
+		mcp.WithTemplateDescription("Entries of an HAProxy map, by numeric map ID or map file name"),
+		mcp.WithTemplateMIMEType(jsonMIMEType),
+	)
+	s.AddResourceTemplate(mapTemplate, func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		id := resourceArg(req, "id")
+		if err := authorizeResource(ctx, policy, req.Params.URI); err != nil {
+			return nil, err
+		}
+		return readJSON(ctx, req.Params.URI, "show map", func() (interface{}, error) {
+			return client.ShowMapWithContext(ctx, id)
+		})
+	})
+
+	slog.Info("HAProxy MCP resources registered")
+}
+
+// resourceRequest describes a read of a resource URI for policy evaluation,
+// as a call to the tool returning the same data. Maps have no tool; policies
+// grant them as show_map. ok is false for URIs of no known resource.
+func resourceRequest(uri string) (r auth.Request, ok bool) {
+	path, ok := strings.CutPrefix(uri, "haproxy://")
+	if !ok {
+		return r, false
+	}
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		var err error
+		if parts[i], err = url.PathUnescape(part); err != nil {
+			return r, false
+		}
+	}
+
+	switch {
+	case len(parts) == 1 && parts[0] == "info":
+		return auth.Request{Tool: "show_info", Unscoped: true}, true
+	case len(parts) == 1 && parts[0] == "backends":
+		return auth.Request{Tool: "list_backends", Unscoped: true}, true
+	case len(parts) == 2 && parts[0] == "backends":
+		return auth.Request{Tool: "get_backend", Backend: parts[1], AllBackends: parts[1] == ""}, true
+	case len(parts) == 4 && parts[0] == "backends" && parts[2] == "servers":
+		return auth.Request{Tool: "get_server", Backend: parts[1], Server: parts[3], AllBackends: parts[1] == ""}, true
+	case len(parts) >= 2 && parts[0] == "maps":
+		return auth.Request{Tool: "show_map", Unscoped: true}, true
+	}
+	return r, false
+}
+
+// authorizeResource checks a read of uri against policy for the identity of
+// ctx. A nil policy allows everything; otherwise unknown URIs are denied.
+func authorizeResource(ctx context.Context, policy *auth.Policy, uri string) error {
+	if policy == nil {
+		return nil
+	}
+	r, ok := resourceRequest(uri)
+	if !ok {
+		slog.WarnContext(ctx, "Denied request for unknown resource", "uri", uri)
+		return fmt.Errorf("permission denied: unknown resource %s", uri)
+	}
+	return authorize(ctx, policy, r)
+}
+
+// readJSON executes a client call and returns its result as a JSON resource
+func readJSON(ctx context.Context, uri, action string, fn func() (interface{}, error)) ([]mcp.ResourceContents, error) {
+	v, err := fn()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to "+action, "uri", uri, "error", err)
+		return nil, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to marshal resource", "uri", uri, "error", err)
+		return nil, fmt.Errorf("failed to marshal %s: %w", uri, err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: jsonMIMEType, Text: string(out)},
+	}, nil
+}
+
+// resourceArg extracts a URI template variable from the request
+func resourceArg(req mcp.ReadResourceRequest, key string) string {
+	switch v := req.Params.Arguments[key].(type) {
+	case string:
+		return v
+	case []string:
+		if len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	haproxytesting "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/testing"
+)
+
+// readResource sends a resources/read request through the MCP server
+func readResource(t *testing.T, s *server.MCPServer, uri string) mcp.JSONRPCMessage {
+	t.Helper()
+
+	message, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "resources/read",
+		"params":  map[string]interface{}{"uri": uri},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	return s.HandleMessage(context.Background(), message)
+}
+
+// TestReadResources tests that each resource and template resolves to client data
+func TestReadResources(t *testing.T) {
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test")
+	RegisterResources(s, haproxytesting.NewMockHAProxyClient(), nil)
+
+	testCases := []struct {
+		uri      string
+		contains string
+	}{
+		{uri: "haproxy://info", contains: `"Version":"2.4.0"`},
+		{uri: "haproxy://backends", contains: `["backend1","backend2"]`},
+		{uri: "haproxy://backends/backend1", contains: `"status":"UP"`},
+		{uri: "haproxy://backends/backend1/servers/server1", contains: `"name":"server1"`},
+		{uri: "haproxy://maps/0", contains: `"key":"example.com"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.uri, func(t *testing.T) {
+			response, ok := readResource(t, s, tc.uri).(mcp.JSONRPCResponse)
+			if !ok {
+				t.Fatalf("Expected JSON-RPC response for %s", tc.uri)
+			}
+			result, ok := response.Result.(mcp.ReadResourceResult)
+			if !ok || len(result.Contents) != 1 {
+				t.Fatalf("Expected one resource content, got %#v", response.Result)
+			}
+			text, ok := result.Contents[0].(mcp.TextResourceContents)
+			if !ok {
+				t.Fatalf("Expected text contents, got %T", result.Contents[0])
+			}
+			if text.URI != tc.uri || text.MIMEType != "application/json" {
+				t.Errorf("Unexpected contents metadata: %s %s", text.URI, text.MIMEType)
+			}
+			if !strings.Contains(text.Text, tc.contains) {
+				t.Errorf("Expected %q in %s, got: %s", tc.contains, tc.uri, text.Text)
+			}
+		})
+	}
+}
+
+// TestReadResourceErrors tests that client failures and unknown URIs surface as JSON-RPC errors
+func TestReadResourceErrors(t *testing.T) {
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test")
+	RegisterResources(s, haproxytesting.NewMockHAProxyClient(), nil)
+
+	for _, uri := range []string{"haproxy://maps/42", "haproxy://unknown"} {
+		if _, ok := readResource(t, s, uri).(mcp.JSONRPCError); !ok {
+			t.Errorf("Expected JSON-RPC error for %s", uri)
+		}
+	}
+}
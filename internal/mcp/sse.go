@@ -0,0 +1,27 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// NewSSEServer creates an SSE transport for s whose handlers outlive the POST
+// request that carried the message.
+//
+// mcp-go answers each POST with 202 Accepted and handles the message in the
+// background with the request context, which net/http cancels as soon as the
+// handler returns. Runtime commands would then fail with "context canceled",
+// so the context is detached from the request while keeping its values, such
+// as the session and the authenticated identity. Runtime commands still apply
+// their own timeout when the context has no deadline.
+func NewSSEServer(s *server.MCPServer, opts ...server.SSEOption) *server.SSEServer {
+	opts = append(opts, server.WithSSEContextFunc(detachRequestContext))
+	return server.NewSSEServer(s, opts...)
+}
+
+// detachRequestContext keeps the values of ctx without its cancellation
+func detachRequestContext(ctx context.Context, _ *http.Request) context.Context {
+	return context.WithoutCancel(ctx)
+}
@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestSSEToolCallContext tests that SSE tool handlers run with a context that
+// is not cancelled when the POST request that carried the call completes
+func TestSSEToolCallContext(t *testing.T) {
+	s, _, _ := newTestServer(t)
+	s.AddTool(mcp.NewTool("context_probe"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		select {
+		case <-ctx.Done():
+			return mcp.NewToolResultError("context ended: " + ctx.Err().Error()), nil
+		case <-time.After(100 * time.Millisecond):
+			return mcp.NewToolResultText("context alive"), nil
+		}
+	})
+
+	sse := NewSSEServer(s)
+	ts := httptest.NewServer(sse)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+sse.CompleteSsePath(), nil)
+	stream, err := http.DefaultClient.Do(req)
+	if err != nil || stream.StatusCode != http.StatusOK {
+		t.Fatalf("Expected event stream, got %v %v", err, stream)
+	}
+	defer stream.Body.Close()
+	events := bufio.NewScanner(stream.Body)
+
+	// nextData returns the data of the next SSE event
+	nextData := func() string {
+		for events.Scan() {
+			if data, ok := strings.CutPrefix(events.Text(), "data: "); ok {
+				return data
+			}
+		}
+		t.Fatalf("Stream ended early: %v", events.Err())
+		return ""
+	}
+
+	endpoint := nextData()
+	if strings.HasPrefix(endpoint, "/") {
+		endpoint = ts.URL + endpoint
+	}
+	post := func(body string) {
+		resp, err := http.Post(endpoint, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("Expected 202, got %d", resp.StatusCode)
+		}
+	}
+
+	post(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","clientInfo":{"name":"test","version":"1"},"capabilities":{}}}`)
+	nextData()
+
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"context_probe","arguments":{}}}`, "context alive"},
+		{`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"show_info","arguments":{}}}`, `"info"`},
+	}
+	for _, tt := range tests {
+		post(tt.body)
+		data := nextData()
+		if strings.Contains(data, `"isError":true`) || !strings.Contains(data, tt.want) {
+			t.Errorf("Expected %q in the tool result, got %s", tt.want, data)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+)
+
+// Streamable HTTP transport settings
+const (
+	// SessionIDHeader carries the session ID assigned on initialize
+	SessionIDHeader = "Mcp-Session-Id"
+
+	// DefaultSessionIdleTimeout is how long a session without requests or open streams is kept
+	DefaultSessionIdleTimeout = 30 * time.Minute
+
+	maxRequestBodySize = 4 << 20 // 4 MiB
+	notificationBuffer = 100
+)
+
+// streamableSession is a client session of the streamable HTTP transport
+type streamableSession struct {
+	id            string
+	owner         string // Authenticated identity that created the session, empty without authentication
+	notifications chan mcp.JSONRPCNotification
+	initialized   atomic.Bool
+	lastSeen      atomic.Int64 // Unix nanoseconds of the last request
+	streams       atomic.Int32 // Open GET streams
+}
+
+func (s *streamableSession) SessionID() string { return s.id }
+func (s *streamableSession) Initialize()       { s.initialized.Store(true) }
+func (s *streamableSession) Initialized() bool { return s.initialized.Load() }
+func (s *streamableSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+// touch records activity on the session
+func (s *streamableSession) touch() {
+	s.lastSeen.Store(time.Now().UnixNano())
+}
+
+// StreamableHTTPServer serves MCP over the streamable HTTP transport.
+//
+// mcp-go only ships stdio and SSE, so this handler implements the transport on
+// top of MCPServer.HandleMessage: POST carries JSON-RPC messages and is answered
+// with JSON, GET opens an SSE stream for server notifications, and DELETE ends
+// the session.
+type StreamableHTTPServer struct {
+	server      *server.MCPServer
+	idleTimeout time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*streamableSession
+	done     chan struct{}
+	closed   bool
+}
+
+// NewStreamableHTTPServer creates a streamable HTTP handler. Sessions idle for
+// longer than idleTimeout are expired; zero uses DefaultSessionIdleTimeout.
+func NewStreamableHTTPServer(s *server.MCPServer, idleTimeout time.Duration) *StreamableHTTPServer {
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultSessionIdleTimeout
+	}
+	return &StreamableHTTPServer{
+		server:      s,
+		idleTimeout: idleTimeout,
+		sessions:    make(map[string]*streamableSession),
+		done:        make(chan struct{}),
+	}
+}
+
+// ServeHTTP dispatches on the request method
+func (h *StreamableHTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.handlePost(w, r)
+	case http.MethodGet:
+		h.handleGet(w, r)
+	case http.MethodDelete:
+		h.handleDelete(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Close ends all sessions and open streams
+func (h *StreamableHTTPServer) Close() {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	h.closed = true
+	close(h.done)
+	ids := make([]string, 0, len(h.sessions))
+	for id := range h.sessions {
+		ids = append(ids, id)
+	}
+	h.mu.Unlock()
+
+	for _, id := range ids {
+		h.closeSession(context.Background(), id)
+	}
+}
+
+// handlePost processes a JSON-RPC message or batch and writes the responses as JSON
+func (h *StreamableHTTPServer) handlePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		writeJSONRPCError(w, http.StatusBadRequest, mcp.PARSE_ERROR, "failed to read request body")
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	batch := len(body) > 0 && body[0] == '['
+	var messages []json.RawMessage
+	if batch {
+		err = json.Unmarshal(body, &messages)
+	} else {
+		messages = []json.RawMessage{body}
+		err = json.Unmarshal(body, new(json.RawMessage))
+	}
+	if err != nil || len(messages) == 0 {
+		writeJSONRPCError(w, http.StatusBadRequest, mcp.PARSE_ERROR, "parse error")
+		return
+	}
+
+	var session *streamableSession
+	if containsInitialize(messages) {
+		if batch {
+			writeJSONRPCError(w, http.StatusBadRequest, mcp.INVALID_REQUEST, "initialize must not be sent in a batch")
+			return
+		}
+		if session, err = h.newSession(r.Context()); err != nil {
+			slog.ErrorContext(r.Context(), "Failed to create MCP session", "error", err)
+			writeJSONRPCError(w, http.StatusInternalServerError, mcp.INTERNAL_ERROR, "failed to create session")
+			return
+		}
+		w.Header().Set(SessionIDHeader, session.id)
+	} else if session = h.lookupSession(w, r); session == nil {
+		return
+	}
+	session.touch()
+
+	ctx := h.server.WithContext(r.Context(), session)
+	responses := make([]mcp.JSONRPCMessage, 0, len(messages))
+	for _, message := range messages {
+		if response := h.server.HandleMessage(ctx, message); response != nil {
+			responses = append(responses, response)
+		}
+	}
+
+	// Notifications and responses from the client are acknowledged without a body
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if batch {
+		_ = json.NewEncoder(w).Encode(responses)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(responses[0])
+}
+
+// handleGet opens an SSE stream that delivers server notifications for a session
+func (h *StreamableHTTPServer) handleGet(w http.ResponseWriter, r *http.Request) {
+	session := h.lookupSession(w, r)
+	if session == nil {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	session.streams.Add(1)
+	defer func() {
+		session.streams.Add(-1)
+		session.touch()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.done:
+			return
+		case notification, ok := <-session.notifications:
+			if !ok {
+				return
+			}
+			// Marshal through a pointer: NotificationParams only implements json.Marshaler on *NotificationParams
+			data, err := json.Marshal(&notification)
+			if err != nil {
+				slog.Warn("Failed to marshal notification", "session", session.id, "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: message\ndata: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// handleDelete ends a session at the client's request
+func (h *StreamableHTTPServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	session := h.lookupSession(w, r)
+	if session == nil {
+		return
+	}
+	h.closeSession(r.Context(), session.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lookupSession returns the session named by the request header, writing an error response if there is none
+func (h *StreamableHTTPServer) lookupSession(w http.ResponseWriter, r *http.Request) *streamableSession {
+	id := r.Header.Get(SessionIDHeader)
+	if id == "" {
+		writeJSONRPCError(w, http.StatusBadRequest, mcp.INVALID_REQUEST, "missing "+SessionIDHeader+" header")
+		return nil
+	}
+
+	h.mu.Lock()
+	session, ok := h.sessions[id]
+	h.mu.Unlock()
+	// Sessions of other identities are reported as missing so their IDs cannot be probed
+	if !ok || session.owner != sessionOwner(r.Context()) {
+		// 404 tells the client to start a new session with initialize
+		writeJSONRPCError(w, http.StatusNotFound, mcp.INVALID_REQUEST, "session not found")
+		return nil
+	}
+	return session
+}
+
+// sessionOwner identifies the authenticated client of a request
+func sessionOwner(ctx context.Context) string {
+	identity, _ := auth.FromContext(ctx)
+	return identityOwner(identity)
+}
+
+// identityOwner identifies an authenticated client, or none if identity is nil
+func identityOwner(identity *auth.Identity) string {
+	if identity == nil {
+		return ""
+	}
+	return identity.Method + ":" + identity.Subject
+}
+
+// newSession creates and registers a session, expiring idle ones first
+func (h *StreamableHTTPServer) newSession(ctx context.Context) (*streamableSession, error) {
+	h.expireIdle(ctx)
+
+	session := &streamableSession{
+		id:            uuid.New().String(),
+		owner:         sessionOwner(ctx),
+		notifications: make(chan mcp.JSONRPCNotification, notificationBuffer),
+	}
+	session.touch()
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil, fmt.Errorf("server is shutting down")
+	}
+	h.sessions[session.id] = session
+	h.mu.Unlock()
+
+	if err := h.server.RegisterSession(ctx, session); err != nil {
+		h.mu.Lock()
+		delete(h.sessions, session.id)
+		h.mu.Unlock()
+		return nil, err
+	}
+	slog.Debug("MCP session created", "session", session.id)
+	return session, nil
+}
+
+// closeSession removes a session from this handler and the MCP server
+func (h *StreamableHTTPServer) closeSession(ctx context.Context, id string) {
+	h.mu.Lock()
+	_, ok := h.sessions[id]
+	delete(h.sessions, id)
+	h.mu.Unlock()
+
+	if ok {
+		h.server.UnregisterSession(ctx, id)
+		slog.Debug("MCP session closed", "session", id)
+	}
+}
+
+// expireIdle closes sessions with no open stream and no request within the idle timeout
+func (h *StreamableHTTPServer) expireIdle(ctx context.Context) {
+	cutoff := time.Now().Add(-h.idleTimeout).UnixNano()
+
+	h.mu.Lock()
+	expired := make([]string, 0)
+	for id, session := range h.sessions {
+		if session.streams.Load() == 0 && session.lastSeen.Load() < cutoff {
+			expired = append(expired, id)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, id := range expired {
+		slog.Info("Expiring idle MCP session", "session", id)
+		h.closeSession(ctx, id)
+	}
+}
+
+// containsInitialize reports whether any message is an initialize request
+func containsInitialize(messages []json.RawMessage) bool {
+	for _, message := range messages {
+		var m struct {
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(message, &m) == nil && m.Method == string(mcp.MethodInitialize) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSONRPCError writes a JSON-RPC error without a request ID
+func writeJSONRPCError(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(mcp.JSONRPCError{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Error: struct {
+			Code    int         `json:"code"`
+			Message string      `json:"message"`
+			Data    interface{} `json:"data,omitempty"`
+		}{Code: code, Message: message},
+	})
+}
@@ -0,0 +1,194 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+)
+
+// postMessage posts a JSON-RPC body to the streamable endpoint
+func postMessage(t *testing.T, url, sessionID, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if sessionID != "" {
+		req.Header.Set(SessionIDHeader, sessionID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// TestStreamableHTTPSession tests the session lifecycle of the streamable HTTP transport
+func TestStreamableHTTPSession(t *testing.T) {
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithToolCapabilities(true))
+	handler := NewStreamableHTTPServer(s, 0)
+	defer handler.Close()
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp := postMessage(t, ts.URL, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"test","version":"1"},"capabilities":{}}}`)
+	sessionID := resp.Header.Get(SessionIDHeader)
+	if resp.StatusCode != http.StatusOK || sessionID == "" {
+		t.Fatalf("Expected 200 with a session ID, got %d %q", resp.StatusCode, sessionID)
+	}
+	var initResult struct {
+		Result mcp.InitializeResult `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&initResult); err != nil || initResult.Result.ServerInfo.Name != "haproxy-mcp-server-test" {
+		t.Fatalf("Unexpected initialize response: %v %+v", err, initResult)
+	}
+
+	if resp := postMessage(t, ts.URL, sessionID, `{"jsonrpc":"2.0","method":"notifications/initialized"}`); resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Expected 202 for a notification, got %d", resp.StatusCode)
+	}
+
+	resp = postMessage(t, ts.URL, sessionID, `[{"jsonrpc":"2.0","id":2,"method":"ping"},{"jsonrpc":"2.0","id":3,"method":"tools/list"}]`)
+	var batch []map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil || len(batch) != 2 {
+		t.Errorf("Expected two batch responses, got %v %v", err, batch)
+	}
+
+	if resp := postMessage(t, ts.URL, "", `{"jsonrpc":"2.0","id":4,"method":"ping"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 without a session, got %d", resp.StatusCode)
+	}
+	if resp := postMessage(t, ts.URL, "unknown", `{"jsonrpc":"2.0","id":5,"method":"ping"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown session, got %d", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL, nil)
+	req.Header.Set(SessionIDHeader, sessionID)
+	del, err := http.DefaultClient.Do(req)
+	if err != nil || del.StatusCode != http.StatusNoContent {
+		t.Fatalf("Expected 204 on delete, got %v %v", err, del)
+	}
+	del.Body.Close()
+	if resp := postMessage(t, ts.URL, sessionID, `{"jsonrpc":"2.0","id":6,"method":"ping"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 after delete, got %d", resp.StatusCode)
+	}
+}
+
+// TestStreamableHTTPNotifications tests that server notifications are delivered on the GET stream
+func TestStreamableHTTPNotifications(t *testing.T) {
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithResourceCapabilities(true, false))
+	handler := NewStreamableHTTPServer(s, 0)
+	defer handler.Close()
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp := postMessage(t, ts.URL, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"test","version":"1"},"capabilities":{}}}`)
+	sessionID := resp.Header.Get(SessionIDHeader)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	req.Header.Set(SessionIDHeader, sessionID)
+	stream, err := http.DefaultClient.Do(req)
+	if err != nil || stream.StatusCode != http.StatusOK {
+		t.Fatalf("Expected event stream, got %v %v", err, stream)
+	}
+	defer stream.Body.Close()
+
+	if err := s.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": "haproxy://info"}); err != nil {
+		t.Fatalf("Failed to send notification: %v", err)
+	}
+
+	scanner := bufio.NewScanner(stream.Body)
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			if !strings.Contains(data, `"uri":"haproxy://info"`) {
+				t.Errorf("Unexpected notification: %s", data)
+			}
+			return
+		}
+	}
+	t.Fatalf("Stream ended without a notification: %v", scanner.Err())
+}
+
+// staticAuthenticator authenticates every request as the same identity
+type staticAuthenticator struct{ identity *auth.Identity }
+
+func (a staticAuthenticator) Authenticate(r *http.Request) (*auth.Identity, error) {
+	return a.identity, nil
+}
+
+// TestStreamableHTTPIdentity tests that the authenticated identity reaches tool handlers
+func TestStreamableHTTPIdentity(t *testing.T) {
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithToolCapabilities(true))
+	s.AddTool(mcp.NewTool("whoami"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		identity, ok := auth.FromContext(ctx)
+		if !ok {
+			return mcp.NewToolResultError("anonymous"), nil
+		}
+		return mcp.NewToolResultText(identity.Subject), nil
+	})
+	handler := NewStreamableHTTPServer(s, 0)
+	defer handler.Close()
+	authenticators := []auth.Authenticator{staticAuthenticator{&auth.Identity{Subject: "alice", Method: auth.MethodToken}}}
+	ts := httptest.NewServer(auth.Middleware(authenticators, handler))
+	defer ts.Close()
+
+	resp := postMessage(t, ts.URL, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"test","version":"1"},"capabilities":{}}}`)
+	sessionID := resp.Header.Get(SessionIDHeader)
+
+	resp = postMessage(t, ts.URL, sessionID, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"whoami","arguments":{}}}`)
+	var result struct {
+		Result struct {
+			Content []mcp.TextContent `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || len(result.Result.Content) != 1 || result.Result.Content[0].Text != "alice" {
+		t.Errorf("Expected the tool to see alice, got %v %+v", err, result)
+	}
+
+	// Another identity cannot use alice's session
+	other := httptest.NewServer(auth.Middleware([]auth.Authenticator{staticAuthenticator{&auth.Identity{Subject: "bob", Method: auth.MethodToken}}}, handler))
+	defer other.Close()
+	if resp := postMessage(t, other.URL, sessionID, `{"jsonrpc":"2.0","id":3,"method":"ping"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for another identity's session, got %d", resp.StatusCode)
+	}
+}
+
+// TestHealthEndpoints tests the liveness and readiness handlers
+func TestHealthEndpoints(t *testing.T) {
+	rec := httptest.NewRecorder()
+	HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 from health, got %d", rec.Code)
+	}
+
+	ready := ReadinessHandler(func(ctx context.Context) error { return nil })
+	rec = httptest.NewRecorder()
+	ready.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 when HAProxy is reachable, got %d", rec.Code)
+	}
+
+	notReady := ReadinessHandler(func(ctx context.Context) error {
+		return errors.New("dial unix /var/run/haproxy.sock: connection refused")
+	})
+	rec = httptest.NewRecorder()
+	notReady.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable || strings.TrimSpace(rec.Body.String()) != `{"status":"unavailable"}` {
+		t.Errorf("Expected 503 without the error, got %d %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,343 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+)
+
+// StdioSessionID is the session ID mcp-go assigns to the single stdio client
+const StdioSessionID = "stdio"
+
+// Subscription request methods defined by the MCP specification
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// Subscriptions tracks which sessions subscribed to which resource URIs.
+//
+// mcp-go does not route resources/subscribe yet, so Intercept records
+// subscription requests and rewrites them into pings before they reach the
+// server. Both methods answer with an empty result, which is what clients expect.
+//
+// With a policy, a session may only subscribe to resources its identity may
+// read. Denied subscriptions are handed to the server as reads of the URI,
+// which fail the same check, so the client gets the read's permission error.
+type Subscriptions struct {
+	policy *auth.Policy
+
+	mu         sync.RWMutex
+	byURI      map[string]map[string]struct{} // URI -> session IDs
+	identities map[string]*auth.Identity      // Session ID -> identity that opened the session, nil without authentication
+}
+
+// NewSubscriptions creates an empty subscription registry. policy, if not
+// nil, restricts subscriptions and notifications to readable resources.
+func NewSubscriptions(policy *auth.Policy) *Subscriptions {
+	return &Subscriptions{
+		policy:     policy,
+		byURI:      make(map[string]map[string]struct{}),
+		identities: make(map[string]*auth.Identity),
+	}
+}
+
+// Subscribe registers a session's interest in a resource URI
+func (s *Subscriptions) Subscribe(sessionID, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, ok := s.byURI[uri]
+	if !ok {
+		sessions = make(map[string]struct{})
+		s.byURI[uri] = sessions
+	}
+	sessions[sessionID] = struct{}{}
+}
+
+// Unsubscribe removes a session's interest in a resource URI
+func (s *Subscriptions) Unsubscribe(sessionID, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.byURI[uri], sessionID)
+	if len(s.byURI[uri]) == 0 {
+		delete(s.byURI, uri)
+	}
+}
+
+// RemoveSession drops every subscription held by a session
+func (s *Subscriptions) RemoveSession(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.identities, sessionID)
+	for uri, sessions := range s.byURI {
+		delete(sessions, sessionID)
+		if len(sessions) == 0 {
+			delete(s.byURI, uri)
+		}
+	}
+}
+
+// Allowed reports whether the identity of a session may read uri, so that
+// notifications about it can be sent to the session
+func (s *Subscriptions) Allowed(sessionID, uri string) bool {
+	if s.policy == nil {
+		return true
+	}
+	r, ok := resourceRequest(uri)
+	if !ok {
+		return false
+	}
+
+	s.mu.RLock()
+	identity := s.identities[sessionID]
+	s.mu.RUnlock()
+	return s.policy.Authorize(identity, r) == nil
+}
+
+// owns reports whether sessionID is a registered session opened by the client
+// of ctx. known is false for sessions that are not registered.
+func (s *Subscriptions) owns(ctx context.Context, sessionID string) (owned, known bool) {
+	s.mu.RLock()
+	identity, known := s.identities[sessionID]
+	s.mu.RUnlock()
+	return known && identityOwner(identity) == sessionOwner(ctx), known
+}
+
+// Subscribers returns the sessions subscribed to a resource URI, sorted for stable delivery
+func (s *Subscriptions) Subscribers(uri string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sessions := make([]string, 0, len(s.byURI[uri]))
+	for sessionID := range s.byURI[uri] {
+		sessions = append(sessions, sessionID)
+	}
+	sort.Strings(sessions)
+	return sessions
+}
+
+// Empty reports whether no session holds any subscription
+func (s *Subscriptions) Empty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.byURI) == 0
+}
+
+// Hooks returns server hooks that record the identity opening each session and
+// drop subscriptions when a session ends
+func (s *Subscriptions) Hooks() *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddOnRegisterSession(func(ctx context.Context, session server.ClientSession) {
+		identity, _ := auth.FromContext(ctx)
+		s.mu.Lock()
+		s.identities[session.SessionID()] = identity
+		s.mu.Unlock()
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		s.RemoveSession(session.SessionID())
+	})
+	return hooks
+}
+
+// Intercept records subscribe and unsubscribe requests from a session of the
+// client of ctx and returns the message to hand to the MCP server. Other
+// messages are returned unchanged; JSON-RPC batches are rewritten element by element.
+func (s *Subscriptions) Intercept(ctx context.Context, sessionID string, message []byte) []byte {
+	return eachMessage(message, func(m []byte) []byte {
+		return s.interceptMessage(ctx, sessionID, m)
+	})
+}
+
+// eachMessage applies rewrite to message, or to each element of a JSON-RPC batch
+func eachMessage(message []byte, rewrite func([]byte) []byte) []byte {
+	trimmed := bytes.TrimSpace(message)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return rewrite(message)
+	}
+	var batch []json.RawMessage
+	if err := json.Unmarshal(trimmed, &batch); err != nil {
+		return message
+	}
+	for i, m := range batch {
+		batch[i] = rewrite(m)
+	}
+	rewritten, err := json.Marshal(batch)
+	if err != nil {
+		return message
+	}
+	return rewritten
+}
+
+// interceptMessage handles a single JSON-RPC message for Intercept
+func (s *Subscriptions) interceptMessage(ctx context.Context, sessionID string, message []byte) []byte {
+	var request struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id,omitempty"`
+		Method  string          `json:"method"`
+		Params  struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil || request.Params.URI == "" {
+		return message
+	}
+
+	rewrite := map[string]interface{}{
+		"jsonrpc": request.JSONRPC,
+		"id":      request.ID,
+		"method":  mcp.MethodPing,
+	}
+	switch request.Method {
+	case methodResourcesSubscribe:
+		if err := authorizeResource(ctx, s.policy, request.Params.URI); err != nil {
+			rewrite["method"] = mcp.MethodResourcesRead
+			rewrite["params"] = map[string]string{"uri": request.Params.URI}
+			break
+		}
+		slog.Debug("Resource subscription added", "session", sessionID, "uri", request.Params.URI)
+		s.Subscribe(sessionID, request.Params.URI)
+	case methodResourcesUnsubscribe:
+		slog.Debug("Resource subscription removed", "session", sessionID, "uri", request.Params.URI)
+		s.Unsubscribe(sessionID, request.Params.URI)
+	default:
+		return message
+	}
+
+	rewritten, err := json.Marshal(rewrite)
+	if err != nil {
+		return message
+	}
+	return rewritten
+}
+
+// StdioReader wraps the stdio input so subscription requests are intercepted
+// before the stdio server reads them
+func (s *Subscriptions) StdioReader(r io.Reader) io.Reader {
+	return rewriteLines(r, func(line []byte) []byte {
+		return s.Intercept(context.Background(), StdioSessionID, line)
+	})
+}
+
+// rewriteLines returns a reader of the non-empty lines of r, each rewritten by rewrite
+func rewriteLines(r io.Reader, rewrite func([]byte) []byte) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				out := append(rewrite(bytes.TrimSpace(line)), '\n')
+				if _, werr := pw.Write(out); werr != nil {
+					return
+				}
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return pr
+}
+
+// HTTPMiddleware intercepts subscription requests posted over HTTP. The session
+// is taken from the sessionId query parameter (SSE) or the Mcp-Session-Id header (streamable HTTP).
+// Requests for sessions opened by another identity are rejected as for unknown sessions;
+// requests for unregistered sessions are left to the transport to reject.
+func (s *Subscriptions) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.URL.Query().Get("sessionId")
+		if sessionID == "" {
+			sessionID = r.Header.Get(SessionIDHeader)
+		}
+		if r.Method != http.MethodPost || sessionID == "" || r.Body == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		owned, known := s.owns(r.Context(), sessionID)
+		if !known {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !owned {
+			slog.WarnContext(r.Context(), "Rejected request for a session of another identity", "session", sessionID)
+			writeJSONRPCError(w, http.StatusNotFound, mcp.INVALID_REQUEST, "session not found")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+		_ = r.Body.Close()
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		body = s.Intercept(r.Context(), sessionID, body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// StdioWriter serializes writes to the stdio output so notifications can be
+// written between the stdio server's responses.
+//
+// mcp-go marshals queued notifications by value, which drops their params, so
+// resource updates for the stdio session are written here instead.
+type StdioWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewStdioWriter wraps the stdio output
+func NewStdioWriter(w io.Writer) *StdioWriter {
+	return &StdioWriter{w: w}
+}
+
+// Write writes p in a single call so lines from different writers do not interleave
+func (s *StdioWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// Notify writes a notification for the stdio session
+func (s *StdioWriter) Notify(sessionID string, notification mcp.JSONRPCNotification) error {
+	if sessionID != StdioSessionID {
+		return server.ErrSessionNotFound
+	}
+	data, err := json.Marshal(&notification)
+	if err != nil {
+		return err
+	}
+	_, err = s.Write(append(data, '\n'))
+	return err
+}
+
+// SSENotifier delivers notifications through the SSE server's event queue,
+// which marshals them with their params intact
+func SSENotifier(sse *server.SSEServer) Notifier {
+	return func(sessionID string, notification mcp.JSONRPCNotification) error {
+		err := sse.SendEventToSession(sessionID, &notification)
+		// The SSE server reports unknown sessions with a plain error
+		if err != nil && strings.HasPrefix(err.Error(), "session not found") {
+			return server.ErrSessionNotFound
+		}
+		return err
+	}
+}
@@ -0,0 +1,341 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/auth"
+	haproxytesting "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/testing"
+)
+
+// fakeSession is a client session that buffers notifications for inspection
+type fakeSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (f *fakeSession) Initialize()       {}
+func (f *fakeSession) Initialized() bool { return true }
+func (f *fakeSession) SessionID() string { return f.id }
+func (f *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return f.notifications
+}
+
+// TestInterceptSubscriptions tests that subscription requests are recorded and answered as pings
+func TestInterceptSubscriptions(t *testing.T) {
+	subs := NewSubscriptions(nil)
+
+	out := subs.Intercept(context.Background(), "s1", []byte(`{"jsonrpc":"2.0","id":7,"method":"resources/subscribe","params":{"uri":"haproxy://backends/web"}}`))
+	var rewritten map[string]interface{}
+	if err := json.Unmarshal(out, &rewritten); err != nil {
+		t.Fatalf("Invalid rewritten message: %v", err)
+	}
+	if rewritten["method"] != "ping" || rewritten["id"] != float64(7) {
+		t.Errorf("Expected ping with id 7, got %s", out)
+	}
+	if got := subs.Subscribers("haproxy://backends/web"); len(got) != 1 || got[0] != "s1" {
+		t.Errorf("Expected s1 to be subscribed, got %v", got)
+	}
+
+	other := []byte(`{"jsonrpc":"2.0","id":8,"method":"resources/read","params":{"uri":"haproxy://backends/web"}}`)
+	if string(subs.Intercept(context.Background(), "s1", other)) != string(other) {
+		t.Error("Expected other requests to pass through unchanged")
+	}
+
+	subs.Intercept(context.Background(), "s1", []byte(`{"jsonrpc":"2.0","id":9,"method":"resources/unsubscribe","params":{"uri":"haproxy://backends/web"}}`))
+	if !subs.Empty() {
+		t.Errorf("Expected no subscriptions after unsubscribe, got %v", subs.Subscribers("haproxy://backends/web"))
+	}
+}
+
+// TestSubscriptionTransports tests interception on the stdio reader and the HTTP middleware
+func TestSubscriptionTransports(t *testing.T) {
+	subs := NewSubscriptions(nil)
+	request := `{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"haproxy://info"}}`
+
+	out, err := io.ReadAll(subs.StdioReader(strings.NewReader(request + "\n")))
+	if err != nil {
+		t.Fatalf("Failed to read stdio: %v", err)
+	}
+	if !strings.Contains(string(out), `"method":"ping"`) {
+		t.Errorf("Expected stdio request to be rewritten, got %s", out)
+	}
+
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithHooks(subs.Hooks()))
+	if err := s.RegisterSession(context.Background(), &fakeSession{id: "abc"}); err != nil {
+		t.Fatalf("Failed to register session: %v", err)
+	}
+
+	var forwarded string
+	handler := subs.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		forwarded = string(body)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/message?sessionId=abc", strings.NewReader(request))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if !strings.Contains(forwarded, `"method":"ping"`) {
+		t.Errorf("Expected HTTP request to be rewritten, got %s", forwarded)
+	}
+
+	got := subs.Subscribers("haproxy://info")
+	if strings.Join(got, ",") != "abc,stdio" {
+		t.Errorf("Expected abc and stdio subscriptions, got %v", got)
+	}
+}
+
+// TestSubscriptionPolicy tests that sessions may only subscribe to and be
+// notified about resources their identity may read, on their own sessions
+func TestSubscriptionPolicy(t *testing.T) {
+	policy := &auth.Policy{Rules: []auth.PolicyRule{{
+		Groups:   []string{"payments"},
+		Tools:    []string{"get_backend", "get_server"},
+		Backends: []string{"backend1"},
+	}}}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Invalid policy: %v", err)
+	}
+
+	client := haproxytesting.NewMockHAProxyClient()
+	runtime := client.RuntimeClient.(*haproxytesting.MockRuntimeClient)
+	subs := NewSubscriptions(policy)
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithResourceCapabilities(true, false), server.WithHooks(subs.Hooks()))
+	RegisterResources(s, client, policy)
+
+	alice := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "alice", Method: auth.MethodToken, Groups: []string{"payments"}})
+	bob := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "bob", Method: auth.MethodToken, Groups: []string{"checkout"}})
+	aliceSession := &fakeSession{id: "alice-session", notifications: make(chan mcp.JSONRPCNotification, 10)}
+	bobSession := &fakeSession{id: "bob-session", notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := s.RegisterSession(alice, aliceSession); err != nil {
+		t.Fatalf("Failed to register session: %v", err)
+	}
+	if err := s.RegisterSession(bob, bobSession); err != nil {
+		t.Fatalf("Failed to register session: %v", err)
+	}
+
+	out := subs.Intercept(alice, "alice-session", []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"haproxy://backends/backend1"}}`))
+	if !strings.Contains(string(out), `"method":"ping"`) {
+		t.Errorf("Expected an allowed subscription to be answered as a ping, got %s", out)
+	}
+
+	// A denied subscription is not recorded and is answered with the read's permission error
+	out = subs.Intercept(bob, "bob-session", []byte(`{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"haproxy://backends/backend1"}}`))
+	response, err := json.Marshal(s.HandleMessage(bob, out))
+	if err != nil || !strings.Contains(string(response), "permission denied") {
+		t.Errorf("Expected a permission error for a denied subscription, got %s", response)
+	}
+
+	// Subscriptions cannot be made on another identity's session
+	handler := subs.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected a request for another identity's session not to be forwarded")
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/message?sessionId=alice-session", strings.NewReader(`{"jsonrpc":"2.0","id":3,"method":"resources/subscribe","params":{"uri":"haproxy://backends/checkout"}}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req.WithContext(bob))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for another identity's session, got %d", rec.Code)
+	}
+
+	if got := strings.Join(subs.Subscribers("haproxy://backends/backend1"), ","); got != "alice-session" {
+		t.Errorf("Expected only alice-session to be subscribed, got %q", got)
+	}
+	if got := subs.Subscribers("haproxy://backends/checkout"); len(got) != 0 {
+		t.Errorf("Expected no subscription to checkout, got %v", got)
+	}
+
+	// Notifications are only sent to sessions allowed to read the resource
+	subs.Subscribe("bob-session", "haproxy://backends/backend1")
+	watcher := NewStateWatcher(s, client, subs, 0)
+	if err := watcher.poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	runtime.ServersState[0] = map[string]string{"be_name": "backend1", "srv_name": "server1", "srv_op_state": "0"}
+	runtime.ServersState[1] = map[string]string{"be_name": "backend1", "srv_name": "server2", "srv_op_state": "0"}
+	if err := watcher.poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(aliceSession.notifications) != 2 {
+		t.Errorf("Expected a resource update and an alert for alice, got %d notifications", len(aliceSession.notifications))
+	}
+	if len(bobSession.notifications) != 0 {
+		t.Errorf("Expected no notification for bob, got %d", len(bobSession.notifications))
+	}
+}
+
+// TestDiffSnapshots tests which resource URIs change between snapshots
+func TestDiffSnapshots(t *testing.T) {
+	previous := newStateSnapshot([]map[string]string{
+		{"be_name": "web", "srv_name": "web1", "srv_op_state": "2", "srv_admin_state": "0", "srv_uweight": "1"},
+		{"be_name": "web", "srv_name": "web2", "srv_op_state": "2", "srv_admin_state": "0", "srv_uweight": "1"},
+		{"be_name": "api", "srv_name": "api1", "srv_op_state": "2", "srv_admin_state": "0", "srv_uweight": "1"},
+	})
+
+	testCases := []struct {
+		name    string
+		current []map[string]string
+		want    string
+	}{
+		{
+			name: "No change",
+			current: []map[string]string{
+				{"be_name": "web", "srv_name": "web1", "srv_op_state": "2", "srv_admin_state": "0", "srv_uweight": "1"},
+				{"be_name": "web", "srv_name": "web2", "srv_op_state": "2", "srv_admin_state": "0", "srv_uweight": "1"},
+				{"be_name": "api", "srv_name": "api1", "srv_op_state": "2", "srv_admin_state": "0", "srv_uweight": "1"},
+			},
+			want: "",
+		},
+		{
+			name: "Server enters maintenance and weight changes",
+			current: []map[string]string{
+				{"be_name": "web", "srv_name": "web1", "srv_op_state": "2", "srv_admin_state": "1", "srv_uweight": "1"},
+				{"be_name": "web", "srv_name": "web2", "srv_op_state": "2", "srv_admin_state": "0", "srv_uweight": "5"},
+				{"be_name": "api", "srv_name": "api1", "srv_op_state": "2", "srv_admin_state": "0", "srv_uweight": "1"},
+			},
+			want: "haproxy://backends/web,haproxy://backends/web/servers/web1,haproxy://backends/web/servers/web2",
+		},
+		{
+			name: "Backend loses all servers",
+			current: []map[string]string{
+				{"be_name": "web", "srv_name": "web1", "srv_op_state": "2", "srv_admin_state": "0", "srv_uweight": "1"},
+				{"be_name": "web", "srv_name": "web2", "srv_op_state": "2", "srv_admin_state": "0", "srv_uweight": "1"},
+			},
+			want: "haproxy://backends,haproxy://backends/api,haproxy://backends/api/servers/api1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := strings.Join(diffSnapshots(previous, newStateSnapshot(tc.current)), ",")
+			if got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestStateWatcherNotifies tests that subscribed sessions are notified when a server goes down
+func TestStateWatcherNotifies(t *testing.T) {
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithResourceCapabilities(true, false))
+	session := &fakeSession{id: "s1", notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := s.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("Failed to register session: %v", err)
+	}
+
+	client := haproxytesting.NewMockHAProxyClient()
+	runtime := client.RuntimeClient.(*haproxytesting.MockRuntimeClient)
+	subs := NewSubscriptions(nil)
+	subs.Subscribe("s1", "haproxy://backends/backend1/servers/server2")
+	watcher := NewStateWatcher(s, client, subs, 0)
+
+	// The first poll only records the baseline
+	if err := watcher.poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(session.notifications) != 0 {
+		t.Fatalf("Expected no notification for the baseline, got %d", len(session.notifications))
+	}
+
+	runtime.ServersState[1] = map[string]string{"be_name": "backend1", "srv_name": "server2", "srv_op_state": "0"}
+	if err := watcher.poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+
+	select {
+	case n := <-session.notifications:
+		if n.Method != mcp.MethodNotificationResourceUpdated || n.Params.AdditionalFields["uri"] != "haproxy://backends/backend1/servers/server2" {
+			t.Errorf("Unexpected notification: %+v", n)
+		}
+	default:
+		t.Fatal("Expected a resource update notification")
+	}
+	if len(session.notifications) != 0 {
+		t.Errorf("Expected only the subscribed URI to be notified, got %d more", len(session.notifications))
+	}
+}
+
+// TestStateWatcherAlertsBackendDown tests that backend subscribers get a warning
+// message besides the resource update when a backend has no servers left in UP state
+func TestStateWatcherAlertsBackendDown(t *testing.T) {
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithResourceCapabilities(true, false), server.WithLogging())
+	session := &fakeSession{id: "s1", notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := s.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("Failed to register session: %v", err)
+	}
+
+	client := haproxytesting.NewMockHAProxyClient()
+	runtime := client.RuntimeClient.(*haproxytesting.MockRuntimeClient)
+	subs := NewSubscriptions(nil)
+	subs.Subscribe("s1", "haproxy://backends/backend1")
+	watcher := NewStateWatcher(s, client, subs, 0)
+
+	if err := watcher.poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+
+	// Losing one of two servers updates the backend without an alert
+	runtime.ServersState[0] = map[string]string{"be_name": "backend1", "srv_name": "server1", "srv_op_state": "0"}
+	if err := watcher.poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if n := <-session.notifications; n.Method != mcp.MethodNotificationResourceUpdated {
+		t.Errorf("Expected a resource update, got %+v", n)
+	}
+	if len(session.notifications) != 0 {
+		t.Fatalf("Expected no alert while a server is UP, got %d more notifications", len(session.notifications))
+	}
+
+	runtime.ServersState[1] = map[string]string{"be_name": "backend1", "srv_name": "server2", "srv_op_state": "0"}
+	if err := watcher.poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(session.notifications) != 2 {
+		t.Fatalf("Expected a resource update and an alert, got %d notifications", len(session.notifications))
+	}
+	if n := <-session.notifications; n.Method != mcp.MethodNotificationResourceUpdated || n.Params.AdditionalFields["uri"] != "haproxy://backends/backend1" {
+		t.Errorf("Unexpected notification: %+v", n)
+	}
+	n := <-session.notifications
+	data, _ := n.Params.AdditionalFields["data"].(map[string]any)
+	if n.Method != "notifications/message" || n.Params.AdditionalFields["level"] != mcp.LoggingLevelWarning || data["backend"] != "backend1" {
+		t.Errorf("Expected a warning message for backend1, got %+v", n)
+	}
+
+	// A backend that stays down is not alerted again
+	if err := watcher.poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(session.notifications) != 0 {
+		t.Errorf("Expected no notification for an unchanged backend, got %d", len(session.notifications))
+	}
+}
+
+// TestStdioWriterNotify tests that stdio notifications keep their params and skip other sessions
+func TestStdioWriterNotify(t *testing.T) {
+	var out strings.Builder
+	writer := NewStdioWriter(&out)
+	notification := mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: mcp.MethodNotificationResourceUpdated,
+			Params: mcp.NotificationParams{AdditionalFields: map[string]any{"uri": "haproxy://info"}},
+		},
+	}
+
+	if err := writer.Notify("other", notification); !errors.Is(err, server.ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound for another session, got %v", err)
+	}
+	if err := writer.Notify(StdioSessionID, notification); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"params":{"uri":"haproxy://info"}`) || !strings.HasSuffix(out.String(), "\n") {
+		t.Errorf("Unexpected stdio output: %q", out.String())
+	}
+}
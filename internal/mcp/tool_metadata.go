@@ -0,0 +1,250 @@
+package mcp
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// readOnlyTool annotates a tool that only reads HAProxy state
+func readOnlyTool(title string) mcp.ToolOption {
+	return mcp.WithToolAnnotation(mcp.ToolAnnotation{
+		Title:          title,
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	})
+}
+
+// updateTool annotates a tool that changes HAProxy state. destructive marks
+// changes that remove servers, traffic or data; idempotent marks changes that
+// have no further effect when repeated with the same arguments.
+func updateTool(title string, destructive, idempotent bool) mcp.ToolOption {
+	return mcp.WithToolAnnotation(mcp.ToolAnnotation{
+		Title:           title,
+		DestructiveHint: destructive,
+		IdempotentHint:  idempotent,
+	})
+}
+
+// outputObject is the schema of a callJSON result: an object holding value under key
+func outputObject(key string, value map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{key: value},
+		"required":   []string{key},
+	}
+}
+
+// Schemas of common values
+var (
+	stringSchema      = map[string]interface{}{"type": "string"}
+	stringListSchema  = map[string]interface{}{"type": "array", "items": stringSchema}
+	stringMapSchema   = map[string]interface{}{"type": "object", "additionalProperties": stringSchema}
+	stringTableSchema = map[string]interface{}{"type": "array", "items": stringMapSchema}
+	objectSchema      = map[string]interface{}{"type": "object"}
+	arraySchema       = map[string]interface{}{"type": "array", "items": objectSchema}
+
+	// rowPageSchema is the schema of a haproxy.RowPage
+	rowPageSchema = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"rows":        stringTableSchema,
+			"total":       map[string]interface{}{"type": "integer"},
+			"next_cursor": stringSchema,
+		},
+		"required": []string{"rows", "total"},
+	}
+
+	// sslCertListSchema is the schema of a runtime SSLCertList
+	sslCertListSchema = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"certificates": stringListSchema,
+			"transaction":  stringSchema,
+		},
+		"required": []string{"certificates"},
+	}
+
+	// sslFileListSchema is the schema of a runtime SSLFileList
+	sslFileListSchema = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"files":       stringListSchema,
+			"transaction": stringSchema,
+		},
+		"required": []string{"files"},
+	}
+
+	// certExpiryReportSchema is the schema of a haproxy.CertExpiryReport
+	certExpiryReportSchema = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"generated_at":  stringSchema,
+			"warning_days":  map[string]interface{}{"type": "integer"},
+			"critical_days": map[string]interface{}{"type": "integer"},
+			"summary":       map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "integer"}},
+			"certificates": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"certificate": stringSchema,
+						"not_after":   stringSchema,
+						"days_left":   map[string]interface{}{"type": "integer"},
+						"status":      map[string]interface{}{"type": "string", "enum": []string{"expired", "critical", "warning", "ok", "unknown"}},
+						"error":       stringSchema,
+					},
+					"required": []string{"certificate", "days_left", "status"},
+				},
+			},
+		},
+		"required": []string{"generated_at", "summary", "certificates"},
+	}
+
+	// resolverHealthReportSchema is the schema of a haproxy.ResolverHealthReport
+	resolverHealthReportSchema = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"max_failure_percent": map[string]interface{}{"type": "integer"},
+			"summary":             map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "integer"}},
+			"nameservers": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"resolvers":       stringSchema,
+						"nameserver":      stringSchema,
+						"status":          map[string]interface{}{"type": "string", "enum": []string{"failing", "ok", "idle"}},
+						"failure_percent": map[string]interface{}{"type": "number"},
+						"issues":          stringListSchema,
+						"counters":        objectSchema,
+					},
+					"required": []string{"resolvers", "nameserver", "status", "counters"},
+				},
+			},
+		},
+		"required": []string{"summary", "nameservers"},
+	}
+
+	// peersReportSchema is the schema of a haproxy.PeersReport
+	peersReportSchema = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"healthy":      map[string]interface{}{"type": "boolean"},
+			"connected":    map[string]interface{}{"type": "integer"},
+			"disconnected": map[string]interface{}{"type": "integer"},
+			"out_of_sync":  map[string]interface{}{"type": "integer"},
+			"peers": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"section":   stringSchema,
+						"peer":      stringSchema,
+						"address":   stringSchema,
+						"connected": map[string]interface{}{"type": "boolean"},
+						"synced":    map[string]interface{}{"type": "boolean"},
+						"status":    stringSchema,
+						"reconnect": stringSchema,
+						"issues":    stringListSchema,
+						"tables":    arraySchema,
+					},
+					"required": []string{"section", "peer", "connected", "synced", "tables"},
+				},
+			},
+		},
+		"required": []string{"healthy", "peers"},
+	}
+
+	// processDiagnosisSchema is the schema of a haproxy.ProcessDiagnosis
+	processDiagnosisSchema = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"generated_at": stringSchema,
+			"anomalies":    stringListSchema,
+			"activity":     objectSchema,
+			"threads":      arraySchema,
+			"pools":        objectSchema,
+			"tasks":        objectSchema,
+			"fds":          objectSchema,
+			"errors":       stringMapSchema,
+		},
+		"required": []string{"generated_at", "anomalies"},
+	}
+
+	// crtListEntriesSchema is the schema of a list of runtime CrtListEntry
+	crtListEntriesSchema = map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"certificate": stringSchema,
+				"line":        map[string]interface{}{"type": "integer"},
+				"ssl_options": stringSchema,
+				"sni_filters": stringListSchema,
+			},
+			"required": []string{"certificate"},
+		},
+	}
+
+	// messageOutput is the schema of a callExec result
+	messageOutput = outputObject("message", stringSchema)
+)
+
+// toolOutputSchemas describes the structured content returned by each tool
+var toolOutputSchemas = map[string]map[string]interface{}{
+	"show_stat":                outputObject("stats", rowPageSchema),
+	"show_info":                outputObject("info", stringMapSchema),
+	"debug_counters":           outputObject("counters", objectSchema),
+	"clear_counters_all":       messageOutput,
+	"dump_stats_file":          messageOutput,
+	"list_backends":            outputObject("backends", stringListSchema),
+	"get_backend":              outputObject("backend", objectSchema),
+	"show_servers_state":       outputObject("servers_state", rowPageSchema),
+	"list_servers":             outputObject("servers", stringListSchema),
+	"get_server":               outputObject("server", objectSchema),
+	"add_server":               messageOutput,
+	"del_server":               messageOutput,
+	"enable_server":            messageOutput,
+	"disable_server":           messageOutput,
+	"set_weight":               messageOutput,
+	"set_maxconn_server":       messageOutput,
+	"shutdown_sessions_server": messageOutput,
+	"shutdown_session":         messageOutput,
+	"enable_health":            messageOutput,
+	"disable_health":           messageOutput,
+	"enable_agent":             messageOutput,
+	"disable_agent":            messageOutput,
+	"list_ssl_certs":           outputObject("certificates", sslCertListSchema),
+	"show_ssl_cert":            outputObject("certificate", objectSchema),
+	"new_ssl_cert":             messageOutput,
+	"set_ssl_cert":             messageOutput,
+	"commit_ssl_cert":          messageOutput,
+	"abort_ssl_cert":           messageOutput,
+	"del_ssl_cert":             messageOutput,
+	"cert_expiry_report":       outputObject("report", certExpiryReportSchema),
+	"list_ocsp_responses":      outputObject("ocsp_responses", arraySchema),
+	"show_ocsp_response":       outputObject("ocsp_response", objectSchema),
+	"update_ocsp_response":     messageOutput,
+	"list_crt_lists":           outputObject("crt_lists", stringListSchema),
+	"show_crt_list":            outputObject("entries", crtListEntriesSchema),
+	"add_crt_list_entry":       messageOutput,
+	"del_crt_list_entry":       messageOutput,
+	"list_ca_files":            outputObject("ca_files", sslFileListSchema),
+	"show_ca_file":             outputObject("ca_file", objectSchema),
+	"new_ca_file":              messageOutput,
+	"set_ca_file":              messageOutput,
+	"add_ca_file":              messageOutput,
+	"commit_ca_file":           messageOutput,
+	"abort_ca_file":            messageOutput,
+	"list_crl_files":           outputObject("crl_files", sslFileListSchema),
+	"show_crl_file":            outputObject("crl_file", objectSchema),
+	"set_crl_file":             messageOutput,
+	"commit_crl_file":          messageOutput,
+	"abort_crl_file":           messageOutput,
+	"show_errors":              outputObject("errors", arraySchema),
+	"show_resolvers":           outputObject("report", resolverHealthReportSchema),
+	"show_peers":               outputObject("report", peersReportSchema),
+	"diagnose_process":         outputObject("diagnosis", processDiagnosisSchema),
+	"reload_haproxy":           messageOutput,
+	"execute_command":          outputObject("output", stringSchema),
+	"approve_change":           objectSchema, // Output of the approved tool
+}
@@ -7,12 +7,15 @@ import (
     "github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
 )
 
-func RegisterTools(s *server.MCPServer, client *haproxy.HAProxyClient) {
+// RegisterTools registers every HAProxy tool on the MCP server, backed by the given client
+func RegisterTools(s *server.MCPServer, client haproxy.Client) {
     slog.Info("Registering HAProxy MCP tools...")
     registerStatTools(s, client)
     registerBackendTools(s, client)
     registerServerTools(s, client)
     registerHealthAgentTools(s, client)
+    registerSSLTools(s, client)
+    registerDiagnosticTools(s, client)
     registerReloadTool(s, client)
     slog.Info("All HAProxy MCP tools registered successfully")
 }
\ No newline at end of file
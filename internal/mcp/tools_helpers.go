@@ -5,8 +5,11 @@ import (
     "encoding/json"
     "fmt"
     "log/slog"
+    "strings"
 
     "github.com/mark3labs/mcp-go/mcp"
+
+    "github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
 )
 
 // callJSON handles executing a client call, error logging, and JSON marshalling
@@ -16,12 +19,13 @@ func callJSON(ctx context.Context, action, mapKey string, fn func() (interface{}
         slog.ErrorContext(ctx, "Failed to "+action, "error", err)
         return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v", action, err)), nil
     }
-    out, err := json.Marshal(map[string]interface{}{mapKey: v})
+    structured := map[string]interface{}{mapKey: v}
+    out, err := json.Marshal(structured)
     if err != nil {
         slog.ErrorContext(ctx, "Failed to marshal "+mapKey+" output", "error", err)
         return mcp.NewToolResultError("Internal server error: failed to marshal results"), nil
     }
-    return mcp.NewToolResultText(string(out)), nil
+    return structuredResult(string(out), structured), nil
 }
 
 // callExec handles executing a client action, error logging, and returning plain text
@@ -31,7 +35,14 @@ func callExec(ctx context.Context, action string, fn func() (string, error)) (*m
         slog.ErrorContext(ctx, "Failed to "+action, "error", err)
         return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v", action, err)), nil
     }
-    return mcp.NewToolResultText(s), nil
+    return structuredResult(s, map[string]interface{}{"message": s}), nil
+}
+
+// structuredResult returns a text result that also carries structured content (see OutputRewriter)
+func structuredResult(text string, structured interface{}) *mcp.CallToolResult {
+    result := mcp.NewToolResultText(text)
+    result.Meta = map[string]interface{}{structuredContentMeta: structured}
+    return result
 }
 
 // getString extracts a string argument from the request
@@ -42,10 +53,57 @@ func getString(req mcp.CallToolRequest, key string) string {
     return ""
 }
 
+// getBool extracts a boolean argument from the request
+func getBool(req mcp.CallToolRequest, key string) bool {
+    v, _ := req.Params.Arguments[key].(bool)
+    return v
+}
+
 // getInt extracts a numeric argument (as int) from the request
 func getInt(req mcp.CallToolRequest, key string) int {
     if f, ok := req.Params.Arguments[key].(float64); ok {
         return int(f)
     }
     return 0
-}
\ No newline at end of file
+}
+
+// getList extracts a comma separated string argument as a list
+func getList(req mcp.CallToolRequest, key string) []string {
+    var items []string
+    for _, item := range strings.Split(getString(req, key), ",") {
+        if item = strings.TrimSpace(item); item != "" {
+            items = append(items, item)
+        }
+    }
+    return items
+}
+
+// withRowQuery adds the pagination, field selection, sorting and filter
+// arguments read by getRowQuery. types adds the row type filter of show stat;
+// statuses describes the status values of the table.
+func withRowQuery(types bool, statuses string) mcp.ToolOption {
+    return func(t *mcp.Tool) {
+        mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum rows to return (default %d, max %d)", haproxy.DefaultRowLimit, haproxy.MaxRowLimit)))(t)
+        mcp.WithString("cursor", mcp.Description("next_cursor of the previous page"))(t)
+        mcp.WithString("fields", mcp.Description("Comma separated columns to return, e.g. pxname,svname,status,scur"))(t)
+        mcp.WithString("sort", mcp.Description("Column to sort by; prefix with - for descending order, e.g. -scur"))(t)
+        mcp.WithString("status", mcp.Description("Comma separated status prefixes to keep, case-insensitive. "+statuses))(t)
+        mcp.WithString("name_regex", mcp.Description("Regular expression matched against proxy and server names"))(t)
+        if types {
+            mcp.WithString("type", mcp.Description("Comma separated row types to keep: frontend, backend, server, listener"))(t)
+        }
+    }
+}
+
+// getRowQuery extracts the arguments added by withRowQuery
+func getRowQuery(req mcp.CallToolRequest) haproxy.RowQuery {
+    return haproxy.RowQuery{
+        Fields:    getList(req, "fields"),
+        Types:     getList(req, "type"),
+        Statuses:  getList(req, "status"),
+        NameRegex: getString(req, "name_regex"),
+        SortBy:    getString(req, "sort"),
+        Limit:     getInt(req, "limit"),
+        Cursor:    getString(req, "cursor"),
+    }
+}
@@ -0,0 +1,332 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
+	haproxytesting "github.com/tuannvm/haproxy-mcp-server/internal/haproxy/testing"
+)
+
+// newTestServer registers all tools against a HAProxy client backed by mocks
+func newTestServer(t *testing.T) (*server.MCPServer, *haproxy.HAProxyClient, *haproxytesting.MockRuntimeClient) {
+	t.Helper()
+
+	client := haproxytesting.NewMockHAProxyClient()
+	runtime, ok := client.RuntimeClient.(*haproxytesting.MockRuntimeClient)
+	if !ok {
+		t.Fatalf("Expected mock runtime client, got %T", client.RuntimeClient)
+	}
+
+	s := server.NewMCPServer("haproxy-mcp-server-test", "test", server.WithToolCapabilities(true))
+	RegisterTools(s, client)
+	NewCommandGuard(nil, DefaultCommandDeny, false).Register(s, client, nil)
+	return s, client, runtime
+}
+
+// callTool sends a tools/call request through the MCP server and returns the tool result
+func callTool(t *testing.T, s *server.MCPServer, name string, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	return callToolWithContext(t, context.Background(), s, name, args)
+}
+
+// callToolWithContext is like callTool with a request context, e.g. one carrying an identity
+func callToolWithContext(t *testing.T, ctx context.Context, s *server.MCPServer, name string, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+
+	message, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params": map[string]interface{}{
+			"name":      name,
+			"arguments": args,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	response := s.HandleMessage(ctx, message)
+	rpcResponse, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("Expected JSON-RPC response for %s, got %#v", name, response)
+	}
+	result, ok := rpcResponse.Result.(mcp.CallToolResult)
+	if !ok {
+		t.Fatalf("Expected tool result for %s, got %T", name, rpcResponse.Result)
+	}
+	return &result
+}
+
+// resultText returns the text content of a tool result
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+
+	if len(result.Content) == 0 {
+		t.Fatal("Expected tool result content")
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("Expected text content, got %T", result.Content[0])
+	}
+	return text.Text
+}
+
+// TestReadTools tests that read-only tools return data from the mocks
+func TestReadTools(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tool     string
+		args     map[string]interface{}
+		contains string
+	}{
+		{name: "List backends", tool: "list_backends", contains: "backend2"},
+		{name: "Get backend", tool: "get_backend", args: map[string]interface{}{"name": "backend1"}, contains: `"name":"backend1"`},
+		{name: "Show servers state", tool: "show_servers_state", args: map[string]interface{}{"backend": "backend1"}, contains: "10.0.0.2"},
+		{name: "List servers", tool: "list_servers", args: map[string]interface{}{"backend": "backend1"}, contains: "server2"},
+		{name: "Get server", tool: "get_server", args: map[string]interface{}{"backend": "backend1", "server": "server1"}, contains: `"status":"UP"`},
+		{name: "Show stat", tool: "show_stat", contains: "BACKEND"},
+		{name: "Show stat page", tool: "show_stat", args: map[string]interface{}{"type": "server", "fields": "svname,status", "limit": 1}, contains: `{"stats":{"rows":[{"status":"UP","svname":"server1"}],"total":1}}`},
+		{name: "Show servers state page", tool: "show_servers_state", args: map[string]interface{}{"sort": "-srv_addr", "limit": 1}, contains: `"srv_name":"server2","srv_op_state":"2"}],"total":2,"next_cursor":"1"`},
+		{name: "Show servers state status", tool: "show_servers_state", args: map[string]interface{}{"status": "up", "fields": "srv_name"}, contains: `{"servers_state":{"rows":[{"srv_name":"server1"},{"srv_name":"server2"}],"total":2}}`},
+		{name: "Show info", tool: "show_info", contains: "2.4.0"},
+		{name: "Debug counters", tool: "debug_counters", contains: "connections"},
+		{name: "List SSL certs", tool: "list_ssl_certs", contains: `{"certificates":{"certificates":["/etc/haproxy/ssl/site.pem"]}}`},
+		{name: "Show crt-list", tool: "show_crt_list", args: map[string]interface{}{"crt_list": "/etc/haproxy/crt-list.txt"}, contains: `"line":1,"ssl_options":"alpn h2","sni_filters":["example.com"]`},
+		{name: "Cert expiry report", tool: "cert_expiry_report", args: map[string]interface{}{"warning_days": 36500, "critical_days": 1}, contains: `"not_after":"Jan  1 00:00:00 2027 GMT"`},
+		{name: "Show OCSP response", tool: "show_ocsp_response", args: map[string]interface{}{"id": "/etc/haproxy/ssl/site.pem"}, contains: `"cert_status":"good"`},
+		{name: "Show errors by frontend", tool: "show_errors", args: map[string]interface{}{"frontend": "http-in", "direction": "response"}, contains: `"backend":"backend1","server":"server1"`},
+		{name: "Show errors by backend", tool: "show_errors", args: map[string]interface{}{"backend": "backend2"}, contains: `{"errors":[]}`},
+		{name: "Show resolvers", tool: "show_resolvers", contains: `"nameserver":"dns2","status":"failing","failure_percent":40`},
+		{name: "Show resolvers threshold", tool: "show_resolvers", args: map[string]interface{}{"resolvers": "dns", "max_failure_percent": 50}, contains: `"summary":{"failing":0,"idle":0,"ok":2}`},
+		{name: "Show peers", tool: "show_peers", args: map[string]interface{}{"peers": "mypeers"}, contains: `"healthy":true,"connected":1,"disconnected":0,"out_of_sync":0`},
+		{name: "Diagnose process", tool: "diagnose_process", contains: `"anomalies":["thread 2 is stuck running process_stream"]`},
+		{name: "List CA files", tool: "list_ca_files", contains: `{"ca_files":{"files":["/etc/haproxy/ssl/clients-ca.pem"]}}`},
+		{name: "Show CRL file", tool: "show_crl_file", args: map[string]interface{}{"name": "/etc/haproxy/ssl/clients.crl"}, contains: `"revoked":[{"serial":"1008","revocation_date":"Oct 18 00:00:00 2026 GMT"}]`},
+		{name: "Show SSL cert", tool: "show_ssl_cert", args: map[string]interface{}{"name": "/etc/haproxy/ssl/site.pem"}, contains: `"subject_alt_names":["DNS:example.com"]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, _ := newTestServer(t)
+
+			result := callTool(t, s, tc.tool, tc.args)
+			text := resultText(t, result)
+			if result.IsError {
+				t.Fatalf("Unexpected tool error: %s", text)
+			}
+			if !strings.Contains(text, tc.contains) {
+				t.Errorf("Expected %q in result, got: %s", tc.contains, text)
+			}
+		})
+	}
+}
+
+// TestWriteTools tests that mutating tools reach the runtime client with the right arguments
+func TestWriteTools(t *testing.T) {
+	s, _, runtime := newTestServer(t)
+
+	callTool(t, s, "add_server", map[string]interface{}{"backend": "backend1", "name": "server3", "addr": "10.0.0.3", "port": 8080, "weight": 50})
+	if len(runtime.AddedServers) != 1 || runtime.AddedServers[0]["port"] != 8080 || runtime.AddedServers[0]["weight"] != 50 {
+		t.Errorf("Unexpected added servers: %v", runtime.AddedServers)
+	}
+
+	callTool(t, s, "del_server", map[string]interface{}{"backend": "backend1", "name": "server3"})
+	if len(runtime.DeletedServers) != 1 || runtime.DeletedServers[0]["name"] != "server3" {
+		t.Errorf("Unexpected deleted servers: %v", runtime.DeletedServers)
+	}
+
+	callTool(t, s, "enable_server", map[string]interface{}{"backend": "backend1", "server": "server1"})
+	callTool(t, s, "disable_server", map[string]interface{}{"backend": "backend1", "server": "server1"})
+	if len(runtime.EnabledServers) != 1 || len(runtime.DisabledServers) != 1 {
+		t.Errorf("Expected one enable and one disable, got %v and %v", runtime.EnabledServers, runtime.DisabledServers)
+	}
+
+	result := callTool(t, s, "set_weight", map[string]interface{}{"backend": "backend1", "server": "server1", "weight": 10})
+	if text := resultText(t, result); !strings.Contains(text, "set to 10") {
+		t.Errorf("Unexpected set_weight result: %s", text)
+	}
+	callTool(t, s, "set_maxconn_server", map[string]interface{}{"backend": "backend1", "server": "server1", "maxconn": 500})
+	if len(runtime.WeightUpdates) != 1 || len(runtime.MaxconnUpdates) != 1 || runtime.MaxconnUpdates[0]["maxconn"] != 500 {
+		t.Errorf("Unexpected updates: weight %v, maxconn %v", runtime.WeightUpdates, runtime.MaxconnUpdates)
+	}
+
+	for _, tool := range []string{"enable_health", "disable_health", "enable_agent", "disable_agent"} {
+		callTool(t, s, tool, map[string]interface{}{"backend": "backend1", "server": "server2"})
+	}
+	wantToggles := []string{"enable health", "disable health", "enable agent", "disable agent"}
+	if len(runtime.CheckToggles) != len(wantToggles) {
+		t.Fatalf("Expected %d check toggles, got %v", len(wantToggles), runtime.CheckToggles)
+	}
+	for i, want := range wantToggles {
+		toggle := runtime.CheckToggles[i]
+		if got := toggle["action"] + " " + toggle["check"]; got != want || toggle["server"] != "server2" {
+			t.Errorf("Toggle %d: expected %q on server2, got %v", i, want, toggle)
+		}
+	}
+
+	callTool(t, s, "shutdown_sessions_server", map[string]interface{}{"backend": "backend1", "server": "server1"})
+	callTool(t, s, "shutdown_session", map[string]interface{}{"id": "0x55d1c3e0a2b0"})
+	if len(runtime.ShutdownServers) != 1 || runtime.ShutdownServers[0]["server"] != "server1" {
+		t.Errorf("Unexpected server session shutdowns: %v", runtime.ShutdownServers)
+	}
+	if len(runtime.ShutdownSessions) != 1 || runtime.ShutdownSessions[0] != "0x55d1c3e0a2b0" {
+		t.Errorf("Unexpected session shutdowns: %v", runtime.ShutdownSessions)
+	}
+
+	callTool(t, s, "clear_counters_all", nil)
+	callTool(t, s, "dump_stats_file", map[string]interface{}{"filepath": "/tmp/stats.dump"})
+	callTool(t, s, "reload_haproxy", nil)
+	if runtime.CountersCleared != 1 || runtime.Reloads != 1 {
+		t.Errorf("Expected one clear and one reload, got %d and %d", runtime.CountersCleared, runtime.Reloads)
+	}
+	if len(runtime.DumpedStatsFiles) != 1 || runtime.DumpedStatsFiles[0] != "/tmp/stats.dump" {
+		t.Errorf("Unexpected dumped stats files: %v", runtime.DumpedStatsFiles)
+	}
+
+	if len(runtime.ExecutedCommands) != 0 {
+		t.Errorf("Expected no raw runtime commands, got %v", runtime.ExecutedCommands)
+	}
+}
+
+// TestSSLCertTools tests staging, reviewing, committing and deleting a certificate, and attaching it to a crt-list
+func TestSSLCertTools(t *testing.T) {
+	s, _, runtime := newTestServer(t)
+	name := "/etc/haproxy/ssl/new.pem"
+
+	for _, step := range []struct {
+		tool string
+		args map[string]interface{}
+	}{
+		{tool: "new_ssl_cert", args: map[string]interface{}{"name": name}},
+		{tool: "set_ssl_cert", args: map[string]interface{}{"name": name, "payload": "-----BEGIN CERTIFICATE-----"}},
+		{tool: "show_ssl_cert", args: map[string]interface{}{"name": name, "transaction": true}},
+		{tool: "commit_ssl_cert", args: map[string]interface{}{"name": name}},
+		{tool: "del_ssl_cert", args: map[string]interface{}{"name": name}},
+	} {
+		if result := callTool(t, s, step.tool, step.args); result.IsError {
+			t.Fatalf("Unexpected %s error: %s", step.tool, resultText(t, result))
+		}
+	}
+	if runtime.SSLPayloads[name] != "-----BEGIN CERTIFICATE-----" || len(runtime.SSLCommits) != 1 || len(runtime.DeletedSSLCerts) != 1 {
+		t.Errorf("Unexpected certificate changes: payloads %v, commits %v, deletions %v", runtime.SSLPayloads, runtime.SSLCommits, runtime.DeletedSSLCerts)
+	}
+
+	crtList := "/etc/haproxy/crt-list.txt"
+	callTool(t, s, "new_ssl_cert", map[string]interface{}{"name": name})
+	result := callTool(t, s, "add_crt_list_entry", map[string]interface{}{"crt_list": crtList, "certificate": name, "ssl_options": "alpn h2", "sni_filters": "shop.example.com, *.shop.example.com"})
+	if result.IsError {
+		t.Fatalf("Unexpected add_crt_list_entry error: %s", resultText(t, result))
+	}
+	if entries := runtime.CrtLists[crtList]; len(entries) != 2 || len(entries[1].SNIFilters) != 2 || entries[1].SSLOptions != "alpn h2" {
+		t.Errorf("Unexpected crt-list entries: %+v", entries)
+	}
+	callTool(t, s, "del_crt_list_entry", map[string]interface{}{"crt_list": crtList, "certificate": name, "line": 2})
+	if entries := runtime.CrtLists[crtList]; len(entries) != 1 {
+		t.Errorf("Expected the entry to be removed, got %+v", entries)
+	}
+
+	result = callTool(t, s, "update_ocsp_response", map[string]interface{}{"certificate": "/etc/haproxy/ssl/site.pem"})
+	if result.IsError || len(runtime.OCSPUpdates) != 1 {
+		t.Errorf("Unexpected update_ocsp_response result: %s", resultText(t, result))
+	}
+	result = callTool(t, s, "cert_expiry_report", map[string]interface{}{"warning_days": 7, "critical_days": 30})
+	if !result.IsError || !strings.Contains(resultText(t, result), "must not exceed") {
+		t.Errorf("Expected inverted thresholds to fail, got: %s", resultText(t, result))
+	}
+
+	result = callTool(t, s, "abort_ssl_cert", map[string]interface{}{"name": name})
+	if !result.IsError || !strings.Contains(resultText(t, result), "Failed to abort SSL certificate") {
+		t.Errorf("Expected abort without a transaction to fail, got: %+v", result)
+	}
+}
+
+// TestCAAndCRLFileTools tests the CA and CRL file transactions through the tools
+func TestCAAndCRLFileTools(t *testing.T) {
+	s, _, runtime := newTestServer(t)
+	caFile := "/etc/haproxy/ssl/partners-ca.pem"
+	crlFile := "/etc/haproxy/ssl/clients.crl"
+
+	for _, step := range []struct {
+		tool string
+		args map[string]interface{}
+	}{
+		{tool: "new_ca_file", args: map[string]interface{}{"name": caFile}},
+		{tool: "set_ca_file", args: map[string]interface{}{"name": caFile, "payload": "ca1"}},
+		{tool: "add_ca_file", args: map[string]interface{}{"name": caFile, "payload": "ca2"}},
+		{tool: "show_ca_file", args: map[string]interface{}{"name": caFile, "transaction": true}},
+		{tool: "commit_ca_file", args: map[string]interface{}{"name": caFile}},
+		{tool: "set_crl_file", args: map[string]interface{}{"name": crlFile, "payload": "crl"}},
+		{tool: "show_crl_file", args: map[string]interface{}{"name": crlFile, "transaction": true}},
+		{tool: "commit_crl_file", args: map[string]interface{}{"name": crlFile}},
+	} {
+		if result := callTool(t, s, step.tool, step.args); result.IsError {
+			t.Fatalf("Unexpected %s error: %s", step.tool, resultText(t, result))
+		}
+	}
+	if runtime.SSLPayloads[caFile] != "ca1\nca2" || runtime.SSLPayloads[crlFile] != "crl" || strings.Join(runtime.SSLCommits, ",") != caFile+","+crlFile {
+		t.Errorf("Unexpected CA and CRL changes: payloads %v, commits %v", runtime.SSLPayloads, runtime.SSLCommits)
+	}
+
+	result := callTool(t, s, "abort_crl_file", map[string]interface{}{"name": crlFile})
+	if !result.IsError || !strings.Contains(resultText(t, result), "Failed to abort CRL file") {
+		t.Errorf("Expected abort without a transaction to fail, got: %+v", result)
+	}
+}
+
+// TestToolErrors tests that runtime failures are reported as tool errors
+func TestToolErrors(t *testing.T) {
+	s, _, runtime := newTestServer(t)
+	runtime.FailAddServer = true
+	runtime.FailServersState = true
+
+	result := callTool(t, s, "add_server", map[string]interface{}{"backend": "backend1", "name": "server3", "addr": "10.0.0.3"})
+	if !result.IsError || !strings.Contains(resultText(t, result), "Failed to add server") {
+		t.Errorf("Expected add_server error, got: %+v", result)
+	}
+
+	result = callTool(t, s, "show_servers_state", nil)
+	if !result.IsError {
+		t.Errorf("Expected show_servers_state error, got: %+v", result)
+	}
+}
+
+// TestShowStatRuntimeFallback tests that show_stat falls back to the runtime client when the stats page fails
+func TestShowStatRuntimeFallback(t *testing.T) {
+	s, client, _ := newTestServer(t)
+	stats := haproxytesting.NewMockStatsClient()
+	stats.FailGetStats = true
+	client.StatsClient = haproxytesting.NewStatsClientAdapter(stats)
+
+	result := callTool(t, s, "show_stat", map[string]interface{}{"filter": "backend1"})
+	text := resultText(t, result)
+	if result.IsError || !strings.Contains(text, `"weight":"200"`) {
+		t.Errorf("Expected runtime stats rows, got: %s", text)
+	}
+}
+
+// TestShowStatPageColumns tests that show_stat pages carry every show stat
+// column when the stats page, whose rows only carry a few, is configured
+func TestShowStatPageColumns(t *testing.T) {
+	s, client, runtime := newTestServer(t)
+	if client.StatsClient == nil {
+		t.Fatal("Expected the test client to have a stats client")
+	}
+	runtime.StatRows = []map[string]string{
+		{"pxname": "backend1", "svname": "server1", "type": "2", "status": "UP", "scur": "3"},
+		{"pxname": "backend1", "svname": "server2", "type": "2", "status": "UP", "scur": "12"},
+		{"pxname": "backend1", "svname": "BACKEND", "type": "1", "status": "UP", "scur": "15"},
+	}
+
+	result := callTool(t, s, "show_stat", map[string]interface{}{"type": "server", "fields": "svname,scur", "sort": "-scur"})
+	want := `{"stats":{"rows":[{"scur":"12","svname":"server2"},{"scur":"3","svname":"server1"}],"total":2}}`
+	if text := resultText(t, result); result.IsError || text != want {
+		t.Errorf("Expected %s, got %s", want, text)
+	}
+}
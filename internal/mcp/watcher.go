@@ -0,0 +1,276 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tuannvm/haproxy-mcp-server/internal/haproxy"
+)
+
+// serverSnapshot is the watched state of a single server
+type serverSnapshot struct {
+	Status string // UP, DOWN, STARTING, STOPPING, MAINT or DRAIN, see haproxy.ServerStatus
+	Weight string // User-visible weight
+}
+
+// stateSnapshot maps backend name to server name to server state
+type stateSnapshot map[string]map[string]serverSnapshot
+
+// newStateSnapshot builds a snapshot from 'show servers state' rows
+func newStateSnapshot(rows []map[string]string) stateSnapshot {
+	snapshot := make(stateSnapshot)
+	for _, row := range rows {
+		backend, name := row["be_name"], row["srv_name"]
+		if backend == "" || name == "" {
+			continue
+		}
+
+		if snapshot[backend] == nil {
+			snapshot[backend] = make(map[string]serverSnapshot)
+		}
+		snapshot[backend][name] = serverSnapshot{Status: haproxy.ServerStatus(row), Weight: row["srv_uweight"]}
+	}
+	return snapshot
+}
+
+// backendURI returns the resource URI of a backend
+func backendURI(backend string) string {
+	return fmt.Sprintf("haproxy://backends/%s", url.PathEscape(backend))
+}
+
+// serverURI returns the resource URI of a server
+func serverURI(backend, server string) string {
+	return fmt.Sprintf("haproxy://backends/%s/servers/%s", url.PathEscape(backend), url.PathEscape(server))
+}
+
+// countUp returns the number of servers in UP state
+func countUp(servers map[string]serverSnapshot) int {
+	up := 0
+	for _, s := range servers {
+		if s.Status == "UP" {
+			up++
+		}
+	}
+	return up
+}
+
+// diffSnapshots returns the sorted resource URIs whose content changed between two snapshots.
+// A server URI changes when its status or weight changes or it appears or disappears; its
+// backend URI changes with it, and the backend list URI changes when backends come or go.
+func diffSnapshots(previous, current stateSnapshot) []string {
+	changed := make(map[string]struct{})
+
+	backends := make(map[string]struct{})
+	for backend := range previous {
+		backends[backend] = struct{}{}
+	}
+	for backend := range current {
+		backends[backend] = struct{}{}
+	}
+
+	for backend := range backends {
+		before, hadBackend := previous[backend]
+		after, hasBackend := current[backend]
+		if hadBackend != hasBackend {
+			changed[backendsResourceURI] = struct{}{}
+		}
+
+		servers := make(map[string]struct{})
+		for name := range before {
+			servers[name] = struct{}{}
+		}
+		for name := range after {
+			servers[name] = struct{}{}
+		}
+
+		backendChanged := false
+		for name := range servers {
+			old, hadServer := before[name]
+			now, hasServer := after[name]
+			if hadServer != hasServer || old != now {
+				changed[serverURI(backend, name)] = struct{}{}
+				backendChanged = true
+			}
+		}
+
+		if backendChanged {
+			changed[backendURI(backend)] = struct{}{}
+		}
+	}
+
+	uris := make([]string, 0, len(changed))
+	for uri := range changed {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// backendsDown returns the sorted backends that had servers in UP state in
+// the previous snapshot and have none left in the current one
+func backendsDown(previous, current stateSnapshot) []string {
+	var down []string
+	for backend, before := range previous {
+		if countUp(before) > 0 && countUp(current[backend]) == 0 {
+			down = append(down, backend)
+		}
+	}
+	sort.Strings(down)
+	return down
+}
+
+// Notifier delivers a notification to one session. It returns
+// server.ErrSessionNotFound for sessions that belong to another transport.
+type Notifier func(sessionID string, notification mcp.JSONRPCNotification) error
+
+// StateWatcher polls server state and notifies subscribed sessions when resources change
+type StateWatcher struct {
+	server   *server.MCPServer
+	client   haproxy.Client
+	subs     *Subscriptions
+	interval time.Duration
+	previous stateSnapshot
+
+	mu        sync.Mutex
+	notifiers []Notifier
+}
+
+// NewStateWatcher creates a watcher that polls every interval
+func NewStateWatcher(s *server.MCPServer, client haproxy.Client, subs *Subscriptions, interval time.Duration) *StateWatcher {
+	return &StateWatcher{server: s, client: client, subs: subs, interval: interval}
+}
+
+// AddNotifier registers a transport-specific notifier. Notifiers are tried in
+// order before the session's notification channel on the MCP server.
+func (w *StateWatcher) AddNotifier(n Notifier) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.notifiers = append(w.notifiers, n)
+}
+
+// Run polls until ctx is done
+func (w *StateWatcher) Run(ctx context.Context) {
+	slog.Info("Starting resource state watcher", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Resource state watcher stopped")
+			return
+		case <-ticker.C:
+			pollCtx, cancel := context.WithTimeout(ctx, w.interval)
+			if err := w.poll(pollCtx); err != nil {
+				slog.Warn("Failed to poll server state", "error", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// poll takes a snapshot, compares it with the previous one and sends notifications.
+// Nothing is polled while no session is subscribed.
+func (w *StateWatcher) poll(ctx context.Context) error {
+	if w.subs.Empty() {
+		w.previous = nil
+		return nil
+	}
+
+	rows, err := w.client.ShowServersStateWithContext(ctx, "")
+	if err != nil {
+		return err
+	}
+	current := newStateSnapshot(rows)
+
+	// The first snapshot after subscribing only sets the baseline
+	if w.previous != nil {
+		for _, uri := range diffSnapshots(w.previous, current) {
+			w.notify(uri)
+		}
+		for _, backend := range backendsDown(w.previous, current) {
+			w.alertDown(backend)
+		}
+	}
+	w.previous = current
+	return nil
+}
+
+// notify sends notifications/resources/updated to every session subscribed to uri
+func (w *StateWatcher) notify(uri string) {
+	w.broadcast(uri, newNotification(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri}))
+}
+
+// alertDown sends a warning notifications/message to every session subscribed
+// to a backend that has no servers left in UP state, and allowed to read it,, since a resource update
+// alone does not tell clients that the backend cannot serve traffic
+func (w *StateWatcher) alertDown(backend string) {
+	uri := backendURI(backend)
+	slog.Warn("Backend has no servers left in UP state", "backend", backend)
+
+	w.broadcast(uri, newNotification("notifications/message", map[string]any{
+		"level":  mcp.LoggingLevelWarning,
+		"logger": "haproxy",
+		"data": map[string]any{
+			"message": fmt.Sprintf("Backend %s has no servers left in UP state", backend),
+			"backend": backend,
+			"uri":     uri,
+		},
+	}))
+}
+
+// newNotification builds a JSON-RPC notification with params
+func newNotification(method string, params map[string]any) mcp.JSONRPCNotification {
+	return mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: method,
+			Params: mcp.NotificationParams{AdditionalFields: params},
+		},
+	}
+}
+
+// broadcast sends notification to every session subscribed to uri whose
+// identity may still read it
+func (w *StateWatcher) broadcast(uri string, notification mcp.JSONRPCNotification) {
+	for _, sessionID := range w.subs.Subscribers(uri) {
+		if !w.subs.Allowed(sessionID, uri) {
+			slog.Warn("Withheld notification denied by policy", "session", sessionID, "method", notification.Method, "uri", uri)
+			continue
+		}
+		err := w.send(sessionID, notification)
+		if errors.Is(err, server.ErrSessionNotFound) {
+			w.subs.RemoveSession(sessionID)
+			continue
+		}
+		if err != nil {
+			slog.Warn("Failed to send notification", "session", sessionID, "method", notification.Method, "uri", uri, "error", err)
+			continue
+		}
+		slog.Debug("Sent notification", "session", sessionID, "method", notification.Method, "uri", uri)
+	}
+}
+
+// send delivers a notification through the first notifier that owns the session
+func (w *StateWatcher) send(sessionID string, notification mcp.JSONRPCNotification) error {
+	w.mu.Lock()
+	notifiers := w.notifiers
+	w.mu.Unlock()
+
+	for _, n := range notifiers {
+		if err := n(sessionID, notification); !errors.Is(err, server.ErrSessionNotFound) {
+			return err
+		}
+	}
+	return w.server.SendNotificationToSpecificClient(sessionID, notification.Method, notification.Params.AdditionalFields)
+}